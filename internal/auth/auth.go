@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"gaijin/internal/database"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -28,6 +30,49 @@ func (a *Auth) Middleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// APIMiddleware is Middleware for JSON endpoints: it answers 401 instead of redirecting to /login
+func (a *Auth) APIMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.IsAuthenticated(r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"User not authenticated"}`))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// AdminMiddleware only lets admins through; use it inside Middleware
+func (a *Auth) AdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.IsAdmin(r) {
+			http.Error(w, "Admins only", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// IsAdmin reports whether the current user is listed in the comma-separated ADMIN_USERS
+// environment variable
+func (a *Auth) IsAdmin(r *http.Request) bool {
+	userID, err := a.GetCurrentUser(r)
+	if err != nil {
+		return false
+	}
+	user, err := a.db.GetUserInfo(userID)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(os.Getenv("ADMIN_USERS"), ",") {
+		if strings.TrimSpace(name) != "" && strings.TrimSpace(name) == user.Username {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Auth) IsAuthenticated(r *http.Request) bool {
 	userID, err := a.GetCurrentUser(r)
 	return err == nil && userID > 0
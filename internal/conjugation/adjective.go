@@ -0,0 +1,198 @@
+package conjugation
+
+import (
+	"fmt"
+	"gaijin/internal/kana"
+	"strings"
+)
+
+// AdjectiveClass is an adjective's conjugation class
+type AdjectiveClass int
+
+const (
+	IAdjective   AdjectiveClass = iota // 高い
+	NaAdjective                        // 静か
+	IAdjectiveIi                       // いい, whose other forms come from よい: よくない, よかった
+)
+
+func (c AdjectiveClass) String() string {
+	switch c {
+	case IAdjective:
+		return "i-adjective"
+	case NaAdjective:
+		return "na-adjective"
+	case IAdjectiveIi:
+		return "i-adjective (いい)"
+	}
+	return fmt.Sprintf("AdjectiveClass(%d)", int(c))
+}
+
+// adjectivePOSClasses maps JMdict adjective part-of-speech codes to their class
+// adj-no (本物の) and adj-pn (この) don't conjugate, so they're left out
+var adjectivePOSClasses = map[string]AdjectiveClass{
+	"adj-i":  IAdjective,
+	"adj-ix": IAdjectiveIi,
+	"adj-na": NaAdjective,
+}
+
+// AdjectiveClassFromPOS returns the class of the first adjective code among a word's parts of speech
+// ok is false if none of them is an adjective class this package conjugates
+func AdjectiveClassFromPOS(codes []string) (class AdjectiveClass, ok bool) {
+	for _, code := range codes {
+		if class, ok := adjectivePOSClasses[code]; ok {
+			return class, true
+		}
+	}
+	return 0, false
+}
+
+// AdjectiveForm is a conjugated adjective form, named after its plain affirmative
+type AdjectiveForm int
+
+const (
+	AdjectiveNonPast     AdjectiveForm = iota // 高い, 静かだ
+	AdjectivePast                             // 高かった, 静かだった
+	AdjectiveTe                               // 高くて, 静かで
+	Adverbial                                 // 高く, 静かに
+	AdjectiveConditional                      // 高ければ, 静かなら
+	Seeming                                   // 高そう
+	Excessive                                 // 高すぎる
+)
+
+// adjectiveStems are the pieces of an adjective its forms are built from
+type adjectiveStems struct {
+	dict string // 高い, or 静か
+	stem string // 高, よ for いい, or 静か
+}
+
+// Adjective is an adjective in its dictionary form, ready to conjugate
+type Adjective struct {
+	Dictionary string
+	Reading    string // in kana, "" if it isn't known
+	Class      AdjectiveClass
+	stems      adjectiveStems
+	kana       adjectiveStems // the reading's stems
+}
+
+// NewAdjective checks that the dictionary form and its reading end the way their class needs and
+// works out their stems
+// Pass "" for the reading to use the dictionary form when it's kana.
+func NewAdjective(dictionary, reading string, class AdjectiveClass) (Adjective, error) {
+	s, err := newAdjectiveStems(dictionary, class)
+	if err != nil {
+		return Adjective{}, err
+	}
+	a := Adjective{Dictionary: dictionary, Class: class, stems: s}
+
+	if reading == "" && kana.IsKana(dictionary) {
+		reading = dictionary
+	}
+	if reading != "" {
+		if a.kana, err = newAdjectiveStems(reading, class); err != nil {
+			return Adjective{}, fmt.Errorf("reading %s: %w", reading, err)
+		}
+		a.Reading = reading
+	}
+	return a, nil
+}
+
+// newAdjectiveStems works out the stems of an adjective in its dictionary form, written or read
+func newAdjectiveStems(dictionary string, class AdjectiveClass) (adjectiveStems, error) {
+	switch class {
+	case IAdjective, IAdjectiveIi:
+		// いい and かっこいい conjugate from よい; 良い already does
+		if prefix, ok := strings.CutSuffix(dictionary, "いい"); class == IAdjectiveIi && ok {
+			return adjectiveStems{dict: dictionary, stem: prefix + "よ"}, nil
+		}
+		stem, ok := strings.CutSuffix(dictionary, "い")
+		if !ok || stem == "" {
+			return adjectiveStems{}, fmt.Errorf("%s doesn't end in い", dictionary)
+		}
+		return adjectiveStems{dict: dictionary, stem: stem}, nil
+	case NaAdjective:
+		if dictionary == "" {
+			return adjectiveStems{}, fmt.Errorf("empty na-adjective")
+		}
+		return adjectiveStems{dict: dictionary, stem: dictionary}, nil
+	}
+	return adjectiveStems{}, fmt.Errorf("unknown adjective class %v", class)
+}
+
+// Conjugate returns the adjective in a form
+func (a Adjective) Conjugate(form AdjectiveForm, polarity Polarity, politeness Politeness) Conjugation {
+	forms := a.stems.conjugate(a.Class, form, polarity, politeness)
+	c := Conjugation{Text: forms[0], Alts: forms[1:]}
+	if a.Reading != "" {
+		readings := a.kana.conjugate(a.Class, form, polarity, politeness)
+		c.Reading, c.AltReadings = readings[0], readings[1:]
+	}
+	if len(c.Alts) == 0 {
+		c.Alts, c.AltReadings = nil, nil
+	}
+	return c
+}
+
+// conjugate builds a form from the stems, followed by its alternatives
+func (s adjectiveStems) conjugate(class AdjectiveClass, form AdjectiveForm, polarity Polarity, politeness Politeness) []string {
+	// Each choice is the form followed by its alternatives
+	pick := func(plain, negative, polite, politeNegative []string) []string {
+		switch {
+		case polarity == Negative && politeness == Polite:
+			return politeNegative
+		case polarity == Negative:
+			return negative
+		case politeness == Polite:
+			return polite
+		}
+		return plain
+	}
+
+	st := s.stem
+	if class == NaAdjective {
+		// Negatives use the spoken じゃ, with the written では as an alternative
+		neg := func(rest string) []string { return []string{st + "じゃ" + rest, st + "では" + rest} }
+		switch form {
+		case AdjectiveNonPast:
+			return pick([]string{st + "だ"}, neg("ない"), []string{st + "です"}, []string{st + "じゃないです", st + "ではありません"})
+		case AdjectivePast:
+			return pick([]string{st + "だった"}, neg("なかった"), []string{st + "でした"}, []string{st + "じゃなかったです", st + "ではありませんでした"})
+		case AdjectiveTe:
+			// The connective forms have no polite versions, so polite speech uses the plain ones
+			return pick([]string{st + "で"}, neg("なくて"), []string{st + "で"}, neg("なくて"))
+		case Adverbial:
+			return pick([]string{st + "に"}, neg("なく"), []string{st + "に"}, neg("なく"))
+		case AdjectiveConditional:
+			return pick([]string{st + "なら", st + "だったら"}, append(neg("なければ"), st+"じゃなかったら"),
+				[]string{st + "なら", st + "だったら"}, append(neg("なければ"), st+"じゃなかったら"))
+		case Seeming:
+			return pick([]string{st + "そう"}, neg("なさそう"), []string{st + "そうです"}, neg("なさそうです"))
+		case Excessive:
+			return pick([]string{st + "すぎる"}, []string{st + "すぎない"}, []string{st + "すぎます"}, []string{st + "すぎません"})
+		}
+		return []string{""}
+	}
+
+	switch form {
+	case AdjectiveNonPast:
+		return pick([]string{s.dict}, []string{st + "くない"}, []string{s.dict + "です"}, []string{st + "くないです", st + "くありません"})
+	case AdjectivePast:
+		return pick([]string{st + "かった"}, []string{st + "くなかった"}, []string{st + "かったです"}, []string{st + "くなかったです", st + "くありませんでした"})
+	case AdjectiveTe:
+		return pick([]string{st + "くて"}, []string{st + "くなくて"}, []string{st + "くて"}, []string{st + "くなくて"})
+	case Adverbial:
+		return pick([]string{st + "く"}, []string{st + "くなく"}, []string{st + "く"}, []string{st + "くなく"})
+	case AdjectiveConditional:
+		return pick([]string{st + "ければ", st + "かったら"}, []string{st + "くなければ", st + "くなかったら"},
+			[]string{st + "ければ", st + "かったら"}, []string{st + "くなければ", st + "くなかったら"})
+	case Seeming:
+		// よさそう keeps a さ, like なさそう
+		sou := st + "そう"
+		if class == IAdjectiveIi {
+			sou = st + "さそう"
+		}
+		return pick([]string{sou}, []string{st + "くなさそう"}, []string{sou + "です"}, []string{st + "くなさそうです"})
+	case Excessive:
+		return pick([]string{st + "すぎる"}, []string{st + "すぎない"}, []string{st + "すぎます"}, []string{st + "すぎません"})
+	}
+	return []string{""}
+}
@@ -0,0 +1,119 @@
+package conjugation
+
+import (
+	"reflect"
+	"testing"
+)
+
+// adjectiveForms are an adjective's forms in one polarity and politeness, in the order of the
+// AdjectiveForm constants
+type adjectiveForms [Excessive + 1]string
+
+func TestAdjectiveForms(t *testing.T) {
+	tests := []struct {
+		adjective  string
+		class      AdjectiveClass
+		polarity   Polarity
+		politeness Politeness
+		want       adjectiveForms
+	}{
+		{"高い", IAdjective, Affirmative, Plain, adjectiveForms{"高い", "高かった", "高くて", "高く", "高ければ", "高そう", "高すぎる"}},
+		{"高い", IAdjective, Negative, Plain, adjectiveForms{"高くない", "高くなかった", "高くなくて", "高くなく", "高くなければ", "高くなさそう", "高すぎない"}},
+		{"高い", IAdjective, Affirmative, Polite, adjectiveForms{"高いです", "高かったです", "高くて", "高く", "高ければ", "高そうです", "高すぎます"}},
+		{"高い", IAdjective, Negative, Polite, adjectiveForms{"高くないです", "高くなかったです", "高くなくて", "高くなく", "高くなければ", "高くなさそうです", "高すぎません"}},
+		{"いい", IAdjectiveIi, Affirmative, Plain, adjectiveForms{"いい", "よかった", "よくて", "よく", "よければ", "よさそう", "よすぎる"}},
+		{"いい", IAdjectiveIi, Negative, Plain, adjectiveForms{"よくない", "よくなかった", "よくなくて", "よくなく", "よくなければ", "よくなさそう", "よすぎない"}},
+		{"いい", IAdjectiveIi, Affirmative, Polite, adjectiveForms{"いいです", "よかったです", "よくて", "よく", "よければ", "よさそうです", "よすぎます"}},
+		{"かっこいい", IAdjectiveIi, Negative, Plain, adjectiveForms{"かっこよくない", "かっこよくなかった", "かっこよくなくて", "かっこよくなく", "かっこよくなければ", "かっこよくなさそう", "かっこよすぎない"}},
+		{"よい", IAdjectiveIi, Affirmative, Plain, adjectiveForms{"よい", "よかった", "よくて", "よく", "よければ", "よさそう", "よすぎる"}},
+		{"静か", NaAdjective, Affirmative, Plain, adjectiveForms{"静かだ", "静かだった", "静かで", "静かに", "静かなら", "静かそう", "静かすぎる"}},
+		{"静か", NaAdjective, Negative, Plain, adjectiveForms{"静かじゃない", "静かじゃなかった", "静かじゃなくて", "静かじゃなく", "静かじゃなければ", "静かじゃなさそう", "静かすぎない"}},
+		{"静か", NaAdjective, Affirmative, Polite, adjectiveForms{"静かです", "静かでした", "静かで", "静かに", "静かなら", "静かそうです", "静かすぎます"}},
+		{"静か", NaAdjective, Negative, Polite, adjectiveForms{"静かじゃないです", "静かじゃなかったです", "静かじゃなくて", "静かじゃなく", "静かじゃなければ", "静かじゃなさそうです", "静かすぎません"}},
+	}
+
+	for _, tt := range tests {
+		a, err := NewAdjective(tt.adjective, "", tt.class)
+		if err != nil {
+			t.Fatalf("NewAdjective(%s, %v): %v", tt.adjective, tt.class, err)
+		}
+		for form, want := range tt.want {
+			if got := a.Conjugate(AdjectiveForm(form), tt.polarity, tt.politeness).Text; got != want {
+				t.Errorf("%s form %d (%d, %d) = %s, want %s", tt.adjective, form, tt.polarity, tt.politeness, got, want)
+			}
+		}
+	}
+}
+
+func TestAdjectiveReadings(t *testing.T) {
+	tests := []struct {
+		adjective, reading string
+		class              AdjectiveClass
+		form               AdjectiveForm
+		polarity           Polarity
+		politeness         Politeness
+		want               Conjugation
+	}{
+		{"高い", "たかい", IAdjective, AdjectivePast, Affirmative, Plain, Conjugation{Text: "高かった", Reading: "たかかった"}},
+		{"高い", "たかい", IAdjective, AdjectiveNonPast, Negative, Polite,
+			Conjugation{Text: "高くないです", Reading: "たかくないです", Alts: []string{"高くありません"}, AltReadings: []string{"たかくありません"}}},
+		{"良い", "いい", IAdjectiveIi, AdjectiveNonPast, Negative, Plain, Conjugation{Text: "良くない", Reading: "よくない"}},
+		{"良い", "いい", IAdjectiveIi, Seeming, Affirmative, Plain, Conjugation{Text: "良さそう", Reading: "よさそう"}},
+		{"静か", "しずか", NaAdjective, AdjectiveConditional, Affirmative, Plain,
+			Conjugation{Text: "静かなら", Reading: "しずかなら", Alts: []string{"静かだったら"}, AltReadings: []string{"しずかだったら"}}},
+		{"静か", "しずか", NaAdjective, AdjectiveNonPast, Negative, Plain,
+			Conjugation{Text: "静かじゃない", Reading: "しずかじゃない", Alts: []string{"静かではない"}, AltReadings: []string{"しずかではない"}}},
+		{"じゃま", "", NaAdjective, AdjectiveNonPast, Negative, Plain,
+			Conjugation{Text: "じゃまじゃない", Reading: "じゃまじゃない", Alts: []string{"じゃまではない"}, AltReadings: []string{"じゃまではない"}}},
+		{"高い", "", IAdjective, AdjectiveNonPast, Affirmative, Plain, Conjugation{Text: "高い"}},
+	}
+
+	for _, tt := range tests {
+		a, err := NewAdjective(tt.adjective, tt.reading, tt.class)
+		if err != nil {
+			t.Fatalf("NewAdjective(%s, %s, %v): %v", tt.adjective, tt.reading, tt.class, err)
+		}
+		if got := a.Conjugate(tt.form, tt.polarity, tt.politeness); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s form %d (%d, %d) = %+v, want %+v", tt.adjective, tt.form, tt.polarity, tt.politeness, got, tt.want)
+		}
+	}
+}
+
+func TestNewAdjectiveRejectsWrongEnding(t *testing.T) {
+	tests := []struct {
+		adjective, reading string
+		class              AdjectiveClass
+	}{
+		{"静か", "", IAdjective},
+		{"い", "", IAdjective},
+		{"", "", NaAdjective},
+		{"高い", "たか", IAdjective},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewAdjective(tt.adjective, tt.reading, tt.class); err == nil {
+			t.Errorf("NewAdjective(%s, %s, %v) succeeded, want an error", tt.adjective, tt.reading, tt.class)
+		}
+	}
+}
+
+func TestAdjectiveClassFromPOS(t *testing.T) {
+	tests := []struct {
+		codes []string
+		want  AdjectiveClass
+		ok    bool
+	}{
+		{[]string{"adj-i"}, IAdjective, true},
+		{[]string{"adj-ix"}, IAdjectiveIi, true},
+		{[]string{"n", "adj-na"}, NaAdjective, true},
+		{[]string{"adj-no", "n"}, 0, false},
+		{[]string{"v1"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := AdjectiveClassFromPOS(tt.codes)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("AdjectiveClassFromPOS(%v) = %v, %v, want %v, %v", tt.codes, got, ok, tt.want, tt.ok)
+		}
+	}
+}
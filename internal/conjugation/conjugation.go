@@ -0,0 +1,437 @@
+// Package conjugation conjugates Japanese verbs into the forms the verb page shows,
+// plain or polite and affirmative or negative.
+package conjugation
+
+import (
+	"fmt"
+	"gaijin/internal/kana"
+	"strings"
+)
+
+// Class is a verb's conjugation class
+type Class int
+
+const (
+	Ichidan Class = iota // 食べる
+	Godan                // 書く
+	Suru                 // する, and nouns + する such as 勉強する
+	Kuru                 // 来る
+
+	// Verbs that break one of their class's rules
+	IchidanKureru  // くれる: imperative くれ
+	GodanIku       // 行く: 行って, 行った
+	GodanAru       // ある: negative ない
+	GodanHonorific // くださる, なさる, いらっしゃる: ください, くださいます
+	GodanU         // 問う, 請う: 問うて, 問うた
+)
+
+func (c Class) String() string {
+	switch c {
+	case Ichidan:
+		return "ichidan"
+	case Godan:
+		return "godan"
+	case Suru:
+		return "suru"
+	case Kuru:
+		return "kuru"
+	case IchidanKureru:
+		return "ichidan (くれる)"
+	case GodanIku:
+		return "godan (行く)"
+	case GodanAru:
+		return "godan (ある)"
+	case GodanHonorific:
+		return "godan (くださる)"
+	case GodanU:
+		return "godan (問う)"
+	}
+	return fmt.Sprintf("Class(%d)", int(c))
+}
+
+// posClasses maps JMdict verb part-of-speech codes to their class
+// vs-s (愛する) and vz (信ずる) verbs mix classes, so they're left to Classify
+var posClasses = map[string]Class{
+	"v1":    Ichidan,
+	"v1-s":  IchidanKureru,
+	"v5u":   Godan,
+	"v5k":   Godan,
+	"v5g":   Godan,
+	"v5s":   Godan,
+	"v5t":   Godan,
+	"v5n":   Godan,
+	"v5b":   Godan,
+	"v5m":   Godan,
+	"v5r":   Godan,
+	"v5k-s": GodanIku,
+	"v5r-i": GodanAru,
+	"v5aru": GodanHonorific,
+	"v5u-s": GodanU,
+	"vk":    Kuru,
+	"vs-i":  Suru,
+}
+
+// ClassFromPOS returns the class of the first verb code among a word's parts of speech
+// ok is false if none of them is a verb class this package conjugates
+func ClassFromPOS(codes []string) (class Class, ok bool) {
+	for _, code := range codes {
+		if class, ok := posClasses[code]; ok {
+			return class, true
+		}
+	}
+	return 0, false
+}
+
+// classifiedPOS are the verb codes ClassFromPOS leaves to Classify
+var classifiedPOS = map[string]bool{"vs-s": true, "vz": true}
+
+// IsVerbPOS reports whether a word's parts of speech make it a verb this package conjugates,
+// by ClassFromPOS or, for vs-s and vz, by Classify
+// Nouns that take する (vs) and adverbs aren't verbs themselves.
+func IsVerbPOS(codes []string) bool {
+	if _, ok := ClassFromPOS(codes); ok {
+		return true
+	}
+	for _, code := range codes {
+		if classifiedPOS[code] {
+			return true
+		}
+	}
+	return false
+}
+
+// Form is a conjugated form, named after its plain affirmative
+type Form int
+
+const (
+	NonPast     Form = iota // 食べる
+	Past                    // 食べた
+	Te                      // 食べて
+	Progressive             // 食べている
+	JustDone                // 食べたばかり
+	Wish                    // 食べたらいいのに
+	Conditional             // 食べれば
+	Tara                    // 食べたら
+	Imperative              // 食べろ
+	Volitional              // 食べよう
+	Potential               // 食べられる
+	Causative               // 食べさせる
+	Passive                 // 食べられる
+	Obligation              // 食べなければならない
+	Desire                  // 食べたい
+)
+
+// Polarity is whether a form is affirmative or negative
+type Polarity int
+
+const (
+	Affirmative Polarity = iota
+	Negative
+)
+
+// Politeness is whether a form is plain or polite (ます) speech
+type Politeness int
+
+const (
+	Plain Politeness = iota
+	Polite
+)
+
+// Conjugation is a conjugated form, with other ways of saying the same thing
+type Conjugation struct {
+	Text        string
+	Reading     string   // Text in kana, "" if the verb's reading isn't known
+	Alts        []string // e.g. 食べたら for 食べれば
+	AltReadings []string // Alts in kana, in the same order
+}
+
+// stems are the pieces of a verb its forms are built from, each with the verb's prefix
+type stems struct {
+	dict      string
+	nai       string // before ない
+	masu      string // before ます
+	tai       string // before たい; the ます stem, except for honorific verbs (くださいます, くださりたい)
+	te        string
+	ta        string
+	ba        string // before ば
+	imper     string
+	volition  string
+	potential string
+	passive   string
+	causative string
+}
+
+// godanRows are the godan endings with their あ, い, え and お row kana and て/た forms
+var godanRows = []struct{ u, a, i, e, o, te, ta string }{
+	{"う", "わ", "い", "え", "お", "って", "った"},
+	{"く", "か", "き", "け", "こ", "いて", "いた"},
+	{"ぐ", "が", "ぎ", "げ", "ご", "いで", "いだ"},
+	{"す", "さ", "し", "せ", "そ", "して", "した"},
+	{"つ", "た", "ち", "て", "と", "って", "った"},
+	{"ぬ", "な", "に", "ね", "の", "んで", "んだ"},
+	{"ぶ", "ば", "び", "べ", "ぼ", "んで", "んだ"},
+	{"む", "ま", "み", "め", "も", "んで", "んだ"},
+	{"る", "ら", "り", "れ", "ろ", "って", "った"},
+}
+
+// Verb is a verb in its dictionary form, ready to conjugate
+type Verb struct {
+	Dictionary string
+	Reading    string // in kana, "" if it isn't known
+	Class      Class
+	stems      stems
+	kana       stems // the reading's stems
+}
+
+// New checks that the dictionary form and its reading end the way their class needs and
+// works out their stems
+// Pass "" for the reading to use the dictionary form when it's kana (来る and 為る are read くる
+// and する); a kanji verb without a reading conjugates without one.
+func New(dictionary, reading string, class Class) (Verb, error) {
+	s, err := newStems(dictionary, class)
+	if err != nil {
+		return Verb{}, err
+	}
+	v := Verb{Dictionary: dictionary, Class: class, stems: s}
+
+	if reading == "" {
+		reading = guessReading(dictionary)
+	}
+	if reading != "" {
+		if v.kana, err = newStems(reading, class); err != nil {
+			return Verb{}, fmt.Errorf("reading %s: %w", reading, err)
+		}
+		v.Reading = reading
+	}
+	return v, nil
+}
+
+// guessReading returns the reading of a verb that's all kana, but for 来る or 為る at the end
+func guessReading(dictionary string) string {
+	if prefix, ok := strings.CutSuffix(dictionary, "来る"); ok {
+		dictionary = prefix + "くる"
+	} else if prefix, ok := strings.CutSuffix(dictionary, "為る"); ok {
+		dictionary = prefix + "する"
+	}
+	if kana.IsKana(dictionary) {
+		return dictionary
+	}
+	return ""
+}
+
+// newStems works out the stems of a verb in its dictionary form, written or read
+func newStems(dictionary string, class Class) (stems, error) {
+	var s stems
+	switch class {
+	case Ichidan, IchidanKureru:
+		stem, ok := strings.CutSuffix(dictionary, "る")
+		if !ok || stem == "" {
+			return stems{}, fmt.Errorf("%s doesn't end in る", dictionary)
+		}
+		s = stems{dict: dictionary, nai: stem, masu: stem, tai: stem, te: stem + "て", ta: stem + "た", ba: stem + "れ",
+			imper: stem + "ろ", volition: stem + "よう", potential: stem + "られる", passive: stem + "られる", causative: stem + "させる"}
+		if class == IchidanKureru {
+			s.imper = stem
+		}
+	case Godan, GodanIku, GodanAru, GodanHonorific, GodanU:
+		for _, g := range godanRows {
+			stem, ok := strings.CutSuffix(dictionary, g.u)
+			if !ok || stem == "" {
+				continue
+			}
+			s = stems{dict: dictionary, nai: stem + g.a, masu: stem + g.i, tai: stem + g.i, te: stem + g.te, ta: stem + g.ta,
+				ba: stem + g.e, imper: stem + g.e, volition: stem + g.o + "う", potential: stem + g.e + "る",
+				passive: stem + g.a + "れる", causative: stem + g.a + "せる"}
+
+			switch {
+			case class == GodanIku && hasAnySuffix(dictionary, "行く", "逝く", "往く", "いく", "ゆく"):
+				s.te, s.ta = stem+"って", stem+"った"
+			case class == GodanAru && hasAnySuffix(dictionary, "ある", "有る", "在る"):
+				// ある has no あら- stem: its negative is plain ない
+				runes := []rune(stem)
+				s.nai = string(runes[:len(runes)-1])
+			case class == GodanHonorific && g.u == "る":
+				s.masu, s.imper = stem+"い", stem+"い"
+			case class == GodanU && g.u == "う":
+				s.te, s.ta = stem+"うて", stem+"うた"
+			case class != Godan:
+				return stems{}, fmt.Errorf("%s doesn't end like a %v verb", dictionary, class)
+			}
+			return s, nil
+		}
+		return stems{}, fmt.Errorf("%s doesn't end in a godan ending", dictionary)
+	case Suru:
+		prefix, ok := strings.CutSuffix(dictionary, "する")
+		if !ok {
+			prefix, ok = strings.CutSuffix(dictionary, "為る")
+		}
+		if !ok {
+			return stems{}, fmt.Errorf("%s doesn't end in する", dictionary)
+		}
+		s = stems{dict: dictionary, nai: prefix + "し", masu: prefix + "し", tai: prefix + "し", te: prefix + "して", ta: prefix + "した",
+			ba: prefix + "すれ", imper: prefix + "しろ", volition: prefix + "しよう", potential: prefix + "できる",
+			passive: prefix + "される", causative: prefix + "させる"}
+	case Kuru:
+		if prefix, ok := strings.CutSuffix(dictionary, "来る"); ok {
+			s = stems{dict: dictionary, nai: prefix + "来", masu: prefix + "来", tai: prefix + "来", te: prefix + "来て", ta: prefix + "来た",
+				ba: prefix + "来れ", imper: prefix + "来い", volition: prefix + "来よう", potential: prefix + "来られる",
+				passive: prefix + "来られる", causative: prefix + "来させる"}
+		} else if prefix, ok := strings.CutSuffix(dictionary, "くる"); ok {
+			s = stems{dict: dictionary, nai: prefix + "こ", masu: prefix + "き", tai: prefix + "き", te: prefix + "きて", ta: prefix + "きた",
+				ba: prefix + "くれ", imper: prefix + "こい", volition: prefix + "こよう", potential: prefix + "こられる",
+				passive: prefix + "こられる", causative: prefix + "こさせる"}
+		} else {
+			return stems{}, fmt.Errorf("%s doesn't end in 来る", dictionary)
+		}
+	default:
+		return stems{}, fmt.Errorf("unknown verb class %v", class)
+	}
+	return s, nil
+}
+
+// Conjugate returns the verb in a form
+func (v Verb) Conjugate(form Form, polarity Polarity, politeness Politeness) Conjugation {
+	// Each form is built twice, from the written stems and from the reading's
+	build := func(f func(s stems) string) (text, reading string) {
+		if v.Reading != "" {
+			reading = f(v.kana)
+		}
+		return f(v.stems), reading
+	}
+
+	var c Conjugation
+	c.Text, c.Reading = build(func(s stems) string { return s.conjugate(form, polarity, politeness) })
+
+	alt := func(f func(s stems) string) {
+		text, reading := build(f)
+		c.Alts = append(c.Alts, text)
+		if reading != "" {
+			c.AltReadings = append(c.AltReadings, reading)
+		}
+	}
+	switch {
+	case form == Conditional:
+		alt(func(s stems) string { return s.conjugate(Tara, polarity, politeness) })
+	case form == Imperative && v.Class == Suru && polarity == Affirmative && politeness == Plain:
+		alt(func(s stems) string { return strings.TrimSuffix(s.imper, "しろ") + "せよ" })
+	}
+	return c
+}
+
+// conjugate builds a form from the stems
+func (s stems) conjugate(form Form, polarity Polarity, politeness Politeness) string {
+	pick := func(plain, negative, polite, politeNegative string) string {
+		switch {
+		case polarity == Negative && politeness == Polite:
+			return politeNegative
+		case polarity == Negative:
+			return negative
+		case politeness == Polite:
+			return polite
+		}
+		return plain
+	}
+
+	switch form {
+	case NonPast:
+		return pick(s.dict, s.nai+"ない", s.masu+"ます", s.masu+"ません")
+	case Past:
+		return pick(s.ta, s.nai+"なかった", s.masu+"ました", s.masu+"ませんでした")
+	case Te:
+		return pick(s.te, s.nai+"なくて", s.masu+"まして", s.masu+"ませんで")
+	case Progressive:
+		return s.te + pick("いる", "いない", "います", "いません")
+	case JustDone:
+		return pick(s.ta+"ばかり", s.nai+"なかったばかり", s.ta+"ばかりです", s.nai+"なかったばかりです")
+	case Wish:
+		return pick(s.ta+"らいいのに", s.nai+"なかったらいいのに", s.ta+"らいいのですが", s.nai+"なかったらいいのですが")
+	case Conditional:
+		// ば has no everyday polite form, so polite speech uses the plain one
+		return pick(s.ba+"ば", s.nai+"なければ", s.ba+"ば", s.nai+"なければ")
+	case Tara:
+		return pick(s.ta+"ら", s.nai+"なかったら", s.masu+"ましたら", s.masu+"ませんでしたら")
+	case Imperative:
+		return pick(s.imper, s.dict+"な", s.te+"ください", s.nai+"ないでください")
+	case Volitional:
+		return pick(s.volition, s.nai+"ないでおこう", s.masu+"ましょう", s.nai+"ないでおきましょう")
+	case Potential, Causative, Passive:
+		// These are ichidan verbs themselves (書ける → 書けない)
+		derived := s.passive
+		if form == Potential {
+			derived = s.potential
+		} else if form == Causative {
+			derived = s.causative
+		}
+		stem := strings.TrimSuffix(derived, "る")
+		return pick(derived, stem+"ない", stem+"ます", stem+"ません")
+	case Obligation:
+		return pick(s.nai+"なければならない", s.nai+"なくてもいい", s.nai+"なければなりません", s.nai+"なくてもいいです")
+	case Desire:
+		return pick(s.tai+"たい", s.tai+"たくない", s.tai+"たいです", s.tai+"たくないです")
+	}
+	return ""
+}
+
+// Classify guesses a verb's class from its spelling, for verbs without dictionary parts of speech
+// The reading is used to see the kana behind a kanji (食べる is たべる); pass "" if it isn't known.
+// After the exceptions, 来る is kuru unless the reading says otherwise, る after an い or え row
+// kana is ichidan and anything else is godan.
+func Classify(verb, reading string) Class {
+	if reading == "" {
+		reading = verb
+	}
+	for _, s := range []string{verb, reading} {
+		if class, ok := exceptions[s]; ok {
+			return class
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(verb, "する") || strings.HasSuffix(verb, "為る"):
+		return Suru
+	case strings.HasSuffix(verb, "来る") && (reading == verb || strings.HasSuffix(reading, "くる")) || verb == "くる":
+		return Kuru
+	}
+
+	runes := []rune(reading)
+	if len(runes) < 2 || runes[len(runes)-1] != 'る' {
+		return Godan
+	}
+	if strings.ContainsRune("いきぎしじちにひびぴみりえけげせぜてでねへべぺめれ", runes[len(runes)-2]) {
+		return Ichidan
+	}
+	return Godan
+}
+
+// exceptions are common verbs whose class the spelling gets wrong, by written form or reading
+// Readings shared with an ichidan verb (かえる is 帰る and 変える) are only listed in kanji
+var exceptions = map[string]Class{
+	"行く": GodanIku, "いく": GodanIku, "逝く": GodanIku,
+	"ある": GodanAru, "有る": GodanAru, "在る": GodanAru,
+	"くださる": GodanHonorific, "下さる": GodanHonorific, "なさる": GodanHonorific, "為さる": GodanHonorific,
+	"いらっしゃる": GodanHonorific, "おっしゃる": GodanHonorific, "仰る": GodanHonorific, "ござる": GodanHonorific,
+	"くれる": IchidanKureru, "呉れる": IchidanKureru,
+	"問う": GodanU, "請う": GodanU, "乞う": GodanU,
+
+	// 出来る is read できる, not as a 来る compound
+	"出来る": Ichidan,
+
+	// Godan verbs that look ichidan
+	"帰る": Godan, "走る": Godan, "はしる": Godan, "入る": Godan, "はいる": Godan, "知る": Godan, "しる": Godan,
+	"切る": Godan, "要る": Godan, "限る": Godan, "かぎる": Godan, "喋る": Godan, "しゃべる": Godan,
+	"滑る": Godan, "減る": Godan, "参る": Godan, "まいる": Godan, "蹴る": Godan, "ける": Godan,
+	"焦る": Godan, "握る": Godan, "にぎる": Godan, "練る": Godan, "照る": Godan, "散る": Godan, "ちる": Godan,
+	"湿る": Godan, "茂る": Godan, "しげる": Godan, "遮る": Godan, "さえぎる": Godan, "嘲る": Godan, "あざける": Godan,
+	"捻る": Godan, "ひねる": Godan, "覆る": Godan, "くつがえる": Godan, "甦る": Godan, "蘇る": Godan, "よみがえる": Godan,
+	"混じる": Godan, "交じる": Godan, "まじる": Godan, "罵る": Godan, "ののしる": Godan,
+}
+
+// hasAnySuffix reports whether s ends with one of the suffixes
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
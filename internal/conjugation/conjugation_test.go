@@ -0,0 +1,345 @@
+package conjugation
+
+import (
+	"reflect"
+	"testing"
+)
+
+// plainForms are a verb's plain affirmative forms, in the order of the Form constants
+type plainForms [Desire + 1]string
+
+func TestPlainForms(t *testing.T) {
+	tests := []struct {
+		verb  string
+		class Class
+		want  plainForms
+	}{
+		{"買う", Godan, plainForms{"買う", "買った", "買って", "買っている", "買ったばかり", "買ったらいいのに", "買えば", "買ったら",
+			"買え", "買おう", "買える", "買わせる", "買われる", "買わなければならない", "買いたい"}},
+		{"書く", Godan, plainForms{"書く", "書いた", "書いて", "書いている", "書いたばかり", "書いたらいいのに", "書けば", "書いたら",
+			"書け", "書こう", "書ける", "書かせる", "書かれる", "書かなければならない", "書きたい"}},
+		{"泳ぐ", Godan, plainForms{"泳ぐ", "泳いだ", "泳いで", "泳いでいる", "泳いだばかり", "泳いだらいいのに", "泳げば", "泳いだら",
+			"泳げ", "泳ごう", "泳げる", "泳がせる", "泳がれる", "泳がなければならない", "泳ぎたい"}},
+		{"話す", Godan, plainForms{"話す", "話した", "話して", "話している", "話したばかり", "話したらいいのに", "話せば", "話したら",
+			"話せ", "話そう", "話せる", "話させる", "話される", "話さなければならない", "話したい"}},
+		{"待つ", Godan, plainForms{"待つ", "待った", "待って", "待っている", "待ったばかり", "待ったらいいのに", "待てば", "待ったら",
+			"待て", "待とう", "待てる", "待たせる", "待たれる", "待たなければならない", "待ちたい"}},
+		{"死ぬ", Godan, plainForms{"死ぬ", "死んだ", "死んで", "死んでいる", "死んだばかり", "死んだらいいのに", "死ねば", "死んだら",
+			"死ね", "死のう", "死ねる", "死なせる", "死なれる", "死ななければならない", "死にたい"}},
+		{"遊ぶ", Godan, plainForms{"遊ぶ", "遊んだ", "遊んで", "遊んでいる", "遊んだばかり", "遊んだらいいのに", "遊べば", "遊んだら",
+			"遊べ", "遊ぼう", "遊べる", "遊ばせる", "遊ばれる", "遊ばなければならない", "遊びたい"}},
+		{"読む", Godan, plainForms{"読む", "読んだ", "読んで", "読んでいる", "読んだばかり", "読んだらいいのに", "読めば", "読んだら",
+			"読め", "読もう", "読める", "読ませる", "読まれる", "読まなければならない", "読みたい"}},
+		{"取る", Godan, plainForms{"取る", "取った", "取って", "取っている", "取ったばかり", "取ったらいいのに", "取れば", "取ったら",
+			"取れ", "取ろう", "取れる", "取らせる", "取られる", "取らなければならない", "取りたい"}},
+		{"食べる", Ichidan, plainForms{"食べる", "食べた", "食べて", "食べている", "食べたばかり", "食べたらいいのに", "食べれば", "食べたら",
+			"食べろ", "食べよう", "食べられる", "食べさせる", "食べられる", "食べなければならない", "食べたい"}},
+		{"見る", Ichidan, plainForms{"見る", "見た", "見て", "見ている", "見たばかり", "見たらいいのに", "見れば", "見たら",
+			"見ろ", "見よう", "見られる", "見させる", "見られる", "見なければならない", "見たい"}},
+		{"する", Suru, plainForms{"する", "した", "して", "している", "したばかり", "したらいいのに", "すれば", "したら",
+			"しろ", "しよう", "できる", "させる", "される", "しなければならない", "したい"}},
+		{"勉強する", Suru, plainForms{"勉強する", "勉強した", "勉強して", "勉強している", "勉強したばかり", "勉強したらいいのに", "勉強すれば", "勉強したら",
+			"勉強しろ", "勉強しよう", "勉強できる", "勉強させる", "勉強される", "勉強しなければならない", "勉強したい"}},
+		{"来る", Kuru, plainForms{"来る", "来た", "来て", "来ている", "来たばかり", "来たらいいのに", "来れば", "来たら",
+			"来い", "来よう", "来られる", "来させる", "来られる", "来なければならない", "来たい"}},
+		{"くる", Kuru, plainForms{"くる", "きた", "きて", "きている", "きたばかり", "きたらいいのに", "くれば", "きたら",
+			"こい", "こよう", "こられる", "こさせる", "こられる", "こなければならない", "きたい"}},
+	}
+
+	for _, tt := range tests {
+		v, err := New(tt.verb, "", tt.class)
+		if err != nil {
+			t.Fatalf("New(%s, %v): %v", tt.verb, tt.class, err)
+		}
+		for form, want := range tt.want {
+			if got := v.Conjugate(Form(form), Affirmative, Plain).Text; got != want {
+				t.Errorf("%s form %d = %s, want %s", tt.verb, form, got, want)
+			}
+		}
+	}
+}
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		verb       string
+		class      Class
+		form       Form
+		polarity   Polarity
+		politeness Politeness
+		want       string
+	}{
+		{"書く", Godan, NonPast, Negative, Plain, "書かない"},
+		{"書く", Godan, NonPast, Affirmative, Polite, "書きます"},
+		{"書く", Godan, NonPast, Negative, Polite, "書きません"},
+		{"買う", Godan, NonPast, Negative, Plain, "買わない"},
+		{"書く", Godan, Past, Negative, Plain, "書かなかった"},
+		{"書く", Godan, Past, Affirmative, Polite, "書きました"},
+		{"書く", Godan, Past, Negative, Polite, "書きませんでした"},
+		{"書く", Godan, Progressive, Negative, Polite, "書いていません"},
+		{"書く", Godan, JustDone, Affirmative, Polite, "書いたばかりです"},
+		{"書く", Godan, Wish, Negative, Plain, "書かなかったらいいのに"},
+		{"書く", Godan, Conditional, Negative, Plain, "書かなければ"},
+		{"書く", Godan, Conditional, Affirmative, Polite, "書けば"},
+		{"書く", Godan, Tara, Affirmative, Polite, "書きましたら"},
+		{"書く", Godan, Imperative, Negative, Plain, "書くな"},
+		{"書く", Godan, Imperative, Affirmative, Polite, "書いてください"},
+		{"書く", Godan, Imperative, Negative, Polite, "書かないでください"},
+		{"書く", Godan, Volitional, Affirmative, Polite, "書きましょう"},
+		{"書く", Godan, Potential, Negative, Plain, "書けない"},
+		{"書く", Godan, Causative, Affirmative, Polite, "書かせます"},
+		{"書く", Godan, Passive, Negative, Polite, "書かれません"},
+		{"書く", Godan, Obligation, Negative, Plain, "書かなくてもいい"},
+		{"書く", Godan, Obligation, Affirmative, Polite, "書かなければなりません"},
+		{"書く", Godan, Desire, Negative, Polite, "書きたくないです"},
+		{"食べる", Ichidan, NonPast, Negative, Plain, "食べない"},
+		{"食べる", Ichidan, Te, Negative, Plain, "食べなくて"},
+		{"食べる", Ichidan, Potential, Affirmative, Polite, "食べられます"},
+		{"する", Suru, NonPast, Negative, Plain, "しない"},
+		{"する", Suru, Past, Negative, Polite, "しませんでした"},
+		{"する", Suru, Potential, Negative, Plain, "できない"},
+		{"勉強する", Suru, NonPast, Affirmative, Polite, "勉強します"},
+		{"来る", Kuru, NonPast, Negative, Plain, "来ない"},
+		{"来る", Kuru, Past, Affirmative, Polite, "来ました"},
+		{"くる", Kuru, NonPast, Negative, Plain, "こない"},
+		{"くる", Kuru, NonPast, Affirmative, Polite, "きます"},
+	}
+
+	for _, tt := range tests {
+		v, err := New(tt.verb, "", tt.class)
+		if err != nil {
+			t.Fatalf("New(%s, %v): %v", tt.verb, tt.class, err)
+		}
+		if got := v.Conjugate(tt.form, tt.polarity, tt.politeness).Text; got != tt.want {
+			t.Errorf("%s form %d (%d, %d) = %s, want %s", tt.verb, tt.form, tt.polarity, tt.politeness, got, tt.want)
+		}
+	}
+}
+
+func TestAlts(t *testing.T) {
+	tests := []struct {
+		verb  string
+		class Class
+		form  Form
+		want  []string
+	}{
+		{"書く", Godan, Conditional, []string{"書いたら"}},
+		{"書く", Godan, Past, nil},
+		{"する", Suru, Imperative, []string{"せよ"}},
+		{"来る", Kuru, Past, nil},
+		{"来る", Kuru, Conditional, []string{"来たら"}},
+		{"くる", Kuru, Past, nil},
+	}
+
+	for _, tt := range tests {
+		v, err := New(tt.verb, "", tt.class)
+		if err != nil {
+			t.Fatalf("New(%s, %v): %v", tt.verb, tt.class, err)
+		}
+		if got := v.Conjugate(tt.form, Affirmative, Plain).Alts; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s form %d alts = %v, want %v", tt.verb, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestReadings(t *testing.T) {
+	tests := []struct {
+		verb, reading string
+		class         Class
+		form          Form
+		polarity      Polarity
+		politeness    Politeness
+		want          Conjugation
+	}{
+		{"食べる", "たべる", Ichidan, Past, Affirmative, Plain, Conjugation{Text: "食べた", Reading: "たべた"}},
+		{"書く", "かく", Godan, Conditional, Affirmative, Plain,
+			Conjugation{Text: "書けば", Reading: "かけば", Alts: []string{"書いたら"}, AltReadings: []string{"かいたら"}}},
+		{"来る", "くる", Kuru, NonPast, Negative, Plain, Conjugation{Text: "来ない", Reading: "こない"}},
+		{"来る", "くる", Kuru, NonPast, Affirmative, Polite, Conjugation{Text: "来ます", Reading: "きます"}},
+		{"来る", "", Kuru, Imperative, Affirmative, Plain, Conjugation{Text: "来い", Reading: "こい"}},
+		{"持って来る", "", Kuru, Past, Affirmative, Plain, Conjugation{Text: "持って来た"}},
+		{"出来る", "できる", Classify("出来る", ""), NonPast, Negative, Plain, Conjugation{Text: "出来ない", Reading: "できない"}},
+		{"勉強する", "べんきょうする", Suru, Imperative, Affirmative, Plain,
+			Conjugation{Text: "勉強しろ", Reading: "べんきょうしろ", Alts: []string{"勉強せよ"}, AltReadings: []string{"べんきょうせよ"}}},
+		{"為る", "", Suru, NonPast, Negative, Plain, Conjugation{Text: "しない", Reading: "しない"}},
+		{"行く", "いく", GodanIku, Te, Affirmative, Plain, Conjugation{Text: "行って", Reading: "いって"}},
+		{"ある", "", GodanAru, NonPast, Negative, Plain, Conjugation{Text: "ない", Reading: "ない"}},
+		{"食べる", "", Ichidan, NonPast, Affirmative, Plain, Conjugation{Text: "食べる"}},
+	}
+
+	for _, tt := range tests {
+		v, err := New(tt.verb, tt.reading, tt.class)
+		if err != nil {
+			t.Fatalf("New(%s, %s, %v): %v", tt.verb, tt.reading, tt.class, err)
+		}
+		if got := v.Conjugate(tt.form, tt.polarity, tt.politeness); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s form %d (%d, %d) = %+v, want %+v", tt.verb, tt.form, tt.polarity, tt.politeness, got, tt.want)
+		}
+	}
+}
+
+func TestNewRejectsWrongReading(t *testing.T) {
+	if _, err := New("食べる", "たべ", Ichidan); err == nil {
+		t.Error("New(食べる, たべ, ichidan) succeeded, want an error")
+	}
+}
+
+func TestNewRejectsWrongEnding(t *testing.T) {
+	tests := []struct {
+		verb  string
+		class Class
+	}{
+		{"書く", Ichidan},
+		{"る", Ichidan},
+		{"食べ", Godan},
+		{"来る", Suru},
+		{"する", Kuru},
+		{"書く", GodanIku},
+		{"取る", GodanAru},
+		{"待つ", GodanHonorific},
+		{"書く", GodanU},
+	}
+
+	for _, tt := range tests {
+		if _, err := New(tt.verb, "", tt.class); err == nil {
+			t.Errorf("New(%s, %v) succeeded, want an error", tt.verb, tt.class)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		verb    string
+		reading string
+		want    Class
+	}{
+		{"食べる", "たべる", Ichidan},
+		{"見る", "みる", Ichidan},
+		{"みる", "", Ichidan},
+		{"変える", "かえる", Ichidan},
+		{"書く", "かく", Godan},
+		{"取る", "とる", Godan},
+		{"帰る", "かえる", Godan},
+		{"走る", "はしる", Godan},
+		{"入る", "はいる", Godan},
+		{"はいる", "", Godan},
+		{"知る", "しる", Godan},
+		{"行く", "いく", GodanIku},
+		{"ある", "", GodanAru},
+		{"くださる", "", GodanHonorific},
+		{"くれる", "", IchidanKureru},
+		{"問う", "とう", GodanU},
+		{"する", "", Suru},
+		{"為る", "する", Suru},
+		{"勉強する", "べんきょうする", Suru},
+		{"来る", "くる", Kuru},
+		{"くる", "", Kuru},
+		{"出来る", "", Ichidan},
+		{"出来る", "できる", Ichidan},
+		{"持って来る", "もってくる", Kuru},
+		{"持って来る", "", Kuru},
+		{"できる", "", Ichidan},
+	}
+
+	for _, tt := range tests {
+		if got := Classify(tt.verb, tt.reading); got != tt.want {
+			t.Errorf("Classify(%s, %s) = %v, want %v", tt.verb, tt.reading, got, tt.want)
+		}
+	}
+}
+
+func TestClassFromPOS(t *testing.T) {
+	tests := []struct {
+		codes []string
+		want  Class
+		ok    bool
+	}{
+		{[]string{"v1", "vt"}, Ichidan, true},
+		{[]string{"v5r", "vi"}, Godan, true},
+		{[]string{"v5k-s"}, GodanIku, true},
+		{[]string{"v5r-i"}, GodanAru, true},
+		{[]string{"v5aru"}, GodanHonorific, true},
+		{[]string{"v5u-s"}, GodanU, true},
+		{[]string{"v1-s"}, IchidanKureru, true},
+		{[]string{"vk"}, Kuru, true},
+		{[]string{"vs-i"}, Suru, true},
+		{[]string{"n", "vs"}, 0, false},
+		{nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ClassFromPOS(tt.codes)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ClassFromPOS(%v) = %v, %v, want %v, %v", tt.codes, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExceptions(t *testing.T) {
+	tests := []struct {
+		verb       string
+		class      Class
+		form       Form
+		polarity   Polarity
+		politeness Politeness
+		want       string
+	}{
+		{"行く", GodanIku, Te, Affirmative, Plain, "行って"},
+		{"行く", GodanIku, Past, Affirmative, Plain, "行った"},
+		{"行く", GodanIku, Progressive, Affirmative, Plain, "行っている"},
+		{"行く", GodanIku, Conditional, Affirmative, Plain, "行けば"},
+		{"いく", GodanIku, NonPast, Negative, Plain, "いかない"},
+		{"ある", GodanAru, NonPast, Negative, Plain, "ない"},
+		{"ある", GodanAru, Past, Negative, Plain, "なかった"},
+		{"ある", GodanAru, NonPast, Affirmative, Polite, "あります"},
+		{"ある", GodanAru, Past, Affirmative, Plain, "あった"},
+		{"有る", GodanAru, NonPast, Negative, Plain, "ない"},
+		{"である", GodanAru, NonPast, Negative, Plain, "でない"},
+		{"くださる", GodanHonorific, Imperative, Affirmative, Plain, "ください"},
+		{"くださる", GodanHonorific, NonPast, Affirmative, Polite, "くださいます"},
+		{"くださる", GodanHonorific, NonPast, Negative, Plain, "くださらない"},
+		{"くださる", GodanHonorific, Past, Affirmative, Plain, "くださった"},
+		{"いらっしゃる", GodanHonorific, NonPast, Affirmative, Polite, "いらっしゃいます"},
+		// The い stem is only for ます and the imperative; たい keeps り
+		{"くださる", GodanHonorific, Desire, Affirmative, Plain, "くださりたい"},
+		{"いらっしゃる", GodanHonorific, Desire, Affirmative, Plain, "いらっしゃりたい"},
+		{"なさる", GodanHonorific, Desire, Negative, Polite, "なさりたくないです"},
+		{"なさる", GodanHonorific, Past, Affirmative, Polite, "なさいました"},
+		{"なさる", GodanHonorific, Imperative, Affirmative, Plain, "なさい"},
+		{"くれる", IchidanKureru, Imperative, Affirmative, Plain, "くれ"},
+		{"くれる", IchidanKureru, NonPast, Negative, Plain, "くれない"},
+		{"問う", GodanU, Te, Affirmative, Plain, "問うて"},
+		{"問う", GodanU, Past, Affirmative, Plain, "問うた"},
+		{"問う", GodanU, NonPast, Negative, Plain, "問わない"},
+	}
+
+	for _, tt := range tests {
+		v, err := New(tt.verb, "", tt.class)
+		if err != nil {
+			t.Fatalf("New(%s, %v): %v", tt.verb, tt.class, err)
+		}
+		if got := v.Conjugate(tt.form, tt.polarity, tt.politeness).Text; got != tt.want {
+			t.Errorf("%s form %d (%d, %d) = %s, want %s", tt.verb, tt.form, tt.polarity, tt.politeness, got, tt.want)
+		}
+	}
+}
+
+func TestIsVerbPOS(t *testing.T) {
+	tests := []struct {
+		codes []string
+		want  bool
+	}{
+		{[]string{"v1", "vt"}, true},
+		{[]string{"vs-s"}, true},
+		{[]string{"vz"}, true},
+		{[]string{"n", "vs"}, false},
+		{[]string{"adv", "adv-to", "vs"}, false},
+		{[]string{"adj-i"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsVerbPOS(tt.codes); got != tt.want {
+			t.Errorf("IsVerbPOS(%v) = %t, want %t", tt.codes, got, tt.want)
+		}
+	}
+}
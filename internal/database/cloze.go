@@ -0,0 +1,108 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// SRTypeCloze is the sr.type of sentence cloze cards: the word is blanked out of an example
+// sentence and typed back in. They are studied on /study/cloze and kept out of the
+// reading/meaning queue.
+const SRTypeCloze = "sentence cloze"
+
+// wordHasSentenceClause matches words (aliased w) with an example sentence they can be
+// blanked out of (the same sentences GetExampleSentences picks from)
+const wordHasSentenceClause = `EXISTS (
+	SELECT 1 FROM sentence_words sw
+	JOIN sentences s ON s.id = sw.sentence_id
+	WHERE sw.word_id = w.id AND strpos(s.japanese, sw.surface) > 0
+)`
+
+// HasUserSRCloze checks if a user has turned on sentence cloze cards
+func (db *Database) HasUserSRCloze(userID int) (bool, error) {
+	var count int
+	err := db.DB.QueryRow(`SELECT COUNT(*) FROM sr WHERE user_id = $1 AND type = $2`, userID, SRTypeCloze).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user cloze cards: %w", err)
+	}
+	return count > 0, nil
+}
+
+// InitializeUserSRCloze adds a cloze card for every word in the user's deck that has an
+// example sentence. Words added to the deck afterwards get one automatically.
+func (db *Database) InitializeUserSRCloze(userID int) (int, error) {
+	query := `
+		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
+		SELECT DISTINCT $1, w.id, 0, 2.5, 0, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM words w
+		WHERE EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type <> $2)
+			AND NOT EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type = $2)
+			AND ` + wordHasSentenceClause
+	result, err := db.DB.Exec(query, userID, SRTypeCloze)
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize cloze cards: %w", err)
+	}
+	added, _ := result.RowsAffected()
+	log.Printf("✅ Added %d sentence cloze cards for user %d", added, userID)
+	return int(added), nil
+}
+
+// addSRCloze adds a cloze card for a word if the user studies cloze cards and the word has
+// an example sentence
+func (db *Database) addSRCloze(userID int, wordID int) error {
+	enabled, err := db.HasUserSRCloze(userID)
+	if err != nil || !enabled {
+		return err
+	}
+	query := `
+		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
+		SELECT $1, w.id, 0, 2.5, 0, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM words w
+		WHERE w.id = $2
+			AND NOT EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type = $3)
+			AND ` + wordHasSentenceClause
+	if _, err := db.DB.Exec(query, userID, wordID, SRTypeCloze); err != nil {
+		return fmt.Errorf("failed to add cloze entry: %w", err)
+	}
+	return nil
+}
+
+// GetNextSRClozeWord retrieves the next cloze card due for review
+// Cards whose word has lost its sentences are skipped
+func (db *Database) GetNextSRClozeWord(userID int) (*SRWord, error) {
+	query := `
+		SELECT
+			sr.id, sr.user_id, sr.word_id, sr.repetitions, sr.ef, sr.interval, sr.type,
+			sr.last_reviewed, sr.next_review,
+			w.id, w.word, w.furigana, w.romaji, w.level, w.definitions, w.parts_of_speech, w.hiragana_only, w.created_at
+		FROM sr
+		JOIN words w ON sr.word_id = w.id
+		WHERE sr.user_id = $1
+			AND sr.type = $2
+			AND sr.next_review <= CURRENT_TIMESTAMP
+			AND (sr.suspended = FALSE OR sr.suspended IS NULL)
+			AND ` + wordHasSentenceClause + `
+		ORDER BY sr.next_review ASC
+		LIMIT 1
+	`
+
+	var srWord SRWord
+	err := db.DB.QueryRow(query, userID, SRTypeCloze).Scan(
+		&srWord.SRID, &srWord.UserID, &srWord.WordID, &srWord.Repetitions,
+		&srWord.EF, &srWord.Interval, &srWord.Type, &srWord.LastReviewed, &srWord.NextReview,
+		&srWord.Word.ID, &srWord.Word.Word, &srWord.Word.Furigana, &srWord.Word.Romaji,
+		&srWord.Word.Level, &srWord.Word.Definitions, &srWord.Word.PartsOfSpeech, &srWord.Word.HiraganaOnly, &srWord.Word.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No cloze cards due for review
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next SR cloze word: %w", err)
+	}
+	if err := db.attachWordDetails(&srWord.Word); err != nil {
+		return nil, err
+	}
+
+	return &srWord, nil
+}
@@ -0,0 +1,561 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Fields of a word users can propose corrections to
+const (
+	CorrectionReading       = "reading"
+	CorrectionDefinitions   = "definitions"
+	CorrectionPartsOfSpeech = "parts_of_speech"
+	CorrectionHiraganaOnly  = "hiragana_only"
+	CorrectionKatakanaOnly  = "katakana_only"
+
+	// CorrectionPromote asks for a custom word to join the shared dictionary; the proposed
+	// value is the level it should have there
+	CorrectionPromote = "promote"
+)
+
+// Correction statuses
+const (
+	CorrectionPending  = "pending"
+	CorrectionApplied  = "applied"
+	CorrectionRejected = "rejected"
+)
+
+// correctionColumns maps each correctable field to its words column
+// Field names are only ever interpolated into SQL through this map
+var correctionColumns = map[string]string{
+	CorrectionReading:       "furigana",
+	CorrectionDefinitions:   "definitions",
+	CorrectionPartsOfSpeech: "parts_of_speech",
+	CorrectionHiraganaOnly:  "hiragana_only",
+	CorrectionKatakanaOnly:  "katakana_only",
+	CorrectionPromote:       "level",
+}
+
+// CorrectionFields lists the correctable fields in the order forms show them
+var CorrectionFields = []string{
+	CorrectionReading,
+	CorrectionDefinitions,
+	CorrectionPartsOfSpeech,
+	CorrectionHiraganaOnly,
+	CorrectionKatakanaOnly,
+}
+
+// WordCorrection is a user's proposed fix to one field of a word
+type WordCorrection struct {
+	ID            int
+	WordID        int
+	Word          string
+	UserID        int
+	Username      string
+	Field         string
+	OriginalValue string // the field's value when the correction was proposed
+	CurrentValue  string // the field's value now; differs from OriginalValue if it was edited since
+	ProposedValue string
+	Note          string
+	Status        string
+	ReviewedBy    sql.NullInt64
+	ReviewNote    string
+	CreatedAt     string
+}
+
+// Stale reports whether the word changed after the correction was proposed
+func (c WordCorrection) Stale() bool {
+	return c.OriginalValue != c.CurrentValue
+}
+
+// WordEdit is one entry of a word's audit history
+type WordEdit struct {
+	ID           int
+	WordID       int
+	Word         string
+	Field        string
+	OldValue     string
+	NewValue     string
+	CorrectionID sql.NullInt64
+	EditedBy     string
+	EditedAt     string
+}
+
+// ValidateCorrection normalizes a proposed value and checks it makes sense for the field
+func ValidateCorrection(field string, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch field {
+	case CorrectionReading:
+		for _, r := range value {
+			if !isKana(r) && r != '/' && r != ' ' {
+				return "", fmt.Errorf("reading must be kana, with alternates separated by /")
+			}
+		}
+	case CorrectionHiraganaOnly, CorrectionKatakanaOnly:
+		switch strings.ToLower(value) {
+		case "true", "yes", "1":
+			return "true", nil
+		case "false", "no", "0":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%s must be true or false", field)
+	case CorrectionPromote:
+		if level, err := strconv.Atoi(value); err != nil || level < 1 || level > 5 {
+			return "", fmt.Errorf("level must be a JLPT level from 1 to 5")
+		}
+	case CorrectionDefinitions, CorrectionPartsOfSpeech:
+	default:
+		return "", fmt.Errorf("unknown field %q", field)
+	}
+	if value == "" {
+		return "", fmt.Errorf("%s can't be empty", field)
+	}
+	return value, nil
+}
+
+// isKana reports whether r is hiragana, katakana or the long vowel mark
+func isKana(r rune) bool {
+	return (r >= 'ぁ' && r <= 'ゖ') || (r >= 'ァ' && r <= 'ヺ') || r == 'ー'
+}
+
+// querier is the part of *sql.DB and *sql.Tx that helpers reading inside or outside a transaction need
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// checkPromotion returns an error if the shared dictionary already has the custom word's
+// spelling at the level it would be promoted to
+func checkPromotion(q querier, wordID int, level string) error {
+	var word string
+	err := q.QueryRow(`
+		SELECT d.word FROM words w
+		JOIN words d ON d.word = w.word AND d.owner_id = 0 AND d.id <> w.id
+		WHERE w.id = $1 AND d.level = $2
+	`, wordID, level).Scan(&word)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check dictionary for the word: %w", err)
+	}
+	return fmt.Errorf("%s is already in the dictionary at level %s; add that entry to your deck instead", word, level)
+}
+
+// wordFieldValue gets the current value of a correctable field as text
+func wordFieldValue(q querier, wordID int, field string) (string, error) {
+	column, ok := correctionColumns[field]
+	if !ok {
+		return "", fmt.Errorf("unknown field %q", field)
+	}
+	var value string
+	err := q.QueryRow(`SELECT COALESCE(`+column+`::text, '') FROM words WHERE id = $1`, wordID).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("word not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get word %s: %w", field, err)
+	}
+	return value, nil
+}
+
+// GetWordFieldValues gets the current value of every correctable field of a word
+func (db *Database) GetWordFieldValues(wordID int) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, field := range CorrectionFields {
+		value, err := wordFieldValue(db.DB, wordID, field)
+		if err != nil {
+			return nil, err
+		}
+		values[field] = value
+	}
+	return values, nil
+}
+
+// ProposeCorrection queues a user's correction to a word for moderation
+// Dictionary words take field corrections; a user's own custom words can only be promoted
+func (db *Database) ProposeCorrection(userID int, wordID int, field string, proposed string, note string) (int, error) {
+	proposed, err := ValidateCorrection(field, proposed)
+	if err != nil {
+		return 0, err
+	}
+
+	var ownerID int
+	err = db.DB.QueryRow(`SELECT owner_id FROM words WHERE id = $1`, wordID).Scan(&ownerID)
+	if err == sql.ErrNoRows || err == nil && ownerID != 0 && ownerID != userID {
+		return 0, fmt.Errorf("word not found")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check word: %w", err)
+	}
+	if field == CorrectionPromote && ownerID == 0 {
+		return 0, fmt.Errorf("word is already in the dictionary")
+	}
+	if field != CorrectionPromote && ownerID != 0 {
+		return 0, fmt.Errorf("custom words are edited on the My Words page")
+	}
+	if field == CorrectionPromote {
+		if err := checkPromotion(db.DB, wordID, proposed); err != nil {
+			return 0, err
+		}
+	}
+
+	original, err := wordFieldValue(db.DB, wordID, field)
+	if err != nil {
+		return 0, err
+	}
+	if original == proposed && field != CorrectionPromote {
+		return 0, fmt.Errorf("proposed %s is the same as the current one", field)
+	}
+
+	var id int
+	err = db.DB.QueryRow(`
+		INSERT INTO word_corrections (word_id, user_id, field, original_value, proposed_value, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, wordID, userID, field, original, proposed, strings.TrimSpace(note)).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save correction: %w", err)
+	}
+	log.Printf("✅ User %d proposed a %s correction for word %d", userID, field, wordID)
+	return id, nil
+}
+
+// correctionSelect loads corrections with the word, proposer and the field's current value
+const correctionSelect = `
+	SELECT c.id, c.word_id, w.word, c.user_id, u.username, c.field, c.original_value,
+		CASE c.field
+			WHEN 'reading' THEN COALESCE(w.furigana, '')
+			WHEN 'definitions' THEN COALESCE(w.definitions, '')
+			WHEN 'parts_of_speech' THEN COALESCE(w.parts_of_speech, '')
+			WHEN 'hiragana_only' THEN COALESCE(w.hiragana_only::text, '')
+			WHEN 'katakana_only' THEN COALESCE(w.katakana_only::text, '')
+			WHEN 'promote' THEN w.level::text
+		END,
+		c.proposed_value, c.note, c.status, c.reviewed_by, c.review_note, c.created_at
+	FROM word_corrections c
+	JOIN words w ON w.id = c.word_id
+	JOIN users u ON u.id = c.user_id
+`
+
+func scanCorrection(scan func(dest ...interface{}) error) (*WordCorrection, error) {
+	var c WordCorrection
+	err := scan(&c.ID, &c.WordID, &c.Word, &c.UserID, &c.Username, &c.Field, &c.OriginalValue,
+		&c.CurrentValue, &c.ProposedValue, &c.Note, &c.Status, &c.ReviewedBy, &c.ReviewNote, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetPendingCorrections returns the moderation queue, oldest first
+func (db *Database) GetPendingCorrections() ([]WordCorrection, error) {
+	rows, err := db.DB.Query(correctionSelect+` WHERE c.status = $1 ORDER BY c.created_at, c.id`, CorrectionPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var corrections []WordCorrection
+	for rows.Next() {
+		c, err := scanCorrection(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan correction: %w", err)
+		}
+		corrections = append(corrections, *c)
+	}
+	return corrections, nil
+}
+
+// ApplyCorrection writes a pending correction to the word and records it in word_edits
+// The word keeps its ID, so SR cards and their progress are untouched. Senses and
+// readings derived from the corrected column are updated in the same transaction.
+func (db *Database) ApplyCorrection(id int, adminID int, reviewNote string) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var wordID int
+	var field, proposed, status string
+	err = tx.QueryRow(`
+		SELECT word_id, field, proposed_value, status FROM word_corrections WHERE id = $1 FOR UPDATE
+	`, id).Scan(&wordID, &field, &proposed, &status)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("correction not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get correction: %w", err)
+	}
+	if status != CorrectionPending {
+		return fmt.Errorf("correction has already been %s", status)
+	}
+
+	old, err := wordFieldValue(tx, wordID, field)
+	if err != nil {
+		return err
+	}
+	update := `UPDATE words SET ` + correctionColumns[field] + ` = $1 WHERE id = $2`
+	if field == CorrectionPromote {
+		// The dictionary has one entry per spelling and level
+		if err := checkPromotion(tx, wordID, proposed); err != nil {
+			return err
+		}
+		// The word keeps its ID, so the creator's SR cards carry over
+		update = `UPDATE words SET level = $1, owner_id = 0 WHERE id = $2`
+	}
+	if _, err := tx.Exec(update, proposed, wordID); err != nil {
+		return fmt.Errorf("failed to update word: %w", err)
+	}
+	_, err = tx.Exec(`
+		INSERT INTO word_edits (word_id, field, old_value, new_value, correction_id, edited_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, wordID, field, old, proposed, id, adminID)
+	if err != nil {
+		return fmt.Errorf("failed to record word edit: %w", err)
+	}
+	_, err = tx.Exec(`
+		UPDATE word_corrections
+		SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, CorrectionApplied, adminID, strings.TrimSpace(reviewNote), id)
+	if err != nil {
+		return fmt.Errorf("failed to update correction: %w", err)
+	}
+	if err := rebuildCorrectedWord(tx, wordID, field, old, proposed); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit correction: %w", err)
+	}
+	log.Printf("✅ Applied correction %d to word %d (%s)", id, wordID, field)
+	return nil
+}
+
+// rebuildCorrectedWord refreshes the structured rows derived from a corrected column, within tx
+// Only the corrected part changes: a definitions correction keeps each sense's parts of speech and
+// tags, and a parts of speech correction keeps the glosses. Words without senses yet get them from
+// the legacy columns.
+func rebuildCorrectedWord(tx *sql.Tx, wordID int, field string, old string, proposed string) error {
+	var word, furigana, definitions, pos string
+	err := tx.QueryRow(`
+		SELECT word, COALESCE(furigana, ''), COALESCE(definitions, ''), COALESCE(parts_of_speech, '')
+		FROM words WHERE id = $1
+	`, wordID).Scan(&word, &furigana, &definitions, &pos)
+	if err != nil {
+		return fmt.Errorf("failed to reload corrected word: %w", err)
+	}
+
+	switch field {
+	case CorrectionDefinitions, CorrectionPartsOfSpeech:
+		senses, err := wordSenses(tx, []int{wordID})
+		if err != nil {
+			return err
+		}
+		current := senses[wordID]
+		switch {
+		case len(current) == 0:
+			current = LegacySenses(definitions, pos)
+		case field == CorrectionDefinitions:
+			current = correctGlosses(current, definitions)
+		default:
+			current = correctPOS(current, old, pos)
+		}
+		return replaceWordSenses(tx, wordID, current)
+	case CorrectionReading:
+		// Keep the written forms (they may carry rare flags from JMdict), replace the readings
+		forms, err := wordForms(tx, []int{wordID})
+		if err != nil {
+			return err
+		}
+		legacyForms, readings := LegacyVariants(word, furigana)
+		if len(forms[wordID]) > 0 {
+			legacyForms = forms[wordID]
+		}
+		return replaceWordVariants(tx, wordID, legacyForms, readings)
+	}
+	return nil
+}
+
+// correctGlosses fits corrected semicolon-separated definitions onto the word's senses
+// Unchanged glosses stay in their sense, an edited gloss takes the place of the one it replaced,
+// an added one joins the sense before it, and senses left without glosses are dropped.
+func correctGlosses(senses []Sense, definitions string) []Sense {
+	var items []string
+	for _, def := range strings.Split(definitions, ";") {
+		if def = strings.TrimSpace(def); def != "" {
+			items = append(items, def)
+		}
+	}
+	// The current glosses in order, with the sense each is in
+	var glosses []string
+	var owner []int
+	for i, sense := range senses {
+		for _, g := range sense.Glosses {
+			glosses = append(glosses, g)
+			owner = append(owner, i)
+		}
+	}
+
+	// Longest common subsequence of the old and new glosses: the ones left unchanged
+	lcs := make([][]int, len(glosses)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(items)+1)
+	}
+	for i := len(glosses) - 1; i >= 0; i-- {
+		for j := len(items) - 1; j >= 0; j-- {
+			switch {
+			case glosses[i] == items[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	assigned := make([][]string, len(senses))
+	last := 0 // Sense of the previous new gloss
+	// place puts the new glosses between two unchanged ones in the senses of the old glosses
+	// they replaced, in order, or after the previous gloss once those run out
+	place := func(oldGap []int, newGap []string) {
+		for k, item := range newGap {
+			if k < len(oldGap) {
+				last = owner[oldGap[k]]
+			} else if len(oldGap) > 0 {
+				last = owner[oldGap[len(oldGap)-1]]
+			}
+			assigned[last] = append(assigned[last], item)
+		}
+	}
+	var oldGap []int
+	var newGap []string
+	i, j := 0, 0
+	for i < len(glosses) || j < len(items) {
+		switch {
+		case i < len(glosses) && j < len(items) && glosses[i] == items[j] && lcs[i][j] == lcs[i+1][j+1]+1:
+			place(oldGap, newGap)
+			oldGap, newGap = nil, nil
+			last = owner[i]
+			assigned[last] = append(assigned[last], items[j])
+			i++
+			j++
+		case j == len(items) || (i < len(glosses) && lcs[i+1][j] >= lcs[i][j+1]):
+			oldGap = append(oldGap, i)
+			i++
+		default:
+			newGap = append(newGap, items[j])
+			j++
+		}
+	}
+	place(oldGap, newGap)
+
+	var result []Sense
+	for i, sense := range senses {
+		if len(assigned[i]) == 0 {
+			continue
+		}
+		sense.Glosses = assigned[i]
+		sense.Order = len(result) + 1
+		result = append(result, sense)
+	}
+	return result
+}
+
+// correctPOS applies a parts of speech correction to the word's senses
+// Parts of speech taken out of the list leave every sense; ones added to it are added to every
+// sense, since the list doesn't say which sense they belong to.
+func correctPOS(senses []Sense, old string, proposed string) []Sense {
+	parse := func(list string) []PartOfSpeech {
+		var parts []PartOfSpeech
+		for _, p := range strings.Split(list, ";") {
+			if strings.TrimSpace(p) == "" || strings.EqualFold(strings.TrimSpace(p), "unknown") {
+				continue
+			}
+			parts = append(parts, POSFromDescription(p))
+		}
+		return parts
+	}
+	codes := func(parts []PartOfSpeech) map[string]bool {
+		set := make(map[string]bool)
+		for _, p := range parts {
+			set[p.Code] = true
+		}
+		return set
+	}
+	oldCodes := codes(parse(old))
+	newParts := parse(proposed)
+	newCodes := codes(newParts)
+
+	result := make([]Sense, len(senses))
+	for i, sense := range senses {
+		var pos []PartOfSpeech
+		has := make(map[string]bool)
+		for _, p := range sense.POS {
+			if oldCodes[p.Code] && !newCodes[p.Code] {
+				continue
+			}
+			pos = append(pos, p)
+			has[p.Code] = true
+		}
+		for _, p := range newParts {
+			if !oldCodes[p.Code] && !has[p.Code] {
+				pos = append(pos, p)
+				has[p.Code] = true
+			}
+		}
+		sense.POS = pos
+		result[i] = sense
+	}
+	return result
+}
+
+// RejectCorrection closes a pending correction without changing the word
+func (db *Database) RejectCorrection(id int, adminID int, reviewNote string) error {
+	result, err := db.DB.Exec(`
+		UPDATE word_corrections
+		SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND status = $5
+	`, CorrectionRejected, adminID, strings.TrimSpace(reviewNote), id, CorrectionPending)
+	if err != nil {
+		return fmt.Errorf("failed to reject correction: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("correction not found or already reviewed")
+	}
+	return nil
+}
+
+// GetWordEdits returns the most recent applied edits, newest first
+// A wordID of 0 returns edits to any word
+func (db *Database) GetWordEdits(wordID int, limit int) ([]WordEdit, error) {
+	rows, err := db.DB.Query(`
+		SELECT e.id, e.word_id, w.word, e.field, e.old_value, e.new_value, e.correction_id,
+			COALESCE(u.username, ''), e.edited_at
+		FROM word_edits e
+		JOIN words w ON w.id = e.word_id
+		LEFT JOIN users u ON u.id = e.edited_by
+		WHERE $1 = 0 OR e.word_id = $1
+		ORDER BY e.edited_at DESC, e.id DESC
+		LIMIT $2
+	`, wordID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word edits: %w", err)
+	}
+	defer rows.Close()
+
+	var edits []WordEdit
+	for rows.Next() {
+		var e WordEdit
+		if err := rows.Scan(&e.ID, &e.WordID, &e.Word, &e.Field, &e.OldValue, &e.NewValue, &e.CorrectionID, &e.EditedBy, &e.EditedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan word edit: %w", err)
+		}
+		edits = append(edits, e)
+	}
+	return edits, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCorrectGlosses(t *testing.T) {
+	verb := []PartOfSpeech{{Code: "v5k", Description: "Godan verb with 'ku' ending"}}
+	noun := []PartOfSpeech{{Code: "n", Description: "Noun"}}
+	senses := []Sense{
+		{Order: 1, Glosses: []string{"to write", "to compose"}, POS: verb},
+		{Order: 2, Glosses: []string{"writing"}, POS: noun, Tags: []string{"uk"}},
+	}
+
+	tests := []struct {
+		name, definitions string
+		want              []Sense
+	}{
+		{"unchanged", "to write; to compose; writing", senses},
+		{"edited gloss stays in its sense", "to write; to compose; handwriting", []Sense{
+			senses[0],
+			{Order: 2, Glosses: []string{"handwriting"}, POS: noun, Tags: []string{"uk"}},
+		}},
+		{"added gloss joins the sense before it", "to write; to pen; to compose; writing", []Sense{
+			{Order: 1, Glosses: []string{"to write", "to pen", "to compose"}, POS: verb},
+			senses[1],
+		}},
+		{"emptied sense is dropped", "writing", []Sense{
+			{Order: 1, Glosses: []string{"writing"}, POS: noun, Tags: []string{"uk"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := correctGlosses(senses, tt.definitions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: correctGlosses(%q) = %+v, want %+v", tt.name, tt.definitions, got, tt.want)
+		}
+	}
+}
+
+func TestCorrectPOS(t *testing.T) {
+	senses := []Sense{
+		{Order: 1, Glosses: []string{"study"}, POS: []PartOfSpeech{{Code: "n", Description: "Noun"}}, Tags: []string{"P"}},
+		{Order: 2, Glosses: []string{"diligence"}, POS: []PartOfSpeech{{Code: "n", Description: "Noun"}, {Code: "adj-na", Description: "Na-adjective"}}},
+	}
+
+	got := correctPOS(senses, "Noun; Na-adjective", "Noun; Suru verb")
+
+	want := []Sense{
+		{Order: 1, Glosses: []string{"study"}, POS: []PartOfSpeech{{Code: "n", Description: "Noun"}, {Code: "vs", Description: "Suru verb"}}, Tags: []string{"P"}},
+		{Order: 2, Glosses: []string{"diligence"}, POS: []PartOfSpeech{{Code: "n", Description: "Noun"}, {Code: "vs", Description: "Suru verb"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("correctPOS = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,254 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// CustomWordLevel is the level of user-created words, which have no JLPT level
+const CustomWordLevel = 0
+
+// CustomWord is a private word a user created, as entered on the My Words page
+// It's stored as a words row owned by the user (words.owner_id), so it's studied and
+// checked exactly like a dictionary word
+type CustomWord struct {
+	ID            int
+	Word          string
+	Readings      string // "/"-separated, primary first
+	Romaji        string
+	Definitions   string // ";"-separated
+	PartsOfSpeech string // ";"-separated descriptions, e.g. "Noun; Suru verb"
+	Tags          string // ","-separated, e.g. "work, names"
+
+	InDeck           bool // the user has SR cards for it
+	PromotionPending bool // a request to add it to the shared dictionary is waiting for review
+}
+
+// isKanaWord reports whether word is written only in the given kana
+func isKanaWord(word string, katakana bool) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if r == 'ー' {
+			continue
+		}
+		if katakana && !(r >= 'ァ' && r <= 'ヺ') || !katakana && !(r >= 'ぁ' && r <= 'ゖ') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize trims the fields and checks the word can be studied
+func (c *CustomWord) normalize() error {
+	c.Word = strings.TrimSpace(c.Word)
+	c.Romaji = strings.TrimSpace(c.Romaji)
+	c.Definitions = strings.TrimSpace(c.Definitions)
+	c.PartsOfSpeech = strings.TrimSpace(c.PartsOfSpeech)
+
+	if c.Word == "" {
+		return fmt.Errorf("word can't be empty")
+	}
+	if c.Definitions == "" {
+		return fmt.Errorf("definitions can't be empty")
+	}
+
+	c.Tags = strings.Join(c.tagList(), ", ")
+
+	// Kana words can leave the reading out, like kana words in the dictionary
+	kanaOnly := isKanaWord(c.Word, false) || isKanaWord(c.Word, true)
+	if strings.TrimSpace(c.Readings) == "" && kanaOnly {
+		c.Readings = ""
+		return nil
+	}
+	readings, err := ValidateCorrection(CorrectionReading, c.Readings)
+	if err != nil {
+		return err
+	}
+	c.Readings = readings
+	return nil
+}
+
+func (c *CustomWord) tagList() []string {
+	var tags []string
+	for _, tag := range strings.Split(c.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// senses builds the word's senses, with the tags on every sense
+func (c *CustomWord) senses() []Sense {
+	senses := LegacySenses(c.Definitions, c.PartsOfSpeech)
+	for i := range senses {
+		senses[i].Tags = c.tagList()
+	}
+	return senses
+}
+
+// CreateCustomWord adds a private word for a user and returns its word ID
+func (db *Database) CreateCustomWord(userID int, c CustomWord) (int, error) {
+	if err := c.normalize(); err != nil {
+		return 0, err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var wordID int
+	err = tx.QueryRow(`
+		INSERT INTO words (word, furigana, romaji, level, definitions, parts_of_speech, hiragana_only, katakana_only, owner_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, c.Word, c.Readings, c.Romaji, CustomWordLevel, c.Definitions, c.PartsOfSpeech,
+		isKanaWord(c.Word, false), isKanaWord(c.Word, true), userID).Scan(&wordID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return 0, fmt.Errorf("you already have a word %s", c.Word)
+		}
+		return 0, fmt.Errorf("failed to create custom word: %w", err)
+	}
+
+	if err := rebuildCustomWord(tx, wordID, c); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit custom word: %w", err)
+	}
+	log.Printf("✅ User %d created custom word %s (%d)", userID, c.Word, wordID)
+	return wordID, nil
+}
+
+// UpdateCustomWord edits one of a user's private words in place, keeping its SR cards
+func (db *Database) UpdateCustomWord(userID int, wordID int, c CustomWord) error {
+	if err := c.normalize(); err != nil {
+		return err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE words
+		SET word = $1, furigana = $2, romaji = $3, definitions = $4, parts_of_speech = $5,
+			hiragana_only = $6, katakana_only = $7
+		WHERE id = $8 AND owner_id = $9
+	`, c.Word, c.Readings, c.Romaji, c.Definitions, c.PartsOfSpeech,
+		isKanaWord(c.Word, false), isKanaWord(c.Word, true), wordID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update custom word: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("custom word not found")
+	}
+
+	if err := rebuildCustomWord(tx, wordID, c); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit custom word: %w", err)
+	}
+	return nil
+}
+
+// rebuildCustomWord replaces the senses, forms and readings of a custom word within tx
+func rebuildCustomWord(tx *sql.Tx, wordID int, c CustomWord) error {
+	if err := replaceWordSenses(tx, wordID, c.senses()); err != nil {
+		return err
+	}
+	forms, readings := LegacyVariants(c.Word, c.Readings)
+	return replaceWordVariants(tx, wordID, forms, readings)
+}
+
+// DeleteCustomWord removes one of a user's private words along with its SR cards and every row
+// that refers to it
+func (db *Database) DeleteCustomWord(userID int, wordID int) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM words WHERE id = $1 AND owner_id = $2`, wordID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom word: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("custom word not found")
+	}
+
+	cleanup := []string{
+		`DELETE FROM sr WHERE word_id = $1`,
+		`DELETE FROM word_sense_pos WHERE sense_id IN (SELECT id FROM word_senses WHERE word_id = $1)`,
+		`DELETE FROM word_senses WHERE word_id = $1`,
+		`DELETE FROM word_forms WHERE word_id = $1`,
+		`DELETE FROM word_readings WHERE word_id = $1`,
+		`DELETE FROM sentence_words WHERE word_id = $1`,
+		`DELETE FROM word_corrections WHERE word_id = $1`,
+		`DELETE FROM word_edits WHERE word_id = $1`,
+		`DELETE FROM word_kanji WHERE word_id = $1`,
+		`DELETE FROM word_frequencies WHERE word_id = $1`,
+		`DELETE FROM kanji_confusion WHERE word1_id = $1 OR word2_id = $1`,
+	}
+	for _, query := range cleanup {
+		if _, err := tx.Exec(query, wordID); err != nil {
+			return fmt.Errorf("failed to delete custom word data: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit custom word deletion: %w", err)
+	}
+	return nil
+}
+
+// GetCustomWords returns a user's private words, newest first
+func (db *Database) GetCustomWords(userID int) ([]CustomWord, error) {
+	rows, err := db.DB.Query(`
+		SELECT w.id, w.word, COALESCE(w.furigana, ''), COALESCE(w.romaji, ''), COALESCE(w.definitions, ''),
+			COALESCE(w.parts_of_speech, ''),
+			COALESCE((SELECT array_to_string(tags, ', ') FROM word_senses WHERE word_id = w.id ORDER BY sense_order LIMIT 1), ''),
+			EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id),
+			EXISTS (SELECT 1 FROM word_corrections c WHERE c.word_id = w.id AND c.field = $2 AND c.status = $3)
+		FROM words w
+		WHERE w.owner_id = $1
+		ORDER BY w.created_at DESC, w.id DESC
+	`, userID, CorrectionPromote, CorrectionPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom words: %w", err)
+	}
+	defer rows.Close()
+
+	var words []CustomWord
+	for rows.Next() {
+		var c CustomWord
+		err := rows.Scan(&c.ID, &c.Word, &c.Readings, &c.Romaji, &c.Definitions, &c.PartsOfSpeech, &c.Tags,
+			&c.InDeck, &c.PromotionPending)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan custom word: %w", err)
+		}
+		words = append(words, c)
+	}
+	return words, nil
+}
+
+// CanSeeWord reports whether a word is in the shared dictionary or is one of the user's own
+func (db *Database) CanSeeWord(userID int, wordID int) (bool, error) {
+	var visible bool
+	err := db.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM words WHERE id = $1 AND owner_id IN (0, $2))`, wordID, userID).Scan(&visible)
+	if err != nil {
+		return false, fmt.Errorf("failed to check word: %w", err)
+	}
+	return visible, nil
+}
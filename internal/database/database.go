@@ -2,12 +2,14 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Database struct {
@@ -122,7 +124,11 @@ func (db *Database) InitializeTables() error {
 		key_3 VARCHAR(10) NOT NULL,
 		key_4 VARCHAR(10) NOT NULL,
 		key_5 VARCHAR(10) NOT NULL,
-		show_hiragana_mostly BOOLEAN DEFAULT TRUE
+		show_hiragana_mostly BOOLEAN DEFAULT TRUE,
+		kanji_progression BOOLEAN DEFAULT FALSE,
+		unlock_stage INTEGER DEFAULT 2,
+		auto_link_confusions BOOLEAN DEFAULT FALSE,
+		frequency_corpus VARCHAR(50) DEFAULT ''
 	);`
 
 	createSRTable := `
@@ -193,8 +199,10 @@ func (db *Database) InitializeTables() error {
 		hiragana_only BOOLEAN DEFAULT FALSE,
 		katakana_only BOOLEAN DEFAULT FALSE,
 		frequency INTEGER,
+		jmdict_seq INTEGER,
+		owner_id INTEGER NOT NULL DEFAULT 0,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		UNIQUE(word, level)
+		UNIQUE(word, level, owner_id)
 	);`
 
 	// Hiragana table - stores all hiragana mora for beginners deck
@@ -233,6 +241,243 @@ func (db *Database) InitializeTables() error {
 		UNIQUE(user_id, kana_id, kana_type)
 	);`
 
+	// Kanji table - one row per kanji character that appears in the words table
+	createKanjiTable := `
+	CREATE TABLE IF NOT EXISTS kanji (
+		id SERIAL PRIMARY KEY,
+		character VARCHAR(10) NOT NULL UNIQUE,
+		meanings TEXT,
+		onyomi TEXT,
+		kunyomi TEXT,
+		stroke_count INTEGER,
+		level INTEGER,
+		frequency INTEGER,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Kanji components - the parts a kanji is built from (KRADFILE decomposition)
+	createKanjiComponentsTable := `
+	CREATE TABLE IF NOT EXISTS kanji_components (
+		id SERIAL PRIMARY KEY,
+		kanji_id INTEGER NOT NULL,
+		component VARCHAR(10) NOT NULL,
+		UNIQUE(kanji_id, component)
+	);`
+
+	// Word kanji - which kanji each word is written with
+	createWordKanjiTable := `
+	CREATE TABLE IF NOT EXISTS word_kanji (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		kanji_id INTEGER NOT NULL,
+		UNIQUE(word_id, kanji_id)
+	);`
+
+	// SR Kanji table - spaced repetition tracking for individual kanji
+	createSRKanjiTable := `
+	CREATE TABLE IF NOT EXISTS sr_kanji (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL,
+		kanji_id INTEGER NOT NULL,
+		repetitions INTEGER DEFAULT 0,
+		ef FLOAT DEFAULT 2.5,
+		interval INTEGER DEFAULT 0,
+		last_reviewed TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		next_review TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		suspended BOOLEAN DEFAULT FALSE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(user_id, kanji_id)
+	);`
+
+	// Kanji strokes - KanjiVG stroke path data, one row per stroke in drawing order
+	createKanjiStrokesTable := `
+	CREATE TABLE IF NOT EXISTS kanji_strokes (
+		id SERIAL PRIMARY KEY,
+		kanji_id INTEGER NOT NULL,
+		stroke_number INTEGER NOT NULL,
+		path TEXT NOT NULL,
+		UNIQUE(kanji_id, stroke_number)
+	);`
+
+	// JMdict entries - every entry from the offline JMdict import, stored whole as JSON
+	// words.jmdict_seq points here for the entries on the JLPT lists
+	createJMdictEntriesTable := `
+	CREATE TABLE IF NOT EXISTS jmdict_entries (
+		seq INTEGER PRIMARY KEY,
+		data JSONB NOT NULL,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Parts of speech - JMdict part-of-speech codes (e.g. "v5k", "adj-i", "adv")
+	createPartOfSpeechTable := `
+	CREATE TABLE IF NOT EXISTS part_of_speech (
+		id SERIAL PRIMARY KEY,
+		code VARCHAR(50) NOT NULL UNIQUE,
+		description TEXT NOT NULL
+	);`
+
+	// Word senses - each meaning of a word in dictionary order
+	// words.definitions and words.parts_of_speech stay as the flattened display strings
+	createWordSensesTable := `
+	CREATE TABLE IF NOT EXISTS word_senses (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		sense_order INTEGER NOT NULL,
+		glosses TEXT NOT NULL,
+		tags TEXT[] DEFAULT '{}',
+		UNIQUE(word_id, sense_order)
+	);`
+
+	// Word sense parts of speech - links each sense to its parts of speech
+	createWordSensePOSTable := `
+	CREATE TABLE IF NOT EXISTS word_sense_pos (
+		sense_id INTEGER NOT NULL,
+		pos_id INTEGER NOT NULL,
+		PRIMARY KEY(sense_id, pos_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_sense_pos_pos_id ON word_sense_pos(pos_id);`
+
+	// Word forms - every written form of a word, flagged primary, alternate or rare
+	createWordFormsTable := `
+	CREATE TABLE IF NOT EXISTS word_forms (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		form TEXT NOT NULL,
+		kind VARCHAR(10) NOT NULL DEFAULT 'primary',
+		position INTEGER NOT NULL DEFAULT 0,
+		UNIQUE(word_id, form)
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_forms_form ON word_forms(form);`
+
+	// Word readings - every kana reading of a word, flagged primary, alternate or rare
+	// Replaces the "まいげつ / まいつき" convention in words.furigana, which stays for display
+	createWordReadingsTable := `
+	CREATE TABLE IF NOT EXISTS word_readings (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		reading TEXT NOT NULL,
+		kind VARCHAR(10) NOT NULL DEFAULT 'primary',
+		position INTEGER NOT NULL DEFAULT 0,
+		UNIQUE(word_id, reading)
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_readings_reading ON word_readings(reading);`
+
+	// Pitch accents - Tokyo-dialect accent positions per (written form, reading), 0 = heiban
+	// Imported from a Kanjium-style accents file and matched to words through word_forms/word_readings
+	createPitchAccentsTable := `
+	CREATE TABLE IF NOT EXISTS pitch_accents (
+		form TEXT NOT NULL,
+		reading TEXT NOT NULL,
+		accents INTEGER[] NOT NULL,
+		PRIMARY KEY(form, reading)
+	);
+	CREATE INDEX IF NOT EXISTS idx_pitch_accents_reading ON pitch_accents(reading);`
+
+	// Sentences - example sentences from a Tatoeba/Tanaka corpus export
+	// tokens keeps the raw headword index line the sentence_words links were built from
+	createSentencesTable := `
+	CREATE TABLE IF NOT EXISTS sentences (
+		id SERIAL PRIMARY KEY,
+		source_id VARCHAR(50) NOT NULL UNIQUE,
+		japanese TEXT NOT NULL,
+		english TEXT NOT NULL,
+		tokens TEXT NOT NULL DEFAULT ''
+	);`
+
+	// Sentence words - links sentences to the words their indexed headwords resolve to
+	createSentenceWordsTable := `
+	CREATE TABLE IF NOT EXISTS sentence_words (
+		sentence_id INTEGER NOT NULL,
+		word_id INTEGER NOT NULL,
+		surface TEXT NOT NULL DEFAULT '',
+		good_example BOOLEAN DEFAULT FALSE,
+		PRIMARY KEY(sentence_id, word_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_sentence_words_word_id ON sentence_words(word_id);`
+
+	// Word corrections - user-proposed fixes to a word, waiting for an admin to apply or reject
+	createWordCorrectionsTable := `
+	CREATE TABLE IF NOT EXISTS word_corrections (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		field VARCHAR(30) NOT NULL,
+		original_value TEXT NOT NULL DEFAULT '',
+		proposed_value TEXT NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		status VARCHAR(20) NOT NULL DEFAULT 'pending',
+		reviewed_by INTEGER,
+		review_note TEXT NOT NULL DEFAULT '',
+		reviewed_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_corrections_status ON word_corrections(status);`
+
+	// Word edits - audit history of changes made to words through corrections
+	createWordEditsTable := `
+	CREATE TABLE IF NOT EXISTS word_edits (
+		id SERIAL PRIMARY KEY,
+		word_id INTEGER NOT NULL,
+		field VARCHAR(30) NOT NULL,
+		old_value TEXT NOT NULL DEFAULT '',
+		new_value TEXT NOT NULL DEFAULT '',
+		correction_id INTEGER,
+		edited_by INTEGER,
+		edited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_edits_word_id ON word_edits(word_id);`
+
+	// Frequency ranks per corpus (news, novels, ...); words.frequency holds the default list
+	createWordFrequenciesTable := `
+	CREATE TABLE IF NOT EXISTS word_frequencies (
+		word_id INTEGER NOT NULL,
+		corpus VARCHAR(50) NOT NULL,
+		rank INTEGER NOT NULL,
+		PRIMARY KEY (word_id, corpus)
+	);
+	CREATE INDEX IF NOT EXISTS idx_word_frequencies_corpus_rank ON word_frequencies(corpus, rank);`
+
+	// Search history - each user's recent searches, one row per distinct query
+	createSearchHistoryTable := `
+	CREATE TABLE IF NOT EXISTS search_history (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL,
+		query TEXT NOT NULL,
+		searched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(user_id, query)
+	);
+	CREATE INDEX IF NOT EXISTS idx_search_history_user_searched ON search_history(user_id, searched_at DESC);`
+
+	// Saved searches - searches a user pinned, with the filters they had on
+	createSavedSearchesTable := `
+	CREATE TABLE IF NOT EXISTS saved_searches (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL,
+		query TEXT NOT NULL,
+		level VARCHAR(10) NOT NULL DEFAULT '',
+		pos VARCHAR(20) NOT NULL DEFAULT '',
+		learned VARCHAR(20) NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(user_id, query, level, pos, learned)
+	);`
+
+	// Prefix indexes for pattern searches on forms and readings (たべ*, reading:か?), which
+	// text_pattern_ops lets LIKE 'x%' use whatever the collation
+	// Trigrams can't index Japanese here: with LC_CTYPE 'C' pg_trgm treats kana and kanji as
+	// separators, and most lookups are one or two characters, too short for a trigram anyway.
+	// Substring matches on kana scan the forms and readings instead.
+	createPatternIndexes := `
+	DROP INDEX IF EXISTS idx_word_forms_form_trgm;
+	DROP INDEX IF EXISTS idx_word_readings_reading_trgm;
+	CREATE INDEX IF NOT EXISTS idx_word_forms_form_pattern ON word_forms(form text_pattern_ops);
+	CREATE INDEX IF NOT EXISTS idx_word_readings_reading_pattern ON word_readings(reading text_pattern_ops);`
+
+	// Trigram indexes for SearchWords' substring matches on English definitions
+	createSearchIndexes := `
+	CREATE EXTENSION IF NOT EXISTS pg_trgm;
+	CREATE INDEX IF NOT EXISTS idx_words_definitions_trgm ON words USING GIN (LOWER(definitions) gin_trgm_ops);
+	CREATE INDEX IF NOT EXISTS idx_word_senses_glosses_trgm ON word_senses USING GIN (LOWER(glosses) gin_trgm_ops);`
+
 	// Execute table creation
 	_, err := db.DB.Exec(createSessionsTable)
 	if err != nil {
@@ -278,6 +523,91 @@ func (db *Database) InitializeTables() error {
 	if err != nil {
 		return fmt.Errorf("error creating sr_kana table: %w", err)
 	}
+	_, err = db.DB.Exec(createKanjiTable)
+	if err != nil {
+		return fmt.Errorf("error creating kanji table: %w", err)
+	}
+	_, err = db.DB.Exec(createKanjiComponentsTable)
+	if err != nil {
+		return fmt.Errorf("error creating kanji_components table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordKanjiTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_kanji table: %w", err)
+	}
+	_, err = db.DB.Exec(createSRKanjiTable)
+	if err != nil {
+		return fmt.Errorf("error creating sr_kanji table: %w", err)
+	}
+	_, err = db.DB.Exec(createKanjiStrokesTable)
+	if err != nil {
+		return fmt.Errorf("error creating kanji_strokes table: %w", err)
+	}
+	_, err = db.DB.Exec(createJMdictEntriesTable)
+	if err != nil {
+		return fmt.Errorf("error creating jmdict_entries table: %w", err)
+	}
+	_, err = db.DB.Exec(createPartOfSpeechTable)
+	if err != nil {
+		return fmt.Errorf("error creating part_of_speech table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordSensesTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_senses table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordSensePOSTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_sense_pos table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordFormsTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_forms table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordReadingsTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_readings table: %w", err)
+	}
+	_, err = db.DB.Exec(createPitchAccentsTable)
+	if err != nil {
+		return fmt.Errorf("error creating pitch_accents table: %w", err)
+	}
+	_, err = db.DB.Exec(createSentencesTable)
+	if err != nil {
+		return fmt.Errorf("error creating sentences table: %w", err)
+	}
+	_, err = db.DB.Exec(createSentenceWordsTable)
+	if err != nil {
+		return fmt.Errorf("error creating sentence_words table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordCorrectionsTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_corrections table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordEditsTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_edits table: %w", err)
+	}
+	_, err = db.DB.Exec(createWordFrequenciesTable)
+	if err != nil {
+		return fmt.Errorf("error creating word_frequencies table: %w", err)
+	}
+	_, err = db.DB.Exec(createSearchHistoryTable)
+	if err != nil {
+		return fmt.Errorf("error creating search_history table: %w", err)
+	}
+	_, err = db.DB.Exec(createSavedSearchesTable)
+	if err != nil {
+		return fmt.Errorf("error creating saved_searches table: %w", err)
+	}
+	_, err = db.DB.Exec(createPatternIndexes)
+	if err != nil {
+		return fmt.Errorf("error creating search pattern indexes: %w", err)
+	}
+	// pg_trgm needs CREATE privilege on the database; search still works without the indexes, just slower
+	_, err = db.DB.Exec(createSearchIndexes)
+	if err != nil {
+		log.Printf("⚠️  Skipping search indexes, pg_trgm is unavailable: %v", err)
+	}
 	log.Println("All tables created successfully")
 
 	return nil
@@ -304,11 +634,11 @@ func (db *Database) InitializeUserSRWords(userID int, level int) error {
 		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
 		SELECT $1::INTEGER, id, 0, 2.5, 0, 'english meaning', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 		FROM words
-		WHERE level = $2::INTEGER
+		WHERE level = $2::INTEGER AND owner_id = 0
 		UNION ALL
 		SELECT $1::INTEGER, id, 0, 2.5, 0, 'japanese pronunciation', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 		FROM words
-		WHERE level = $2::INTEGER AND katakana_only = FALSE
+		WHERE level = $2::INTEGER AND katakana_only = FALSE AND owner_id = 0
 		ON CONFLICT DO NOTHING
 	`
 	_, err := db.DB.Exec(query, userID, level)
@@ -330,6 +660,10 @@ type Word struct {
 	PartsOfSpeech string
 	HiraganaOnly  bool
 	CreatedAt     string
+
+	Senses   []Sense       // Structured senses in dictionary order (empty until migrated)
+	Forms    []WordForm    // Written forms, primary first (empty until migrated)
+	Readings []WordReading // Readings, primary first (empty until migrated)
 }
 
 // SRWord represents a word in the SR system with metadata
@@ -346,9 +680,14 @@ type SRWord struct {
 	Word         Word
 }
 
+// studyCardTypes keeps the word study queue to meaning and pronunciation cards; pitch accent
+// and sentence cloze cards have their own quizzes
+const studyCardTypes = "sr.type NOT IN ('" + SRTypePitch + "', '" + SRTypeCloze + "')"
+
 // GetNextSRWord retrieves the next word to study for a user (words due for review)
 // It considers user settings to skip pronunciation study for hiragana_only words if ShowHiraganaMostly is disabled
-func (db *Database) GetNextSRWord(userID int) (*SRWord, error) {
+// wordIDs limits it to those words, for studying a saved search as its own deck; nil means every word
+func (db *Database) GetNextSRWord(userID int, wordIDs []int) (*SRWord, error) {
 	// First, get user settings to check ShowHiraganaMostly preference
 	userSettings, err := db.GetUserSettings(userID)
 	if err != nil {
@@ -357,25 +696,19 @@ func (db *Database) GetNextSRWord(userID int) (*SRWord, error) {
 
 	// Build query with conditional filtering based on ShowHiraganaMostly setting
 	// Also filter out suspended words
-	var query string
-	if userSettings.ShowHiraganaMostly {
-		// Show all words including pronunciation for hiragana_only words
-		query = `
-			SELECT 
-				sr.id, sr.user_id, sr.word_id, sr.repetitions, sr.ef, sr.interval, sr.type,
-				sr.last_reviewed, sr.next_review,
-				w.id, w.word, w.furigana, w.romaji, w.level, w.definitions, w.parts_of_speech, w.hiragana_only, w.created_at
-			FROM sr
-			JOIN words w ON sr.word_id = w.id
-			WHERE sr.user_id = $1 
-				AND sr.next_review <= CURRENT_TIMESTAMP
-				AND (sr.suspended = FALSE OR sr.suspended IS NULL)
-			ORDER BY sr.next_review ASC
-			LIMIT 1
-		`
-	} else {
+	args := []interface{}{userID}
+	filters := ""
+	if !userSettings.ShowHiraganaMostly {
 		// Skip pronunciation study for hiragana_only words
-		query = `
+		filters += `
+				AND NOT (w.hiragana_only = TRUE AND sr.type = 'japanese pronunciation')`
+	}
+	if wordIDs != nil {
+		args = append(args, pq.Array(wordIDs))
+		filters += `
+				AND sr.word_id = ANY($2)`
+	}
+	query := `
 			SELECT 
 				sr.id, sr.user_id, sr.word_id, sr.repetitions, sr.ef, sr.interval, sr.type,
 				sr.last_reviewed, sr.next_review,
@@ -383,16 +716,15 @@ func (db *Database) GetNextSRWord(userID int) (*SRWord, error) {
 			FROM sr
 			JOIN words w ON sr.word_id = w.id
 			WHERE sr.user_id = $1 
-				AND sr.next_review <= CURRENT_TIMESTAMP
-				AND NOT (w.hiragana_only = TRUE AND sr.type = 'japanese pronunciation')
+				AND sr.next_review <= CURRENT_TIMESTAMP` + filters + `
+				AND ` + studyCardTypes + `
 				AND (sr.suspended = FALSE OR sr.suspended IS NULL)
 			ORDER BY sr.next_review ASC
 			LIMIT 1
 		`
-	}
 
 	var srWord SRWord
-	err = db.DB.QueryRow(query, userID).Scan(
+	err = db.DB.QueryRow(query, args...).Scan(
 		&srWord.SRID, &srWord.UserID, &srWord.WordID, &srWord.Repetitions,
 		&srWord.EF, &srWord.Interval, &srWord.Type, &srWord.LastReviewed, &srWord.NextReview,
 		&srWord.Word.ID, &srWord.Word.Word, &srWord.Word.Furigana, &srWord.Word.Romaji,
@@ -405,12 +737,15 @@ func (db *Database) GetNextSRWord(userID int) (*SRWord, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get next SR word: %w", err)
 	}
+	if err := db.attachWordDetails(&srWord.Word); err != nil {
+		return nil, err
+	}
 
 	return &srWord, nil
 }
 
 // GetNextSRWordAdverbs retrieves the next adverb word to study for a user (words due for review)
-// It filters words where "adverb" appears in the parts_of_speech column (semicolon-separated)
+// It filters words with a sense tagged as an adverb (adv or adv-to) in word_senses
 // It considers user settings to skip pronunciation study for hiragana_only words if ShowHiraganaMostly is disabled
 func (db *Database) GetNextSRWordAdverbs(userID int) (*SRWord, error) {
 	// First, get user settings to check ShowHiraganaMostly preference
@@ -420,7 +755,7 @@ func (db *Database) GetNextSRWordAdverbs(userID int) (*SRWord, error) {
 	}
 
 	// Build query with conditional filtering based on ShowHiraganaMostly setting
-	// Filter for adverbs by checking if "adverb" appears in the semicolon-separated parts_of_speech
+	// Filter for adverbs through the structured word senses
 	// Also filter out suspended words
 	var query string
 	if userSettings.ShowHiraganaMostly {
@@ -434,8 +769,13 @@ func (db *Database) GetNextSRWordAdverbs(userID int) (*SRWord, error) {
 			JOIN words w ON sr.word_id = w.id
 			WHERE sr.user_id = $1 
 				AND sr.next_review <= CURRENT_TIMESTAMP
-				AND w.parts_of_speech IS NOT NULL
-				AND ((';' || w.parts_of_speech || ';') LIKE '%;adverb;%')
+				AND EXISTS (
+					SELECT 1 FROM word_senses ws
+					JOIN word_sense_pos wsp ON wsp.sense_id = ws.id
+					JOIN part_of_speech p ON p.id = wsp.pos_id
+					WHERE ws.word_id = w.id AND p.code IN ('adv', 'adv-to')
+				)
+				AND ` + studyCardTypes + `
 				AND (sr.suspended = FALSE OR sr.suspended IS NULL)
 			ORDER BY sr.next_review ASC
 			LIMIT 1
@@ -452,8 +792,13 @@ func (db *Database) GetNextSRWordAdverbs(userID int) (*SRWord, error) {
 			WHERE sr.user_id = $1 
 				AND sr.next_review <= CURRENT_TIMESTAMP
 				AND NOT (w.hiragana_only = TRUE AND sr.type = 'japanese pronunciation')
-				AND w.parts_of_speech IS NOT NULL
-				AND ((';' || w.parts_of_speech || ';') LIKE '%;adverb;%')
+				AND EXISTS (
+					SELECT 1 FROM word_senses ws
+					JOIN word_sense_pos wsp ON wsp.sense_id = ws.id
+					JOIN part_of_speech p ON p.id = wsp.pos_id
+					WHERE ws.word_id = w.id AND p.code IN ('adv', 'adv-to')
+				)
+				AND ` + studyCardTypes + `
 				AND (sr.suspended = FALSE OR sr.suspended IS NULL)
 			ORDER BY sr.next_review ASC
 			LIMIT 1
@@ -474,10 +819,24 @@ func (db *Database) GetNextSRWordAdverbs(userID int) (*SRWord, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get next SR adverb word: %w", err)
 	}
+	if err := db.attachWordDetails(&srWord.Word); err != nil {
+		return nil, err
+	}
 
 	return &srWord, nil
 }
 
+// IsUserSRCard reports whether the SR entry belongs to the user and is a card of the given type
+func (db *Database) IsUserSRCard(userID int, srID int, srType string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM sr WHERE id = $1 AND user_id = $2 AND type = $3)`
+	err := db.DB.QueryRow(query, srID, userID, srType).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check SR card: %w", err)
+	}
+	return exists, nil
+}
+
 func (db *Database) LookupWordBySRId(srID int) (*Word, error) {
 	query := `
 	SELECT w.id, w.word, w.furigana, w.romaji, w.level, w.definitions, w.parts_of_speech, w.hiragana_only, w.created_at
@@ -490,6 +849,30 @@ func (db *Database) LookupWordBySRId(srID int) (*Word, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to lookup word by ID: %w", err)
 	}
+	if err := db.attachWordDetails(&word); err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+// GetWordByID gets a word by its ID, or nil if it doesn't exist
+func (db *Database) GetWordByID(wordID int) (*Word, error) {
+	query := `
+	SELECT id, word, furigana, romaji, level, definitions, parts_of_speech, hiragana_only, created_at
+	FROM words
+	WHERE id = $1
+	`
+	var word Word
+	err := db.DB.QueryRow(query, wordID).Scan(&word.ID, &word.Word, &word.Furigana, &word.Romaji, &word.Level, &word.Definitions, &word.PartsOfSpeech, &word.HiraganaOnly, &word.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word: %w", err)
+	}
+	if err := db.attachWordDetails(&word); err != nil {
+		return nil, err
+	}
 	return &word, nil
 }
 
@@ -504,6 +887,11 @@ type UserSettings struct {
 	Key4               string
 	Key5               string
 	ShowHiraganaMostly bool
+	KanjiProgression   bool // Only unlock words once their kanji reach UnlockStage
+	UnlockStage        int  // SR repetitions a kanji needs before it counts as learned
+	AutoLinkConfusions bool // Link look-alike words to kanji_confusion automatically on wrong answers
+
+	FrequencyCorpus string // Corpus whose frequency ranks order the Learn page, DefaultFrequencyCorpus for words.frequency
 }
 
 type UserInfo struct {
@@ -516,12 +904,15 @@ type UserInfo struct {
 func (db *Database) GetUserSettings(userID int) (*UserSettings, error) {
 	var userSettings UserSettings
 	query := `
-		SELECT id, user_id, sr_time_japanese, sr_time_english, submit_key, key_1, key_2, key_3, key_4, key_5, show_hiragana_mostly 
+		SELECT id, user_id, sr_time_japanese, sr_time_english, submit_key, key_1, key_2, key_3, key_4, key_5, show_hiragana_mostly,
+		       COALESCE(kanji_progression, false), COALESCE(unlock_stage, 2), COALESCE(auto_link_confusions, false),
+		       COALESCE(frequency_corpus, '')
 		FROM user_settings 
 		WHERE user_id = $1
 	`
 	var id int // temporary variable to scan the id column
-	err := db.DB.QueryRow(query, userID).Scan(&id, &userSettings.UserID, &userSettings.SRTimeJapanese, &userSettings.SRTimeEnglish, &userSettings.SubmitKey, &userSettings.Key1, &userSettings.Key2, &userSettings.Key3, &userSettings.Key4, &userSettings.Key5, &userSettings.ShowHiraganaMostly)
+	err := db.DB.QueryRow(query, userID).Scan(&id, &userSettings.UserID, &userSettings.SRTimeJapanese, &userSettings.SRTimeEnglish, &userSettings.SubmitKey, &userSettings.Key1, &userSettings.Key2, &userSettings.Key3, &userSettings.Key4, &userSettings.Key5, &userSettings.ShowHiraganaMostly,
+		&userSettings.KanjiProgression, &userSettings.UnlockStage, &userSettings.AutoLinkConfusions, &userSettings.FrequencyCorpus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user settings: %w", err)
 	}
@@ -539,10 +930,15 @@ func (db *Database) UpdateUserSettings(userID int, settings *UserSettings) error
 		    key_3 = $7, 
 		    key_4 = $8, 
 		    key_5 = $9,
-		    show_hiragana_mostly = $10
+		    show_hiragana_mostly = $10,
+		    kanji_progression = $11,
+		    unlock_stage = $12,
+		    auto_link_confusions = $13,
+		    frequency_corpus = $14
 		WHERE user_id = $1
 	`
-	_, err := db.DB.Exec(query, userID, settings.SRTimeJapanese, settings.SRTimeEnglish, settings.SubmitKey, settings.Key1, settings.Key2, settings.Key3, settings.Key4, settings.Key5, settings.ShowHiraganaMostly)
+	_, err := db.DB.Exec(query, userID, settings.SRTimeJapanese, settings.SRTimeEnglish, settings.SubmitKey, settings.Key1, settings.Key2, settings.Key3, settings.Key4, settings.Key5, settings.ShowHiraganaMostly,
+		settings.KanjiProgression, settings.UnlockStage, settings.AutoLinkConfusions, settings.FrequencyCorpus)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
@@ -911,25 +1307,41 @@ type LearnWord struct {
 	PartsOfSpeech string
 	HiraganaOnly  bool
 	KatakanaOnly  bool
-	IsLearned     bool // Whether the user has added this word to their SR deck
-	Frequency     *int // Frequency rank (lower = more common), nil if no data
-	IsSuspended   bool // Whether the word is suspended (user marked as "known")
+	IsLearned     bool     // Whether the user has added this word to their SR deck
+	Frequency     *int     // Frequency rank (lower = more common), nil if no data
+	IsSuspended   bool     // Whether the word is suspended (user marked as "known")
+	IsLocked      bool     // Kanji progression: the word's kanji have not reached the unlock stage yet
+	LockedBy      []string // Kanji progression: the kanji still below the unlock stage
+
+	Senses   []Sense       // Structured senses in dictionary order (empty until migrated)
+	Readings []WordReading // Readings with pitch accents, primary first (empty until migrated)
 }
 
 // GetWordsForLearning retrieves words by level with pagination for the Learn page
 // Returns words along with whether the user has already added them to their SR deck
+// Words are ordered by their rank in the user's chosen frequency corpus
 func (db *Database) GetWordsForLearning(userID int, level int, limit int, offset int) ([]LearnWord, int, error) {
+	userSettings, err := db.GetUserSettings(userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	corpus := userSettings.FrequencyCorpus
+
 	// Get total count of words (at this level, or all levels if level=0)
 	var totalCount int
 	var countQuery string
-	var err error
 
 	if level == 0 {
 		// All levels mode - only count words with frequency data
-		countQuery = `SELECT COUNT(*) FROM words WHERE frequency IS NOT NULL`
-		err = db.DB.QueryRow(countQuery).Scan(&totalCount)
+		join, rank := frequencyRank(corpus, "$1")
+		countQuery = `SELECT COUNT(*) FROM words w ` + join + ` WHERE ` + rank + ` IS NOT NULL AND w.owner_id = 0`
+		if corpus == DefaultFrequencyCorpus {
+			err = db.DB.QueryRow(countQuery).Scan(&totalCount)
+		} else {
+			err = db.DB.QueryRow(countQuery, corpus).Scan(&totalCount)
+		}
 	} else {
-		countQuery = `SELECT COUNT(*) FROM words WHERE level = $1`
+		countQuery = `SELECT COUNT(*) FROM words WHERE level = $1 AND owner_id = 0`
 		err = db.DB.QueryRow(countQuery, level).Scan(&totalCount)
 	}
 	if err != nil {
@@ -940,6 +1352,18 @@ func (db *Database) GetWordsForLearning(userID int, level int, limit int, offset
 	var query string
 	var rows *sql.Rows
 
+	// The corpus is always the last parameter; the default corpus doesn't use it
+	args := []interface{}{userID}
+	if level == 0 {
+		args = append(args, limit, offset)
+	} else {
+		args = append(args, level, limit, offset)
+	}
+	join, rank := frequencyRank(corpus, fmt.Sprintf("$%d", len(args)+1))
+	if corpus != DefaultFrequencyCorpus {
+		args = append(args, corpus)
+	}
+
 	if level == 0 {
 		// All levels mode - sort purely by frequency, only include words with frequency
 		query = `
@@ -950,16 +1374,16 @@ func (db *Database) GetWordsForLearning(userID int, level int, limit int, offset
 				CASE WHEN EXISTS (
 					SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id
 				) THEN true ELSE false END as is_learned,
-				w.frequency,
+				` + rank + `,
 				CASE WHEN EXISTS (
 					SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.suspended = true
 				) THEN true ELSE false END as is_suspended
 			FROM words w
-			WHERE w.frequency IS NOT NULL
-			ORDER BY w.frequency ASC
+			` + join + `
+			WHERE ` + rank + ` IS NOT NULL AND w.owner_id = 0
+			ORDER BY ` + rank + ` ASC, w.id ASC
 			LIMIT $2 OFFSET $3
 		`
-		rows, err = db.DB.Query(query, userID, limit, offset)
 	} else {
 		query = `
 			SELECT 
@@ -969,17 +1393,18 @@ func (db *Database) GetWordsForLearning(userID int, level int, limit int, offset
 				CASE WHEN EXISTS (
 					SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id
 				) THEN true ELSE false END as is_learned,
-				w.frequency,
+				` + rank + `,
 				CASE WHEN EXISTS (
 					SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.suspended = true
 				) THEN true ELSE false END as is_suspended
 			FROM words w
-			WHERE w.level = $2
-			ORDER BY w.frequency ASC NULLS LAST, w.id ASC
+			` + join + `
+			WHERE w.level = $2 AND w.owner_id = 0
+			ORDER BY ` + rank + ` ASC NULLS LAST, w.id ASC
 			LIMIT $3 OFFSET $4
 		`
-		rows, err = db.DB.Query(query, userID, level, limit, offset)
 	}
+	rows, err = db.DB.Query(query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get words for learning: %w", err)
 	}
@@ -1009,16 +1434,47 @@ func (db *Database) GetWordsForLearning(userID int, level int, limit int, offset
 		words = append(words, word)
 	}
 
+	ids := make([]int, len(words))
+	for i := range words {
+		ids[i] = words[i].ID
+	}
+	senses, err := db.GetWordSenses(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	readings, err := db.GetWordReadings(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range words {
+		words[i].Senses = senses[words[i].ID]
+		words[i].Readings = readings[words[i].ID]
+	}
+
+	// In kanji progression mode, flag words whose kanji the user hasn't learned yet
+	if userSettings.KanjiProgression {
+		if err := db.markLockedWords(userID, userSettings.UnlockStage, words); err != nil {
+			return nil, 0, fmt.Errorf("failed to check word locks: %w", err)
+		}
+	}
+
 	return words, totalCount, nil
 }
 
 // AddWordToSR adds a specific word to a user's SR deck
 // Creates both english meaning and japanese pronunciation entries (if not katakana_only)
+// With kanji progression on, a word whose kanji aren't unlocked yet returns an ErrLocked error,
+// unless it's already in the deck
 func (db *Database) AddWordToSR(userID int, wordID int) error {
 	// First check if word exists and get its katakana_only status
-	var katakanaOnly bool
-	checkQuery := `SELECT COALESCE(katakana_only, false) FROM words WHERE id = $1`
-	err := db.DB.QueryRow(checkQuery, wordID).Scan(&katakanaOnly)
+	var word string
+	var katakanaOnly, inDeck bool
+	checkQuery := `
+		SELECT word, COALESCE(katakana_only, false),
+		       EXISTS(SELECT 1 FROM sr WHERE sr.user_id = $2 AND sr.word_id = words.id)
+		FROM words WHERE id = $1 AND owner_id IN (0, $2)
+	`
+	err := db.DB.QueryRow(checkQuery, wordID, userID).Scan(&word, &katakanaOnly, &inDeck)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("word not found")
@@ -1026,6 +1482,22 @@ func (db *Database) AddWordToSR(userID int, wordID int) error {
 		return fmt.Errorf("failed to check word: %w", err)
 	}
 
+	if !inDeck {
+		userSettings, err := db.GetUserSettings(userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user settings: %w", err)
+		}
+		if userSettings.KanjiProgression {
+			locked, err := db.wordLockedBy(userID, word, userSettings.UnlockStage)
+			if err != nil {
+				return err
+			}
+			if len(locked) > 0 {
+				return fmt.Errorf("%s needs %s first: %w", word, strings.Join(locked, ", "), ErrLocked)
+			}
+		}
+	}
+
 	// Insert meaning entry
 	insertMeaning := `
 		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
@@ -1050,19 +1522,35 @@ func (db *Database) AddWordToSR(userID int, wordID int) error {
 		}
 	}
 
+	// Pitch accent card, if the user has turned them on
+	if err := db.addSRPitch(userID, wordID); err != nil {
+		return err
+	}
+
+	// Sentence cloze card, if the user has turned them on
+	if err := db.addSRCloze(userID, wordID); err != nil {
+		return err
+	}
+
 	log.Printf("✅ Added word %d to SR deck for user %d", wordID, userID)
 	return nil
 }
 
-// AddMultipleWordsToSR adds multiple words to a user's SR deck
-func (db *Database) AddMultipleWordsToSR(userID int, wordIDs []int) error {
+// AddMultipleWordsToSR adds multiple words to a user's SR deck and returns how many it added
+// Words kanji progression keeps locked are skipped rather than failing the rest
+func (db *Database) AddMultipleWordsToSR(userID int, wordIDs []int) (int, error) {
+	added := 0
 	for _, wordID := range wordIDs {
 		err := db.AddWordToSR(userID, wordID)
+		if errors.Is(err, ErrLocked) {
+			continue
+		}
 		if err != nil {
-			return fmt.Errorf("failed to add word %d: %w", wordID, err)
+			return added, fmt.Errorf("failed to add word %d: %w", wordID, err)
 		}
+		added++
 	}
-	return nil
+	return added, nil
 }
 
 // ToggleWordSuspended toggles the suspended status for all SR entries of a word for a user
@@ -1114,7 +1602,7 @@ func (db *Database) SuspendWord(userID int, wordID int) (bool, error) {
 
 // GetLevelWordCounts returns the count of words at each JLPT level
 func (db *Database) GetLevelWordCounts() (map[int]int, error) {
-	query := `SELECT level, COUNT(*) FROM words GROUP BY level ORDER BY level DESC`
+	query := `SELECT level, COUNT(*) FROM words WHERE owner_id = 0 GROUP BY level ORDER BY level DESC`
 	rows, err := db.DB.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get word counts: %w", err)
@@ -1138,7 +1626,7 @@ func (db *Database) GetUserLearnedCountByLevel(userID int) (map[int]int, error)
 		SELECT w.level, COUNT(DISTINCT sr.word_id) 
 		FROM sr 
 		JOIN words w ON sr.word_id = w.id 
-		WHERE sr.user_id = $1 
+		WHERE sr.user_id = $1 AND w.owner_id = 0
 		GROUP BY w.level
 	`
 	rows, err := db.DB.Query(query, userID)
@@ -1166,6 +1654,15 @@ type KanjiWord struct {
 	Level         int
 	Definitions   string
 	PartsOfSpeech string
+
+	Senses   []Sense       // Structured senses in dictionary order (empty until migrated)
+	Readings []WordReading // Readings with pitch accents, primary first (empty until migrated)
+	Example  *Sentence     // Example sentence, set by AttachExampleSentences
+
+	Custom       bool   // A private word the user created, set by SearchWords
+	Deinflection string // How the query inflects this word, e.g. "passive → negative → past", set by SearchWords
+	Learned      bool   // Whether the word is in the user's SR deck, set by SearchWords
+	Frequency    int    // Rank in the user's frequency corpus, 0 if unranked, set by SearchWords
 }
 
 // GetWordsByKanji searches for all words containing a specific kanji character
@@ -1174,7 +1671,7 @@ func (db *Database) GetWordsByKanji(kanji string) ([]KanjiWord, error) {
 	query := `
 		SELECT id, word, furigana, level, definitions, parts_of_speech
 		FROM words
-		WHERE word LIKE '%' || $1 || '%'
+		WHERE word LIKE '%' || $1 || '%' AND owner_id = 0
 		ORDER BY level DESC, word ASC
 	`
 
@@ -1198,78 +1695,9 @@ func (db *Database) GetWordsByKanji(kanji string) ([]KanjiWord, error) {
 		words = append(words, word)
 	}
 
-	return words, nil
-}
-
-// SearchWords searches for words based on the query string
-// If the query contains Japanese characters (hiragana, katakana, kanji), it searches word and furigana
-// Otherwise, it searches the English definitions
-// Results are ordered by JLPT level (N5 first, then N4, etc.)
-func (db *Database) SearchWords(query string) ([]KanjiWord, string, error) {
-	// Detect if query contains Japanese characters
-	isJapanese := containsJapanese(query)
-
-	var sqlQuery string
-	var searchType string
-
-	if isJapanese {
-		// Search in word and furigana fields
-		sqlQuery = `
-			SELECT id, word, furigana, level, definitions, parts_of_speech
-			FROM words
-			WHERE word LIKE '%' || $1 || '%' OR furigana LIKE '%' || $1 || '%'
-			ORDER BY level DESC, word ASC
-			LIMIT 100
-		`
-		searchType = "japanese"
-	} else {
-		// Search in English definitions (case-insensitive)
-		sqlQuery = `
-			SELECT id, word, furigana, level, definitions, parts_of_speech
-			FROM words
-			WHERE LOWER(definitions) LIKE '%' || LOWER($1) || '%'
-			ORDER BY level DESC, word ASC
-			LIMIT 100
-		`
-		searchType = "english"
-	}
-
-	rows, err := db.DB.Query(sqlQuery, query)
-	if err != nil {
-		return nil, searchType, fmt.Errorf("failed to search words: %w", err)
-	}
-	defer rows.Close()
-
-	var words []KanjiWord
-	for rows.Next() {
-		var word KanjiWord
-		var furigana, definitions, partsOfSpeech sql.NullString
-		err := rows.Scan(&word.ID, &word.Word, &furigana, &word.Level, &definitions, &partsOfSpeech)
-		if err != nil {
-			return nil, searchType, fmt.Errorf("failed to scan word: %w", err)
-		}
-		word.Furigana = furigana.String
-		word.Definitions = definitions.String
-		word.PartsOfSpeech = partsOfSpeech.String
-		words = append(words, word)
+	if err := db.attachKanjiWordDetails(words); err != nil {
+		return nil, err
 	}
 
-	return words, searchType, nil
-}
-
-// containsJapanese checks if a string contains Japanese characters
-func containsJapanese(s string) bool {
-	for _, r := range s {
-		// Hiragana: U+3040 - U+309F
-		// Katakana: U+30A0 - U+30FF
-		// CJK Unified Ideographs (Kanji): U+4E00 - U+9FAF
-		// CJK Extension A: U+3400 - U+4DBF
-		if (r >= 0x3040 && r <= 0x309F) || // Hiragana
-			(r >= 0x30A0 && r <= 0x30FF) || // Katakana
-			(r >= 0x4E00 && r <= 0x9FAF) || // CJK Unified Ideographs
-			(r >= 0x3400 && r <= 0x4DBF) { // CJK Extension A
-			return true
-		}
-	}
-	return false
+	return words, nil
 }
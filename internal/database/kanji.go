@@ -0,0 +1,443 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ErrLocked is returned when kanji progression keeps a word or kanji out of the user's deck
+// until the kanji it's built from reach the unlock stage
+var ErrLocked = errors.New("locked until its kanji are learned")
+
+// Kanji represents a single kanji character from the kanji table
+type Kanji struct {
+	ID          int
+	Character   string
+	Meanings    string // semicolon-separated, same as words.definitions
+	Onyomi      string
+	Kunyomi     string
+	StrokeCount int
+	Level       int
+}
+
+// SRKanji represents a kanji in the SR system with metadata
+type SRKanji struct {
+	SRID         int
+	UserID       int
+	KanjiID      int
+	Repetitions  int
+	EF           float64
+	Interval     int
+	LastReviewed string
+	NextReview   string
+	Kanji        Kanji
+}
+
+// LearnKanji represents a kanji for the Learn page progression panel
+type LearnKanji struct {
+	ID         int
+	Character  string
+	Meanings   string
+	Level      int
+	IsUnlocked bool     // All of its kanji components have reached the unlock stage
+	LockedBy   []string // Components still below the unlock stage
+}
+
+// AcceptsMeaning reports whether answer is one of the kanji's meanings, split on ";" and ","
+// Case, spacing, parentheticals and a leading "to", "a", "an" or "the" don't matter, but part
+// of a meaning isn't enough: "on" doesn't match "one"
+func (k *Kanji) AcceptsMeaning(answer string) bool {
+	return hasMeaning(k.Meanings, answer)
+}
+
+// hasMeaning reports whether answer is one of the ";" and ","-separated meanings, compared
+// after normalizeMeaning
+func hasMeaning(meanings string, answer string) bool {
+	answer = normalizeMeaning(answer)
+	if answer == "" {
+		return false
+	}
+	for _, sense := range strings.Split(meanings, ";") {
+		for _, meaning := range strings.Split(sense, ",") {
+			if normalizeMeaning(meaning) == answer {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeMeaning lower-cases an English meaning, drops parentheticals and a leading "to",
+// "a", "an" or "the", and collapses spaces
+func normalizeMeaning(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	words := strings.Fields(b.String())
+	if len(words) > 1 {
+		switch words[0] {
+		case "to", "a", "an", "the":
+			words = words[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ExtractKanji returns the kanji characters contained in s, in order
+func ExtractKanji(s string) []rune {
+	var kanji []rune
+	for _, r := range s {
+		// Kanji range: U+4E00 to U+9FFF, with some extensions
+		if (r >= 0x4E00 && r <= 0x9FFF) ||
+			(r >= 0x3400 && r <= 0x4DBF) || // CJK Extension A
+			(r >= 0x20000 && r <= 0x2A6DF) { // CJK Extension B (rare)
+			kanji = append(kanji, r)
+		}
+	}
+	return kanji
+}
+
+// GetKanjiStages returns the SR stage (repetitions) of every kanji the user has in their deck, keyed by character
+func (db *Database) GetKanjiStages(userID int) (map[string]int, error) {
+	query := `
+		SELECT k.character, sk.repetitions
+		FROM sr_kanji sk
+		JOIN kanji k ON sk.kanji_id = k.id
+		WHERE sk.user_id = $1
+	`
+	rows, err := db.DB.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji stages: %w", err)
+	}
+	defer rows.Close()
+
+	stages := make(map[string]int)
+	for rows.Next() {
+		var character string
+		var repetitions int
+		if err := rows.Scan(&character, &repetitions); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji stage: %w", err)
+		}
+		stages[character] = repetitions
+	}
+	return stages, nil
+}
+
+// GetKnownKanjiSet returns the set of kanji characters present in the kanji table
+// Characters outside this set (e.g. rare variants) never block progression
+func (db *Database) GetKnownKanjiSet() (map[string]bool, error) {
+	rows, err := db.DB.Query(`SELECT character FROM kanji`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji set: %w", err)
+	}
+	defer rows.Close()
+
+	set := make(map[string]bool)
+	for rows.Next() {
+		var character string
+		if err := rows.Scan(&character); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji: %w", err)
+		}
+		set[character] = true
+	}
+	return set, nil
+}
+
+// markLockedWords flags words whose kanji have not yet reached the user's unlock stage
+func (db *Database) markLockedWords(userID int, unlockStage int, words []LearnWord) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	stages, err := db.GetKanjiStages(userID)
+	if err != nil {
+		return err
+	}
+	tracked, err := db.GetKnownKanjiSet()
+	if err != nil {
+		return err
+	}
+
+	for i := range words {
+		seen := make(map[string]bool)
+		for _, k := range ExtractKanji(words[i].Word) {
+			char := string(k)
+			if seen[char] || !tracked[char] {
+				continue
+			}
+			seen[char] = true
+			if stages[char] < unlockStage {
+				words[i].LockedBy = append(words[i].LockedBy, char)
+			}
+		}
+		words[i].IsLocked = len(words[i].LockedBy) > 0 && !words[i].IsLearned
+	}
+	return nil
+}
+
+// wordLockedBy returns the kanji in a word that are in the kanji table and still below the
+// user's unlock stage, the same rule markLockedWords shows on the Learn page
+func (db *Database) wordLockedBy(userID int, word string, unlockStage int) ([]string, error) {
+	var chars []string
+	for _, k := range ExtractKanji(word) {
+		chars = append(chars, string(k))
+	}
+	if len(chars) == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT k.character
+		FROM kanji k
+		LEFT JOIN sr_kanji sk ON sk.kanji_id = k.id AND sk.user_id = $1
+		WHERE k.character = ANY($2) AND COALESCE(sk.repetitions, 0) < $3
+		ORDER BY k.character
+	`, userID, pq.Array(chars), unlockStage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check word locks: %w", err)
+	}
+	defer rows.Close()
+
+	var locked []string
+	for rows.Next() {
+		var character string
+		if err := rows.Scan(&character); err != nil {
+			return nil, fmt.Errorf("failed to scan locking kanji: %w", err)
+		}
+		locked = append(locked, character)
+	}
+	return locked, rows.Err()
+}
+
+// kanjiLockedBy returns the components of a kanji that are kanji themselves and still below the
+// user's unlock stage, the same rule GetKanjiForLearning uses
+func (db *Database) kanjiLockedBy(userID int, kanjiID int, unlockStage int) (string, error) {
+	var locked string
+	err := db.DB.QueryRow(`
+		SELECT COALESCE(string_agg(ck.character, '' ORDER BY ck.character), '')
+		FROM kanji_components kc
+		JOIN kanji ck ON ck.character = kc.component AND ck.id <> kc.kanji_id
+		LEFT JOIN sr_kanji sk ON sk.kanji_id = ck.id AND sk.user_id = $1
+		WHERE kc.kanji_id = $2 AND COALESCE(sk.repetitions, 0) < $3
+	`, userID, kanjiID, unlockStage).Scan(&locked)
+	if err != nil {
+		return "", fmt.Errorf("failed to check kanji locks: %w", err)
+	}
+	return locked, nil
+}
+
+// GetKanjiForLearning returns kanji the user has not added yet, split into unlocked and upcoming (locked)
+// A kanji is unlocked once every component that is itself a kanji has reached unlockStage
+func (db *Database) GetKanjiForLearning(userID int, unlockStage int, limit int) ([]LearnKanji, []LearnKanji, error) {
+	query := `
+		SELECT
+			k.id, k.character, COALESCE(k.meanings, ''), COALESCE(k.level, 0),
+			COALESCE((
+				SELECT string_agg(ck.character, '' ORDER BY ck.character)
+				FROM kanji_components kc
+				JOIN kanji ck ON ck.character = kc.component AND ck.id <> k.id
+				LEFT JOIN sr_kanji sk ON sk.kanji_id = ck.id AND sk.user_id = $1
+				WHERE kc.kanji_id = k.id AND COALESCE(sk.repetitions, 0) < $2
+			), '') AS locked_by
+		FROM kanji k
+		WHERE NOT EXISTS (
+			SELECT 1 FROM sr_kanji sk WHERE sk.kanji_id = k.id AND sk.user_id = $1
+		)
+		ORDER BY k.level DESC NULLS LAST, k.frequency ASC NULLS LAST, k.id ASC
+	`
+	rows, err := db.DB.Query(query, userID, unlockStage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get kanji for learning: %w", err)
+	}
+	defer rows.Close()
+
+	var unlocked, upcoming []LearnKanji
+	for rows.Next() {
+		var k LearnKanji
+		var lockedBy string
+		if err := rows.Scan(&k.ID, &k.Character, &k.Meanings, &k.Level, &lockedBy); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan kanji: %w", err)
+		}
+		for _, r := range lockedBy {
+			k.LockedBy = append(k.LockedBy, string(r))
+		}
+		k.IsUnlocked = len(k.LockedBy) == 0
+
+		if k.IsUnlocked && len(unlocked) < limit {
+			unlocked = append(unlocked, k)
+		} else if !k.IsUnlocked && len(upcoming) < limit {
+			upcoming = append(upcoming, k)
+		}
+		if len(unlocked) >= limit && len(upcoming) >= limit {
+			break
+		}
+	}
+	return unlocked, upcoming, nil
+}
+
+// AddKanjiToSR adds a kanji to a user's SR deck
+// With kanji progression on, a kanji whose components aren't unlocked yet returns an ErrLocked error
+func (db *Database) AddKanjiToSR(userID int, kanjiID int) error {
+	userSettings, err := db.GetUserSettings(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user settings: %w", err)
+	}
+	if userSettings.KanjiProgression {
+		locked, err := db.kanjiLockedBy(userID, kanjiID, userSettings.UnlockStage)
+		if err != nil {
+			return err
+		}
+		if locked != "" {
+			return fmt.Errorf("kanji %d needs %s first: %w", kanjiID, strings.Join(strings.Split(locked, ""), ", "), ErrLocked)
+		}
+	}
+
+	query := `
+		INSERT INTO sr_kanji (user_id, kanji_id, repetitions, ef, interval, last_reviewed, next_review)
+		VALUES ($1, $2, 0, 2.5, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, kanji_id) DO NOTHING
+	`
+	_, err = db.DB.Exec(query, userID, kanjiID)
+	if err != nil {
+		return fmt.Errorf("failed to add kanji to SR: %w", err)
+	}
+	log.Printf("✅ Added kanji %d to SR deck for user %d", kanjiID, userID)
+	return nil
+}
+
+// HasUserSRKanji checks if a user has any kanji in their SR kanji table
+func (db *Database) HasUserSRKanji(userID int) (bool, error) {
+	var count int
+	err := db.DB.QueryRow(`SELECT COUNT(*) FROM sr_kanji WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user SR kanji: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetNextSRKanji retrieves the next kanji to study for a user (kanji due for review)
+func (db *Database) GetNextSRKanji(userID int) (*SRKanji, error) {
+	query := `
+		SELECT
+			sk.id, sk.user_id, sk.kanji_id, sk.repetitions, sk.ef, sk.interval,
+			sk.last_reviewed, sk.next_review,
+			k.id, k.character, COALESCE(k.meanings, ''), COALESCE(k.onyomi, ''), COALESCE(k.kunyomi, ''),
+			COALESCE(k.stroke_count, 0), COALESCE(k.level, 0)
+		FROM sr_kanji sk
+		JOIN kanji k ON sk.kanji_id = k.id
+		WHERE sk.user_id = $1
+			AND sk.next_review <= CURRENT_TIMESTAMP
+			AND (sk.suspended = FALSE OR sk.suspended IS NULL)
+		ORDER BY sk.next_review ASC
+		LIMIT 1
+	`
+
+	var srKanji SRKanji
+	err := db.DB.QueryRow(query, userID).Scan(
+		&srKanji.SRID, &srKanji.UserID, &srKanji.KanjiID, &srKanji.Repetitions, &srKanji.EF, &srKanji.Interval,
+		&srKanji.LastReviewed, &srKanji.NextReview,
+		&srKanji.Kanji.ID, &srKanji.Kanji.Character, &srKanji.Kanji.Meanings, &srKanji.Kanji.Onyomi, &srKanji.Kanji.Kunyomi,
+		&srKanji.Kanji.StrokeCount, &srKanji.Kanji.Level,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No kanji due for review
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next SR kanji: %w", err)
+	}
+	return &srKanji, nil
+}
+
+// LookupKanjiBySRId retrieves kanji information and the owning user by SR kanji ID
+func (db *Database) LookupKanjiBySRId(srID int) (*Kanji, int, error) {
+	query := `
+		SELECT sk.user_id, k.id, k.character, COALESCE(k.meanings, ''), COALESCE(k.onyomi, ''), COALESCE(k.kunyomi, ''),
+		       COALESCE(k.stroke_count, 0), COALESCE(k.level, 0)
+		FROM sr_kanji sk
+		JOIN kanji k ON sk.kanji_id = k.id
+		WHERE sk.id = $1
+	`
+	var kanji Kanji
+	var ownerID int
+	err := db.DB.QueryRow(query, srID).Scan(&ownerID, &kanji.ID, &kanji.Character, &kanji.Meanings, &kanji.Onyomi, &kanji.Kunyomi,
+		&kanji.StrokeCount, &kanji.Level)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to lookup kanji by SR ID: %w", err)
+	}
+	return &kanji, ownerID, nil
+}
+
+// UpdateSRKanji updates an SR kanji record using the SM-2 algorithm
+// quality: 0-5 rating (5=perfect, 4=correct after hesitation, 3=difficult, 2=incorrect, 1=barely, 0=blackout)
+func (db *Database) UpdateSRKanji(srID int, quality int) error {
+	if quality < 0 || quality > 5 {
+		return fmt.Errorf("quality must be between 0 and 5")
+	}
+
+	var currentEF float64
+	var currentInterval int
+	var currentRepetitions int
+	query := `SELECT ef, interval, repetitions FROM sr_kanji WHERE id = $1`
+	err := db.DB.QueryRow(query, srID).Scan(&currentEF, &currentInterval, &currentRepetitions)
+	if err != nil {
+		return fmt.Errorf("failed to get current SR kanji data: %w", err)
+	}
+
+	newEF, newInterval, newRepetitions := calculateSM2(currentEF, currentInterval, currentRepetitions, quality)
+
+	updateQuery := `
+		UPDATE sr_kanji
+		SET ef = $1,
+		    interval = $2,
+		    repetitions = $3,
+		    last_reviewed = CURRENT_TIMESTAMP,
+		    next_review = CURRENT_TIMESTAMP + INTERVAL '1 day' * $2::INTEGER
+		WHERE id = $4
+	`
+	_, err = db.DB.Exec(updateQuery, newEF, newInterval, newRepetitions, srID)
+	if err != nil {
+		return fmt.Errorf("failed to update SR kanji: %w", err)
+	}
+
+	log.Printf("✅ Updated SR kanji %d: quality=%d, EF=%.2f→%.2f, interval=%d→%d days, reps=%d→%d",
+		srID, quality, currentEF, newEF, currentInterval, newInterval, currentRepetitions, newRepetitions)
+	return nil
+}
+
+// calculateSM2 returns the new EF, interval and repetitions for a review of the given quality
+func calculateSM2(ef float64, interval int, repetitions int, quality int) (float64, int, int) {
+	// EF' = EF + (0.1 - (5 - q) * (0.08 + (5 - q) * 0.02))
+	newEF := ef + (0.1 - float64(5-quality)*(0.08+float64(5-quality)*0.02))
+	if newEF < 1.3 {
+		newEF = 1.3
+	}
+
+	if quality < 3 {
+		// Incorrect answer - reset
+		return newEF, 1, 0
+	}
+
+	newRepetitions := repetitions + 1
+	switch newRepetitions {
+	case 1:
+		return newEF, 1, newRepetitions
+	case 2:
+		return newEF, 6, newRepetitions
+	default:
+		return newEF, int(float64(interval) * newEF), newRepetitions
+	}
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+)
+
+// ConfusableWord is another word whose reading or meaning the user gave by mistake,
+// written with a kanji that shares a component with a kanji in the reviewed word
+type ConfusableWord struct {
+	ID              int
+	Word            string
+	Furigana        string
+	Definitions     string
+	Level           int
+	Kanji1          string // Kanji in the reviewed word
+	Kanji2          string // Look-alike kanji in the confused word
+	SharedComponent string // Component both kanji are built from
+}
+
+// FindConfusableWord looks for a different word whose furigana (answerType "pronunciation") or
+// definition (answerType "meaning", compared like Kanji.AcceptsMeaning) matches a wrong answer and
+// that shares a kanji component with the reviewed word. Returns nil if there is no such word.
+func (db *Database) FindConfusableWord(word *Word, answer string, answerType string) (*ConfusableWord, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" || len(ExtractKanji(word.Word)) == 0 {
+		return nil, nil
+	}
+
+	// Only exact matches are kept, so short answers like か or "to" can't fill the limit with
+	// words that merely contain them
+	var match, limit string
+	arg := answer
+	switch answerType {
+	case "pronunciation":
+		match = `(
+			EXISTS(SELECT 1 FROM word_readings r WHERE r.word_id = w.id AND r.reading = $3)
+			OR EXISTS(SELECT 1 FROM unnest(string_to_array(w.furigana, '/')) f WHERE btrim(f) = $3)
+		)`
+		limit = "LIMIT 50"
+	case "meaning":
+		// Meanings are compared the way AcceptsMeaning compares them ("hold" is "to hold"), which
+		// is done below; the SQL only fetches definitions that have the answer's words in order
+		normalized := normalizeMeaning(answer)
+		if normalized == "" {
+			return nil, nil
+		}
+		arg = meaningWordsPattern(normalized)
+		match = `lower(w.definitions) ~ $3`
+	default:
+		return nil, fmt.Errorf("unknown answer type: %s", answerType)
+	}
+
+	query := `
+		SELECT w.id, w.word, COALESCE(w.furigana, ''), COALESCE(w.definitions, ''), w.level
+		FROM words w
+		WHERE w.id <> $1 AND w.word <> $2 AND w.owner_id = 0 AND ` + match + `
+		ORDER BY w.frequency ASC NULLS LAST, w.level DESC
+		` + limit
+	rows, err := db.DB.Query(query, word.ID, word.Word, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find confusable words: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []ConfusableWord
+	for rows.Next() {
+		var c ConfusableWord
+		if err := rows.Scan(&c.ID, &c.Word, &c.Furigana, &c.Definitions, &c.Level); err != nil {
+			return nil, fmt.Errorf("failed to scan confusable word: %w", err)
+		}
+		if answerType == "meaning" && !hasMeaning(c.Definitions, answer) {
+			continue
+		}
+		if len(ExtractKanji(c.Word)) > 0 && len(candidates) < 50 {
+			candidates = append(candidates, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate confusable words: %w", err)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Load the components of every kanji involved in one query
+	chars := kanjiStrings(word.Word)
+	for _, c := range candidates {
+		chars = append(chars, kanjiStrings(c.Word)...)
+	}
+	components, err := db.getKanjiComponents(chars)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		for _, k1 := range kanjiStrings(word.Word) {
+			for _, k2 := range kanjiStrings(c.Word) {
+				if k1 == k2 {
+					continue
+				}
+				if shared := sharedComponent(k1, k2, components); shared != "" {
+					c.Kanji1, c.Kanji2, c.SharedComponent = k1, k2, shared
+					return &c, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// meaningWordsPattern returns a Postgres regular expression matching text that has the words of a
+// normalized meaning in order, as whole words where they start and end with a letter or digit
+func meaningWordsPattern(normalized string) string {
+	words := strings.Fields(normalized)
+	for i, w := range words {
+		pattern := regexp.QuoteMeta(w)
+		if r, _ := utf8.DecodeRuneInString(w); unicode.IsLetter(r) || unicode.IsDigit(r) {
+			pattern = `\m` + pattern
+		}
+		if r, _ := utf8.DecodeLastRuneInString(w); unicode.IsLetter(r) || unicode.IsDigit(r) {
+			pattern += `\M`
+		}
+		words[i] = pattern
+	}
+	return strings.Join(words, ".*")
+}
+
+// IsConfusionLinked reports whether the user has already linked the two words as a confusion pair
+func (db *Database) IsConfusionLinked(userID int, word1ID int, word2ID int) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM kanji_confusion
+			WHERE user_id = $1 AND ((word1_id = $2 AND word2_id = $3) OR (word1_id = $3 AND word2_id = $2))
+		)
+	`
+	err := db.DB.QueryRow(query, userID, word1ID, word2ID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check confusion pair: %w", err)
+	}
+	return exists, nil
+}
+
+// LinkConfusionPair records two words as a kanji confusion pair for the user, unless already linked
+func (db *Database) LinkConfusionPair(userID int, word1ID int, word2ID int, kanji1 string, kanji2 string, note string) error {
+	query := `
+		INSERT INTO kanji_confusion (kanji_1, kanji_2, word1_id, word2_id, user_id, note)
+		SELECT $1, $2, $3, $4, $5, NULLIF($6, '')
+		WHERE NOT EXISTS (
+			SELECT 1 FROM kanji_confusion
+			WHERE user_id = $5 AND ((word1_id = $3 AND word2_id = $4) OR (word1_id = $4 AND word2_id = $3))
+		)
+	`
+	_, err := db.DB.Exec(query, kanji1, kanji2, word1ID, word2ID, userID, note)
+	if err != nil {
+		return fmt.Errorf("failed to link confusion pair: %w", err)
+	}
+	log.Printf("✅ Linked confusion pair %s/%s (words %d, %d) for user %d", kanji1, kanji2, word1ID, word2ID, userID)
+	return nil
+}
+
+// getKanjiComponents returns the components of each given kanji, keyed by character
+func (db *Database) getKanjiComponents(chars []string) (map[string][]string, error) {
+	query := `
+		SELECT k.character, kc.component
+		FROM kanji_components kc
+		JOIN kanji k ON kc.kanji_id = k.id
+		WHERE k.character = ANY($1)
+	`
+	rows, err := db.DB.Query(query, pq.Array(chars))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji components: %w", err)
+	}
+	defer rows.Close()
+
+	components := make(map[string][]string)
+	for rows.Next() {
+		var character, component string
+		if err := rows.Scan(&character, &component); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji component: %w", err)
+		}
+		components[character] = append(components[character], component)
+	}
+	return components, nil
+}
+
+// sharedComponent returns a component found in both kanji, counting each kanji as a component
+// of itself so that e.g. 寺 and 待 match
+func sharedComponent(k1 string, k2 string, components map[string][]string) string {
+	parts := map[string]bool{k1: true}
+	for _, c := range components[k1] {
+		parts[c] = true
+	}
+	for _, c := range append([]string{k2}, components[k2]...) {
+		if parts[c] {
+			return c
+		}
+	}
+	return ""
+}
+
+// kanjiStrings returns the distinct kanji in s as strings
+func kanjiStrings(s string) []string {
+	var result []string
+	for _, k := range ExtractKanji(s) {
+		if !containsString(result, string(k)) {
+			result = append(result, string(k))
+		}
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package database
+
+import "testing"
+
+// Reviewing 待つ, someone who answers "hold" meant 持つ, which JMdict defines as "to hold"
+func TestHasMeaning(t *testing.T) {
+	tests := []struct {
+		definitions, answer string
+		want                bool
+	}{
+		{"to hold (in one's hand); to take; to carry", "hold", true},
+		{"to hold (in one's hand); to take; to carry", "To Hold", true},
+		{"to hold (in one's hand); to take; to carry", "to carry", true},
+		{"to wait; to await", "hold", false},
+		{"household; family", "hold", false},
+		{"a book, the volume", "book", true},
+		{"to", "to", true},
+		{"to hold", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := hasMeaning(tt.definitions, tt.answer); got != tt.want {
+			t.Errorf("hasMeaning(%q, %q) = %t, want %t", tt.definitions, tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestMeaningWordsPattern(t *testing.T) {
+	tests := []struct {
+		normalized, want string
+	}{
+		{"hold", `\mhold\M`},
+		{"look after", `\mlook\M.*\mafter\M`},
+		{"c++", `\mc\+\+`},
+		{"'em", `'em\M`},
+	}
+
+	for _, tt := range tests {
+		if got := meaningWordsPattern(tt.normalized); got != tt.want {
+			t.Errorf("meaningWordsPattern(%q) = %q, want %q", tt.normalized, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// LevelCoverage describes how much of a JLPT level's kanji vocabulary a user can read
+type LevelCoverage struct {
+	Level         int
+	KanjiWords    int // Words at this level that contain at least one kanji
+	ReadableWords int // Of those, words whose kanji are all known
+	LearnedWords  int // Words at this level already in the user's SR deck
+	Percent       int // ReadableWords as a percentage of KanjiWords
+}
+
+// ReadableWord is a word the user can read from known kanji but hasn't added to their deck yet
+type ReadableWord struct {
+	ID          int
+	Word        string
+	Furigana    string
+	Definitions string
+	Level       int
+	Frequency   *int
+}
+
+// KanjiUnlock is an unknown kanji ranked by how much vocabulary learning it would make readable
+type KanjiUnlock struct {
+	Character     string
+	WordCount     int      // Words whose only unknown kanji is this one
+	BestFrequency int      // Most common frequency rank among those words, 0 if no data
+	Words         []string // Sample of the words it unlocks, most frequent first
+}
+
+// KanjiCoverage is the per-user kanji coverage report
+type KanjiCoverage struct {
+	KnownKanji  []string
+	Levels      []LevelCoverage // N5 to N1
+	NowReadable []ReadableWord
+	NextKanji   []KanjiUnlock
+}
+
+// kanjiUnlockSampleSize is how many example words are kept per KanjiUnlock
+const kanjiUnlockSampleSize = 5
+
+// GetUserKnownKanji returns the kanji the user knows, by the same rule as kanji progression:
+// every kanji in a word or kanji card that has reached the user's unlock stage
+// Suspended cards don't count
+func (db *Database) GetUserKnownKanji(userID int) (map[string]bool, error) {
+	userSettings, err := db.GetUserSettings(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	query := `
+		SELECT w.word
+		FROM sr
+		JOIN words w ON sr.word_id = w.id
+		WHERE sr.user_id = $1
+			AND sr.repetitions >= $2
+			AND (sr.suspended = FALSE OR sr.suspended IS NULL)
+		UNION
+		SELECT k.character
+		FROM sr_kanji sk
+		JOIN kanji k ON sk.kanji_id = k.id
+		WHERE sk.user_id = $1
+			AND sk.repetitions >= $2
+			AND (sk.suspended = FALSE OR sk.suspended IS NULL)
+	`
+	rows, err := db.DB.Query(query, userID, userSettings.UnlockStage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get known kanji: %w", err)
+	}
+	defer rows.Close()
+
+	known := make(map[string]bool)
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, fmt.Errorf("failed to scan known kanji: %w", err)
+		}
+		for _, k := range ExtractKanji(text) {
+			known[string(k)] = true
+		}
+	}
+	return known, nil
+}
+
+// GetKanjiCoverage builds the kanji coverage report for a user
+// limit caps the number of now-readable words and next kanji returned
+func (db *Database) GetKanjiCoverage(userID int, limit int) (*KanjiCoverage, error) {
+	known, err := db.GetUserKnownKanji(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	learnedCounts, err := db.GetUserLearnedCountByLevel(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT w.id, w.word, COALESCE(w.furigana, ''), COALESCE(w.definitions, ''), w.level, w.frequency,
+			EXISTS(SELECT 1 FROM sr WHERE sr.word_id = w.id AND sr.user_id = $1)
+		FROM words w
+		WHERE w.owner_id = 0
+		ORDER BY w.frequency ASC NULLS LAST, w.level DESC, w.id ASC
+	`
+	rows, err := db.DB.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words for coverage: %w", err)
+	}
+	defer rows.Close()
+
+	kanjiWords := make(map[int]int)
+	readableWords := make(map[int]int)
+	unlocks := make(map[string]*KanjiUnlock)
+	var nowReadable []ReadableWord
+
+	for rows.Next() {
+		var word ReadableWord
+		var frequency sql.NullInt64
+		var inDeck bool
+		if err := rows.Scan(&word.ID, &word.Word, &word.Furigana, &word.Definitions, &word.Level, &frequency, &inDeck); err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		if frequency.Valid {
+			f := int(frequency.Int64)
+			word.Frequency = &f
+		}
+
+		kanji := ExtractKanji(word.Word)
+		if len(kanji) == 0 {
+			continue
+		}
+		kanjiWords[word.Level]++
+
+		// Collect the distinct kanji in this word the user doesn't know yet
+		var unknown []string
+		for _, k := range kanji {
+			char := string(k)
+			if !known[char] && !containsString(unknown, char) {
+				unknown = append(unknown, char)
+			}
+		}
+
+		switch len(unknown) {
+		case 0:
+			readableWords[word.Level]++
+			if !inDeck && len(nowReadable) < limit {
+				nowReadable = append(nowReadable, word)
+			}
+		case 1:
+			// Learning this one kanji would make the word readable
+			unlock, ok := unlocks[unknown[0]]
+			if !ok {
+				unlock = &KanjiUnlock{Character: unknown[0]}
+				unlocks[unknown[0]] = unlock
+			}
+			unlock.WordCount++
+			// Rows arrive most frequent first, so the first frequency seen is the best
+			if unlock.BestFrequency == 0 && word.Frequency != nil {
+				unlock.BestFrequency = *word.Frequency
+			}
+			if len(unlock.Words) < kanjiUnlockSampleSize {
+				unlock.Words = append(unlock.Words, word.Word)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate words: %w", err)
+	}
+
+	coverage := &KanjiCoverage{NowReadable: nowReadable}
+
+	for char := range known {
+		coverage.KnownKanji = append(coverage.KnownKanji, char)
+	}
+	sort.Strings(coverage.KnownKanji)
+
+	for _, lvl := range []int{5, 4, 3, 2, 1} {
+		lc := LevelCoverage{
+			Level:         lvl,
+			KanjiWords:    kanjiWords[lvl],
+			ReadableWords: readableWords[lvl],
+			LearnedWords:  learnedCounts[lvl],
+		}
+		if lc.KanjiWords > 0 {
+			lc.Percent = (lc.ReadableWords * 100) / lc.KanjiWords
+		}
+		coverage.Levels = append(coverage.Levels, lc)
+	}
+
+	// Rank next kanji by words unlocked, then by how common those words are
+	for _, unlock := range unlocks {
+		coverage.NextKanji = append(coverage.NextKanji, *unlock)
+	}
+	sort.Slice(coverage.NextKanji, func(i, j int) bool {
+		a, b := coverage.NextKanji[i], coverage.NextKanji[j]
+		if a.WordCount != b.WordCount {
+			return a.WordCount > b.WordCount
+		}
+		if (a.BestFrequency == 0) != (b.BestFrequency == 0) {
+			return b.BestFrequency == 0
+		}
+		if a.BestFrequency != b.BestFrequency {
+			return a.BestFrequency < b.BestFrequency
+		}
+		return a.Character < b.Character
+	})
+	if len(coverage.NextKanji) > limit {
+		coverage.NextKanji = coverage.NextKanji[:limit]
+	}
+
+	return coverage, nil
+}
+
+// containsString reports whether s is in list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
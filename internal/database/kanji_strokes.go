@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// KanjiStrokes holds the stroke order diagram data for a kanji
+type KanjiStrokes struct {
+	Character   string   `json:"character"`
+	StrokeCount int      `json:"stroke_count"` // From the kanji table, 0 if unknown
+	Strokes     []string `json:"strokes"`      // SVG path data in drawing order, 109x109 KanjiVG coordinates
+}
+
+// GetKanjiStrokes returns the stroke paths for a kanji character, or nil if the kanji is not in the kanji table
+func (db *Database) GetKanjiStrokes(character string) (*KanjiStrokes, error) {
+	var kanjiID int
+	result := KanjiStrokes{Character: character, Strokes: []string{}}
+	err := db.DB.QueryRow(`SELECT id, COALESCE(stroke_count, 0) FROM kanji WHERE character = $1`, character).
+		Scan(&kanjiID, &result.StrokeCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup kanji: %w", err)
+	}
+
+	rows, err := db.DB.Query(`SELECT path FROM kanji_strokes WHERE kanji_id = $1 ORDER BY stroke_number`, kanjiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji strokes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji stroke: %w", err)
+		}
+		result.Strokes = append(result.Strokes, path)
+	}
+	return &result, nil
+}
+
+// SaveKanjiStrokes replaces the stored stroke paths for a kanji
+func (db *Database) SaveKanjiStrokes(kanjiID int, paths []string) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM kanji_strokes WHERE kanji_id = $1`, kanjiID)
+	if err != nil {
+		return fmt.Errorf("failed to clear kanji strokes: %w", err)
+	}
+
+	for i, path := range paths {
+		_, err = tx.Exec(`INSERT INTO kanji_strokes (kanji_id, stroke_number, path) VALUES ($1, $2, $3)`, kanjiID, i+1, path)
+		if err != nil {
+			return fmt.Errorf("failed to insert kanji stroke %d: %w", i+1, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit kanji strokes: %w", err)
+	}
+	return nil
+}
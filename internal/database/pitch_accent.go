@@ -0,0 +1,149 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+// SRTypePitch is the sr.type of pitch accent quiz cards
+// They are studied on /study/pitch and kept out of the reading/meaning queue
+const SRTypePitch = "pitch accent"
+
+// wordHasPitchClause matches words (aliased w) with pitch accent data for one of their readings
+const wordHasPitchClause = `EXISTS (
+	SELECT 1 FROM word_forms f
+	JOIN word_readings r ON r.word_id = f.word_id
+	JOIN pitch_accents p ON p.form = f.form AND p.reading = r.reading
+	WHERE f.word_id = w.id
+)`
+
+// HasUserSRPitch checks if a user has turned on pitch accent cards
+func (db *Database) HasUserSRPitch(userID int) (bool, error) {
+	var count int
+	err := db.DB.QueryRow(`SELECT COUNT(*) FROM sr WHERE user_id = $1 AND type = $2`, userID, SRTypePitch).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user pitch cards: %w", err)
+	}
+	return count > 0, nil
+}
+
+// InitializeUserSRPitch adds a pitch accent card for every word in the user's deck that has
+// pitch accent data. Words added to the deck afterwards get one automatically.
+func (db *Database) InitializeUserSRPitch(userID int) (int, error) {
+	query := `
+		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
+		SELECT DISTINCT $1, w.id, 0, 2.5, 0, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM words w
+		WHERE EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type <> $2)
+			AND NOT EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type = $2)
+			AND ` + wordHasPitchClause
+	result, err := db.DB.Exec(query, userID, SRTypePitch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize pitch cards: %w", err)
+	}
+	added, _ := result.RowsAffected()
+	log.Printf("✅ Added %d pitch accent cards for user %d", added, userID)
+	return int(added), nil
+}
+
+// addSRPitch adds a pitch accent card for a word if the user studies pitch accent and the
+// word has pitch accent data
+func (db *Database) addSRPitch(userID int, wordID int) error {
+	enabled, err := db.HasUserSRPitch(userID)
+	if err != nil || !enabled {
+		return err
+	}
+	query := `
+		INSERT INTO sr (user_id, word_id, repetitions, ef, interval, type, last_reviewed, next_review)
+		SELECT $1, w.id, 0, 2.5, 0, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM words w
+		WHERE w.id = $2
+			AND NOT EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id AND sr.type = $3)
+			AND ` + wordHasPitchClause
+	if _, err := db.DB.Exec(query, userID, wordID, SRTypePitch); err != nil {
+		return fmt.Errorf("failed to add pitch accent entry: %w", err)
+	}
+	return nil
+}
+
+// GetNextSRPitchWord retrieves the next pitch accent card due for review
+// The word's readings (with accents) are loaded
+func (db *Database) GetNextSRPitchWord(userID int) (*SRWord, error) {
+	query := `
+		SELECT
+			sr.id, sr.user_id, sr.word_id, sr.repetitions, sr.ef, sr.interval, sr.type,
+			sr.last_reviewed, sr.next_review,
+			w.id, w.word, w.furigana, w.romaji, w.level, w.definitions, w.parts_of_speech, w.hiragana_only, w.created_at
+		FROM sr
+		JOIN words w ON sr.word_id = w.id
+		WHERE sr.user_id = $1
+			AND sr.type = $2
+			AND sr.next_review <= CURRENT_TIMESTAMP
+			AND (sr.suspended = FALSE OR sr.suspended IS NULL)
+		ORDER BY sr.next_review ASC
+		LIMIT 1
+	`
+
+	var srWord SRWord
+	err := db.DB.QueryRow(query, userID, SRTypePitch).Scan(
+		&srWord.SRID, &srWord.UserID, &srWord.WordID, &srWord.Repetitions,
+		&srWord.EF, &srWord.Interval, &srWord.Type, &srWord.LastReviewed, &srWord.NextReview,
+		&srWord.Word.ID, &srWord.Word.Word, &srWord.Word.Furigana, &srWord.Word.Romaji,
+		&srWord.Word.Level, &srWord.Word.Definitions, &srWord.Word.PartsOfSpeech, &srWord.Word.HiraganaOnly, &srWord.Word.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No pitch cards due for review
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next SR pitch word: %w", err)
+	}
+	if err := db.attachWordDetails(&srWord.Word); err != nil {
+		return nil, err
+	}
+
+	return &srWord, nil
+}
+
+// PitchAccentEntry is one line of an accent dictionary
+type PitchAccentEntry struct {
+	Form    string
+	Reading string
+	Accents []int
+}
+
+// SavePitchAccents upserts a batch of accent dictionary entries in one transaction
+func (db *Database) SavePitchAccents(entries []PitchAccentEntry) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO pitch_accents (form, reading, accents)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (form, reading) DO UPDATE SET accents = EXCLUDED.accents
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare pitch accent insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range entries {
+		accents := make(pq.Int64Array, len(e.Accents))
+		for i, a := range e.Accents {
+			accents[i] = int64(a)
+		}
+		if _, err := stmt.Exec(e.Form, e.Reading, accents); err != nil {
+			return fmt.Errorf("failed to save pitch accent for %s: %w", e.Form, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pitch accents: %w", err)
+	}
+	return nil
+}
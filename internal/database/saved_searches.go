@@ -0,0 +1,149 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// searchHistoryLimit is how many distinct searches are kept per user
+const searchHistoryLimit = 100
+
+// SavedSearch is a search a user pinned, with the filters that were on when they pinned it
+type SavedSearch struct {
+	ID        int
+	UserID    int
+	Query     string
+	Level     string // SearchOptions.Level
+	POS       string // SearchOptions.POS
+	Learned   string // SearchOptions.Learned
+	CreatedAt time.Time
+}
+
+// Options returns the saved filters, for SearchWords and SearchWordIDs
+func (s SavedSearch) Options() SearchOptions {
+	return SearchOptions{Level: s.Level, POS: s.POS, Learned: s.Learned}
+}
+
+// RecordSearch adds a query to the user's search history, or moves it to the top if it's there
+// Only the most recent searchHistoryLimit queries are kept
+func (db *Database) RecordSearch(userID int, query string) error {
+	_, err := db.DB.Exec(`
+		INSERT INTO search_history (user_id, query)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, query) DO UPDATE SET searched_at = CURRENT_TIMESTAMP
+	`, userID, query)
+	if err != nil {
+		return fmt.Errorf("failed to record search: %w", err)
+	}
+
+	_, err = db.DB.Exec(`
+		DELETE FROM search_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM search_history WHERE user_id = $1 ORDER BY searched_at DESC LIMIT $2
+		)
+	`, userID, searchHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("failed to trim search history: %w", err)
+	}
+	return nil
+}
+
+// GetRecentSearches returns the user's most recent distinct queries, newest first
+func (db *Database) GetRecentSearches(userID int, limit int) ([]string, error) {
+	rows, err := db.DB.Query(`
+		SELECT query FROM search_history
+		WHERE user_id = $1
+		ORDER BY searched_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent searches: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []string
+	for rows.Next() {
+		var query string
+		if err := rows.Scan(&query); err != nil {
+			return nil, fmt.Errorf("failed to scan recent search: %w", err)
+		}
+		queries = append(queries, query)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recent searches: %w", err)
+	}
+	return queries, nil
+}
+
+// SaveSearch pins a search with its filters and returns its ID
+// Saving the same search again returns the existing one
+func (db *Database) SaveSearch(userID int, query string, opts SearchOptions) (int, error) {
+	var id int
+	err := db.DB.QueryRow(`
+		INSERT INTO saved_searches (user_id, query, level, pos, learned)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, query, level, pos, learned) DO UPDATE SET query = EXCLUDED.query
+		RETURNING id
+	`, userID, query, opts.Level, opts.POS, opts.Learned).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save search: %w", err)
+	}
+
+	log.Printf("✅ Saved search %q for user %d", query, userID)
+	return id, nil
+}
+
+// DeleteSavedSearch unpins one of the user's saved searches
+func (db *Database) DeleteSavedSearch(userID int, id int) error {
+	_, err := db.DB.Exec(`DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+// GetSavedSearches returns the user's saved searches, oldest first
+func (db *Database) GetSavedSearches(userID int) ([]SavedSearch, error) {
+	rows, err := db.DB.Query(`
+		SELECT id, user_id, query, level, pos, learned, created_at
+		FROM saved_searches
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []SavedSearch
+	for rows.Next() {
+		var s SavedSearch
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Query, &s.Level, &s.POS, &s.Learned, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		searches = append(searches, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+// GetSavedSearch returns one of the user's saved searches, or nil if they have none with this ID
+func (db *Database) GetSavedSearch(userID int, id int) (*SavedSearch, error) {
+	var s SavedSearch
+	err := db.DB.QueryRow(`
+		SELECT id, user_id, query, level, pos, learned, created_at
+		FROM saved_searches
+		WHERE id = $1 AND user_id = $2
+	`, id, userID).Scan(&s.ID, &s.UserID, &s.Query, &s.Level, &s.POS, &s.Learned, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return &s, nil
+}
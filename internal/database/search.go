@@ -0,0 +1,550 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"gaijin/internal/deinflect"
+	"gaijin/internal/kana"
+	"gaijin/internal/searchquery"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Search match ranks, best first; SearchWords orders results by their best match
+const (
+	searchRankExactPrimary    = iota // the primary form or reading
+	searchRankExact                  // another form or reading, or a whole English definition
+	searchRankDeinflected            // the dictionary form of a conjugated query (食べた → 食べる)
+	searchRankPrefix                 // a form or reading starting with the query
+	searchRankEnglishWord            // a whole word in an English definition
+	searchRankContains               // a form or reading containing the query
+	searchRankEnglishContains        // anywhere in the English definitions
+)
+
+// SearchPageSize is how many results a search page shows unless asked for another size
+const SearchPageSize = 50
+
+// maxSearchPageSize caps SearchOptions.PerPage
+const maxSearchPageSize = 100
+
+// SearchOptions filters and pages SearchWords' results
+// Filter values come from the facets, and empty or unknown values don't filter
+type SearchOptions struct {
+	Level   string // "5" to "1" for a JLPT level, or "custom" for the user's own words
+	POS     string // a part-of-speech group, e.g. "verb" or "adj-i"
+	Learned string // "learned" for words in the user's SR deck, "unlearned" for the rest
+	Page    int    // 1-based; anything lower is the first page
+	PerPage int    // defaults to SearchPageSize
+}
+
+// SearchFacet is one filter value and how many results of the query have it
+type SearchFacet struct {
+	Value string // the SearchOptions value, e.g. "5", "verb" or "learned"
+	Label string
+	Count int
+}
+
+// SearchResults is one page of SearchWords results
+type SearchResults struct {
+	Words   []KanjiWord
+	Type    string // "japanese", "romaji" or "english"
+	Total   int    // results matching the filters, across every page
+	Page    int
+	PerPage int
+
+	// Facets count every result of the query, before the filters, leaving out empty values
+	Levels  []SearchFacet
+	POS     []SearchFacet
+	Learned []SearchFacet
+}
+
+// Pages returns the number of result pages
+func (r *SearchResults) Pages() int {
+	return (r.Total + r.PerPage - 1) / r.PerPage
+}
+
+// PrevPage returns the previous page number, or 0 on the first page
+func (r *SearchResults) PrevPage() int {
+	if r.Page <= 1 {
+		return 0
+	}
+	return r.Page - 1
+}
+
+// NextPage returns the next page number, or 0 on the last page
+func (r *SearchResults) NextPage() int {
+	if r.Page >= r.Pages() {
+		return 0
+	}
+	return r.Page + 1
+}
+
+// searchLevels are the level facets, in display order
+var searchLevels = []SearchFacet{
+	{Value: "5", Label: "N5"},
+	{Value: "4", Label: "N4"},
+	{Value: "3", Label: "N3"},
+	{Value: "2", Label: "N2"},
+	{Value: "1", Label: "N1"},
+	{Value: "custom", Label: "My Words"},
+}
+
+// searchPOSGroups are the part-of-speech facets, with the JMdict codes (as LIKE patterns) in each
+var searchPOSGroups = []struct {
+	SearchFacet
+	codes []string
+}{
+	{SearchFacet{Value: "noun", Label: "Noun"}, []string{"n", "n-%"}},
+	{SearchFacet{Value: "verb", Label: "Verb"}, []string{"v1%", "v5%", "vk", "vs%", "vz"}},
+	{SearchFacet{Value: "adj-i", Label: "い-adjective"}, []string{"adj-i", "adj-ix"}},
+	{SearchFacet{Value: "adj-na", Label: "な-adjective"}, []string{"adj-na"}},
+	{SearchFacet{Value: "adverb", Label: "Adverb"}, []string{"adv", "adv-to"}},
+	{SearchFacet{Value: "expression", Label: "Expression"}, []string{"exp"}},
+	{SearchFacet{Value: "particle", Label: "Particle"}, []string{"prt"}},
+}
+
+// searchLearned are the learned facets
+var searchLearned = []SearchFacet{
+	{Value: "learned", Label: "In my deck"},
+	{Value: "unlearned", Label: "Not in my deck"},
+}
+
+// hasPOS matches the words whose senses have a part-of-speech code LIKE one of the patterns in param
+func hasPOS(wordID string, param string) string {
+	return `EXISTS (
+			SELECT 1 FROM word_senses ws
+			JOIN word_sense_pos wsp ON wsp.sense_id = ws.id
+			JOIN part_of_speech p ON p.id = wsp.pos_id
+			WHERE ws.word_id = ` + wordID + ` AND p.code LIKE ANY(` + param + `))`
+}
+
+// kanaMatchQuery matches the forms and readings containing the term in param, with their rank
+// likeParam is the term escaped for LIKE. Words without word_forms or word_readings rows (not yet
+// migrated by scripts/migrate_word_readings.go) are matched on words.word and furigana instead.
+func kanaMatchQuery(param string, likeParam string) string {
+	rank := func(column, primary string) string {
+		return fmt.Sprintf(`MIN(CASE WHEN %[1]s = %[2]s AND %[3]s THEN %[4]d WHEN %[1]s = %[2]s THEN %[5]d
+			WHEN %[1]s LIKE %[6]s || '%%' THEN %[7]d ELSE %[8]d END)`,
+			column, param, primary, searchRankExactPrimary, searchRankExact, likeParam, searchRankPrefix, searchRankContains)
+	}
+	return `
+		SELECT word_id, ` + rank("form", "kind = 'primary'") + ` AS rank, 1 AS sense, NULL::text AS matched
+		FROM word_forms WHERE form LIKE '%' || ` + likeParam + ` || '%'
+		GROUP BY word_id
+		UNION ALL
+		SELECT word_id, ` + rank("reading", "kind = 'primary'") + ` AS rank, 1 AS sense, NULL::text AS matched
+		FROM word_readings WHERE reading LIKE '%' || ` + likeParam + ` || '%'
+		GROUP BY word_id
+		UNION ALL
+		SELECT w.id AS word_id, ` + rank("v.value", "v.n = 1") + ` AS rank, 1 AS sense, NULL::text AS matched
+		FROM words w
+		CROSS JOIN LATERAL (
+			SELECT btrim(f) AS value, n FROM unnest(string_to_array(w.word, '/')) WITH ORDINALITY AS a(f, n)
+			UNION ALL
+			SELECT btrim(f), n FROM unnest(string_to_array(w.furigana, '/')) WITH ORDINALITY AS b(f, n)
+		) v
+		WHERE v.value LIKE '%' || ` + likeParam + ` || '%'
+			AND NOT EXISTS (SELECT 1 FROM word_forms wf WHERE wf.word_id = w.id)
+			AND NOT EXISTS (SELECT 1 FROM word_readings wr WHERE wr.word_id = w.id)
+		GROUP BY w.id`
+}
+
+// englishMatchQuery matches the words whose definitions contain the term in param, with their rank
+// and the first sense that contains it; likeParam is the term escaped for LIKE
+// Definitions are "; "-separated, and verbs are glossed "to ...", so "eat" matches "to eat" exactly
+func englishMatchQuery(param string, likeParam string) string {
+	return fmt.Sprintf(`
+		SELECT id AS word_id, CASE
+			WHEN LOWER(%[1]s) = ANY(string_to_array(LOWER(definitions), '; '))
+				OR 'to ' || LOWER(%[1]s) = ANY(string_to_array(LOWER(definitions), '; ')) THEN %[2]d
+			WHEN ' ' || regexp_replace(LOWER(definitions), '[^a-z0-9]+', ' ', 'g') || ' ' LIKE '%% ' || LOWER(%[5]s) || ' %%' THEN %[3]d
+			ELSE %[4]d END AS rank,
+			COALESCE((SELECT MIN(s.sense_order) FROM word_senses s
+				WHERE s.word_id = words.id AND LOWER(s.glosses) LIKE '%%' || LOWER(%[5]s) || '%%'), 1) AS sense,
+			NULL::text AS matched
+		FROM words WHERE LOWER(definitions) LIKE '%%' || LOWER(%[5]s) || '%%'`,
+		param, searchRankExact, searchRankEnglishWord, searchRankEnglishContains, likeParam)
+}
+
+// deinflectMatchQuery matches the forms and readings equal to one of the candidate dictionary
+// forms in formsParam, along with the form that matched
+// posParam holds the part-of-speech pattern each form needs, so 食べる isn't a candidate for a
+// godan rule; words without parts of speech match any
+func deinflectMatchQuery(formsParam string, posParam string) string {
+	match := func(table, column string) string {
+		return fmt.Sprintf(`
+		SELECT t.word_id, %[4]d AS rank, 1 AS sense, t.%[2]s AS matched
+		FROM %[1]s t
+		JOIN unnest(%[3]s::text[], %[5]s::text[]) AS d(form, pos) ON d.form = t.%[2]s
+		WHERE %[6]s
+			OR NOT EXISTS (SELECT 1 FROM word_senses ws JOIN word_sense_pos wsp ON wsp.sense_id = ws.id WHERE ws.word_id = t.word_id)`,
+			table, column, formsParam, searchRankDeinflected, posParam, hasPOS("t.word_id", "ARRAY[d.pos]"))
+	}
+	return match("word_forms", "form") + `
+		UNION ALL` + match("word_readings", "reading")
+}
+
+// deinflections returns the dictionary forms a conjugated query could come from, each with
+// the candidates that produce it, shortest chain first
+// Suru verbs are also looked up without する, since the dictionary lists 勉強 rather than 勉強する
+func deinflections(query string) map[string][]deinflect.Candidate {
+	forms := make(map[string][]deinflect.Candidate)
+	for _, c := range deinflect.Deinflect(query) {
+		if c.Type == 0 || c.Type == deinflect.TypeTe {
+			continue // the query itself, or a て-form on its way to ている
+		}
+		forms[c.Word] = append(forms[c.Word], c)
+		if stem := strings.TrimSuffix(c.Word, "する"); c.Type == deinflect.TypeSuru && stem != c.Word && stem != "" {
+			forms[stem] = append(forms[stem], c)
+		}
+	}
+	return forms
+}
+
+// SearchWords searches for words based on the query string
+// If the query contains Japanese characters (hiragana, katakana, kanji), it searches every written
+// form and reading, and the dictionary forms of the query if it's conjugated (行って → 行く)
+// If it's romaji, like "taberu", it searches the readings for its hiragana and katakana as well
+// as the English definitions, since "bake" or "time" are both
+// Otherwise, it searches the English definitions
+// Exact matches on a primary form or reading come first, then other exact matches, dictionary
+// forms, prefixes, whole English words and substrings; ties go to the word whose earlier sense
+// matched, then to the more frequent word in the user's frequency corpus
+// The query can also be a pattern, with ? and * wildcards and filters such as reading:*かい,
+// pos:adverb, level:3 and kanji:水 (see searchquery); a query that doesn't parse returns its
+// *searchquery.Error
+// The user's own custom words are searched along with the dictionary
+func (db *Database) SearchWords(userID int, query string, opts SearchOptions) (*SearchResults, error) {
+	query = strings.TrimSpace(query)
+	results := &SearchResults{Page: opts.Page, PerPage: opts.PerPage}
+	if results.Page < 1 {
+		results.Page = 1
+	}
+	if results.PerPage < 1 {
+		results.PerPage = SearchPageSize
+	}
+	if results.PerPage > maxSearchPageSize {
+		results.PerPage = maxSearchPageSize
+	}
+
+	search, err := db.buildSearch(userID, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	results.Type = search.typ
+
+	if err := db.searchFacets(results, search.cte, search.where, search.args); err != nil {
+		return nil, err
+	}
+
+	pageArgs := append(search.args[:len(search.args):len(search.args)], results.PerPage, (results.Page-1)*results.PerPage)
+	rows, err := db.DB.Query(search.cte+`
+		SELECT w.id, w.word, w.furigana, w.level, w.definitions, w.parts_of_speech, r.custom, r.learned,
+			COALESCE(r.frequency, 0), r.rank, r.matched
+		FROM results r
+		JOIN words w ON w.id = r.id
+		`+search.where+`
+		ORDER BY r.rank, r.sense, r.frequency ASC NULLS LAST, w.level DESC, w.word ASC
+		LIMIT `+fmt.Sprintf("$%d OFFSET $%d", len(search.args)+1, len(search.args)+2), pageArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+	defer rows.Close()
+
+	ranks := make(map[int]int)
+	matched := make(map[int][]string)
+	for rows.Next() {
+		var word KanjiWord
+		var furigana, definitions, partsOfSpeech sql.NullString
+		var rank int
+		var forms pq.StringArray
+		err := rows.Scan(&word.ID, &word.Word, &furigana, &word.Level, &definitions, &partsOfSpeech, &word.Custom,
+			&word.Learned, &word.Frequency, &rank, &forms)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		word.Furigana = furigana.String
+		word.Definitions = definitions.String
+		word.PartsOfSpeech = partsOfSpeech.String
+		ranks[word.ID] = rank
+		matched[word.ID] = forms
+		results.Words = append(results.Words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read search results: %w", err)
+	}
+
+	if err := db.attachKanjiWordDetails(results.Words); err != nil {
+		return nil, err
+	}
+
+	// Explain dictionary-form matches
+	for i, word := range results.Words {
+		if ranks[word.ID] == searchRankDeinflected {
+			results.Words[i].Deinflection = explainDeinflection(word, matched[word.ID], search.conjugated)
+		}
+	}
+
+	return results, nil
+}
+
+// MaxSearchWordIDs caps SearchWordIDs, so a saved search for "*" doesn't add the whole dictionary
+const MaxSearchWordIDs = 1000
+
+// SearchWordIDs returns the IDs of the words a search finds with these filters, in the order
+// SearchWords shows them, up to MaxSearchWordIDs; opts.Page and opts.PerPage are ignored
+func (db *Database) SearchWordIDs(userID int, query string, opts SearchOptions) ([]int, error) {
+	search, err := db.buildSearch(userID, strings.TrimSpace(query), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(search.args[:len(search.args):len(search.args)], MaxSearchWordIDs)
+	rows, err := db.DB.Query(search.cte+`
+		SELECT r.id
+		FROM results r
+		JOIN words w ON w.id = r.id
+		`+search.where+`
+		ORDER BY r.rank, r.sense, r.frequency ASC NULLS LAST, w.level DESC, w.word ASC
+		LIMIT `+fmt.Sprintf("$%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search word IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan word ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word IDs: %w", err)
+	}
+	return ids, nil
+}
+
+// searchSQL is a search's results CTE, the WHERE clause its filters add and their arguments
+type searchSQL struct {
+	cte        string
+	where      string
+	args       []interface{}
+	typ        string // SearchResults.Type
+	conjugated map[string][]deinflect.Candidate
+}
+
+// buildSearch builds the SQL for a search; SearchWords and SearchWordIDs run it
+func (db *Database) buildSearch(userID int, query string, opts SearchOptions) (*searchSQL, error) {
+	settings, err := db.GetUserSettings(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	search := &searchSQL{args: []interface{}{userID}}
+	param := func(value interface{}) string {
+		search.args = append(search.args, value)
+		return fmt.Sprintf("$%d", len(search.args))
+	}
+
+	parsed, err := searchquery.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filters are applied below; the text is searched as before unless it has wildcards, so
+	// Like() only escapes it
+	text := parsed.Text()
+	var matches []string
+	if text == "" || text.HasWildcards() {
+		matches, search.typ = patternMatches(text, param)
+	} else if containsJapanese(string(text)) {
+		// Search in word_forms and word_readings (including alternate and rare variants)
+		matches = append(matches, kanaMatchQuery(param(string(text)), param(text.Like())))
+		search.conjugated = deinflections(string(text))
+		search.typ = "japanese"
+	} else if hiragana, katakana, ok := kana.FromRomaji(string(text)); ok {
+		matches = append(matches, englishMatchQuery(param(string(text)), param(text.Like())),
+			kanaMatchQuery(param(hiragana), param(searchquery.Pattern(hiragana).Like())),
+			kanaMatchQuery(param(katakana), param(searchquery.Pattern(katakana).Like())))
+		search.conjugated = deinflections(hiragana)
+		search.typ = "romaji"
+	} else {
+		// Search in English definitions (case-insensitive)
+		matches = append(matches, englishMatchQuery(param(string(text)), param(text.Like())))
+		search.typ = "english"
+	}
+
+	if len(search.conjugated) > 0 {
+		var forms, pos []string
+		for form, candidates := range search.conjugated {
+			for _, c := range candidates {
+				for _, prefix := range c.POSPrefixes() {
+					forms = append(forms, form)
+					pos = append(pos, prefix+"%")
+				}
+			}
+		}
+		matches = append(matches, deinflectMatchQuery(param(pq.Array(forms)), param(pq.Array(pos))))
+	}
+
+	corpusParam := ""
+	if settings.FrequencyCorpus != DefaultFrequencyCorpus {
+		corpusParam = param(settings.FrequencyCorpus)
+	}
+	join, frequency := frequencyRank(settings.FrequencyCorpus, corpusParam)
+
+	conditions := append([]string{"w.owner_id IN (0, $1)"}, patternConditions(parsed, param)...)
+
+	// Every word the query matches, with its best match; the filters and paging apply to this
+	search.cte = `
+		WITH results AS (
+			SELECT w.id, w.level, w.owner_id <> 0 AS custom,
+				MIN(m.rank) AS rank, MIN(m.sense) AS sense, ` + frequency + ` AS frequency,
+				array_remove(array_agg(DISTINCT m.matched), NULL) AS matched,
+				EXISTS (SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = w.id) AS learned
+			FROM words w
+			JOIN (` + strings.Join(matches, "\n\t\tUNION ALL") + `
+			) m ON m.word_id = w.id
+			` + join + `
+			WHERE ` + strings.Join(conditions, "\n\t\t\t\tAND ") + `
+			GROUP BY w.id, ` + frequency + `
+		)`
+
+	var filters []string
+	for _, level := range searchLevels {
+		if opts.Level != level.Value {
+			continue
+		}
+		if level.Value == "custom" {
+			filters = append(filters, "r.custom")
+		} else {
+			n, _ := strconv.Atoi(level.Value)
+			filters = append(filters, "r.level = "+param(n))
+		}
+	}
+	for _, group := range searchPOSGroups {
+		if opts.POS == group.Value {
+			filters = append(filters, hasPOS("r.id", param(pq.Array(group.codes))))
+		}
+	}
+	switch opts.Learned {
+	case "learned":
+		filters = append(filters, "r.learned")
+	case "unlearned":
+		filters = append(filters, "NOT r.learned")
+	}
+	if len(filters) > 0 {
+		search.where = "WHERE " + strings.Join(filters, " AND ")
+	}
+
+	return search, nil
+}
+
+// searchFacets counts the query's results for each facet value, and the results the filters in
+// where keep
+func (db *Database) searchFacets(results *SearchResults, resultsCTE string, where string, args []interface{}) error {
+	var posValues, posCodes []string
+	for _, group := range searchPOSGroups {
+		for _, code := range group.codes {
+			posValues = append(posValues, group.Value)
+			posCodes = append(posCodes, code)
+		}
+	}
+	args = append(args[:len(args):len(args)], pq.Array(posValues), pq.Array(posCodes))
+
+	rows, err := db.DB.Query(resultsCTE+`
+		SELECT 'total', '', COUNT(*) FROM results r `+where+`
+		UNION ALL
+		SELECT 'level', CASE WHEN r.custom THEN 'custom' ELSE r.level::text END, COUNT(*) FROM results r GROUP BY 2
+		UNION ALL
+		SELECT 'learned', CASE WHEN r.learned THEN 'learned' ELSE 'unlearned' END, COUNT(*) FROM results r GROUP BY 2
+		UNION ALL
+		SELECT 'pos', g.value, COUNT(DISTINCT r.id)
+		FROM results r
+		JOIN word_senses ws ON ws.word_id = r.id
+		JOIN word_sense_pos wsp ON wsp.sense_id = ws.id
+		JOIN part_of_speech p ON p.id = wsp.pos_id
+		JOIN unnest(`+fmt.Sprintf("$%d::text[], $%d::text[]", len(args)-1, len(args))+`) AS g(value, code) ON p.code LIKE g.code
+		GROUP BY g.value
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to count search results: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]map[string]int{"level": {}, "learned": {}, "pos": {}}
+	for rows.Next() {
+		var facet, value string
+		var count int
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return fmt.Errorf("failed to scan search facet: %w", err)
+		}
+		if facet == "total" {
+			results.Total = count
+		} else {
+			counts[facet][value] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read search facets: %w", err)
+	}
+
+	withCounts := func(facets []SearchFacet, counts map[string]int) []SearchFacet {
+		var out []SearchFacet
+		for _, f := range facets {
+			if f.Count = counts[f.Value]; f.Count > 0 {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+	results.Levels = withCounts(searchLevels, counts["level"])
+	results.Learned = withCounts(searchLearned, counts["learned"])
+	for _, group := range searchPOSGroups {
+		results.POS = append(results.POS, withCounts([]SearchFacet{group.SearchFacet}, counts["pos"])...)
+	}
+	return nil
+}
+
+// explainDeinflection returns the shortest chain from one of the word's matched forms to the
+// query that the word's parts of speech allow, or "" if there is none
+func explainDeinflection(word KanjiWord, forms []string, conjugated map[string][]deinflect.Candidate) string {
+	var codes []string
+	for _, sense := range word.Senses {
+		for _, pos := range sense.POS {
+			codes = append(codes, pos.Code)
+		}
+	}
+
+	best := ""
+	bestLen := 0
+	for _, form := range forms {
+		for _, c := range conjugated[form] {
+			if c.FitsPOS(codes) && (best == "" || len(c.Reasons) < bestLen) {
+				best, bestLen = c.Chain(), len(c.Reasons)
+			}
+		}
+	}
+	return best
+}
+
+// containsJapanese checks if a string contains Japanese characters
+func containsJapanese(s string) bool {
+	for _, r := range s {
+		// Hiragana: U+3040 - U+309F
+		// Katakana: U+30A0 - U+30FF
+		// CJK Unified Ideographs (Kanji): U+4E00 - U+9FAF
+		// CJK Extension A: U+3400 - U+4DBF
+		if (r >= 0x3040 && r <= 0x309F) || // Hiragana
+			(r >= 0x30A0 && r <= 0x30FF) || // Katakana
+			(r >= 0x4E00 && r <= 0x9FAF) || // CJK Unified Ideographs
+			(r >= 0x3400 && r <= 0x4DBF) { // CJK Extension A
+			return true
+		}
+	}
+	return false
+}
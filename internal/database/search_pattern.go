@@ -0,0 +1,117 @@
+package database
+
+import (
+	"fmt"
+	"gaijin/internal/kana"
+	"gaijin/internal/searchquery"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Pattern searches (食べ?, *かい, reading:*かい pos:adverb) are parsed by searchquery and
+// translated here into match subqueries, like the ones in search.go, and conditions on words w
+
+// patternMatchQuery matches the forms and readings LIKE the pattern in param, primary ones first
+func patternMatchQuery(param string) string {
+	rank := fmt.Sprintf(`MIN(CASE WHEN kind = 'primary' THEN %d ELSE %d END)`, searchRankExactPrimary, searchRankExact)
+	return `
+		SELECT word_id, ` + rank + ` AS rank, 1 AS sense, NULL::text AS matched
+		FROM word_forms WHERE form LIKE ` + param + `
+		GROUP BY word_id
+		UNION ALL
+		SELECT word_id, ` + rank + ` AS rank, 1 AS sense, NULL::text AS matched
+		FROM word_readings WHERE reading LIKE ` + param + `
+		GROUP BY word_id`
+}
+
+// englishPatternMatchQuery matches the words with a definition LIKE the pattern in param,
+// so "to *" finds verbs; "to " is optional, as in englishMatchQuery
+func englishPatternMatchQuery(param string) string {
+	return fmt.Sprintf(`
+		SELECT id AS word_id, %[2]d AS rank, 1 AS sense, NULL::text AS matched
+		FROM words
+		WHERE EXISTS (
+			SELECT 1 FROM unnest(string_to_array(LOWER(definitions), '; ')) d
+			WHERE d LIKE LOWER(%[1]s) OR d LIKE 'to ' || LOWER(%[1]s))`,
+		param, searchRankExact)
+}
+
+// allWordsMatchQuery matches every word, for queries that are only filters (pos:adverb level:3)
+func allWordsMatchQuery() string {
+	return fmt.Sprintf(`
+		SELECT id AS word_id, %d AS rank, 1 AS sense, NULL::text AS matched FROM words`, searchRankContains)
+}
+
+// romajiPattern converts the romaji between a pattern's wildcards to hiragana and katakana
+// ok is false if the pattern isn't romaji
+func romajiPattern(p searchquery.Pattern) (hiragana, katakana searchquery.Pattern, ok bool) {
+	hiragana, ok = p.MapLiterals(func(s string) (string, bool) {
+		h, _, ok := kana.FromRomaji(s)
+		return h, ok
+	})
+	if !ok {
+		return "", "", false
+	}
+	katakana, _ = hiragana.MapLiterals(func(s string) (string, bool) { return kana.ToKatakana(s), true })
+	return hiragana, katakana, true
+}
+
+// patternMatches returns the match subqueries for the query's free text when it has wildcards,
+// and the search type, as SearchWords would for plain text
+// Queries without text match every word and leave it to the filters; their type is "filters"
+func patternMatches(text searchquery.Pattern, param func(interface{}) string) ([]string, string) {
+	switch {
+	case text == "":
+		return []string{allWordsMatchQuery()}, "filters"
+	case containsJapanese(string(text)):
+		return []string{patternMatchQuery(param(text.Like()))}, "japanese"
+	}
+	if hiragana, katakana, ok := romajiPattern(text); ok {
+		return []string{
+			englishPatternMatchQuery(param(text.Like())),
+			patternMatchQuery(param(hiragana.Like())),
+			patternMatchQuery(param(katakana.Like())),
+		}, "romaji"
+	}
+	return []string{englishPatternMatchQuery(param(text.Like()))}, "english"
+}
+
+// patternConditions returns the conditions on words w for the query's filters
+func patternConditions(query searchquery.Query, param func(interface{}) string) []string {
+	var conditions []string
+	for _, node := range query.Nodes {
+		switch n := node.(type) {
+		case searchquery.Reading:
+			patterns := []searchquery.Pattern{n.Pattern}
+			if !containsJapanese(string(n.Pattern)) {
+				if hiragana, katakana, ok := romajiPattern(n.Pattern); ok {
+					patterns = []searchquery.Pattern{hiragana, katakana}
+				}
+			}
+			var likes []string
+			for _, p := range patterns {
+				likes = append(likes, "wr.reading LIKE "+param(p.Like()))
+			}
+			conditions = append(conditions, `EXISTS (
+				SELECT 1 FROM word_readings wr WHERE wr.word_id = w.id AND (`+strings.Join(likes, " OR ")+`))`)
+		case searchquery.POS:
+			codes := []string{n.Name} // a JMdict code such as v5k
+			for _, group := range searchPOSGroups {
+				if group.Value == n.Name {
+					codes = group.codes
+				}
+			}
+			conditions = append(conditions, hasPOS("w.id", param(pq.Array(codes))))
+		case searchquery.Level:
+			conditions = append(conditions, "w.level = "+param(n.Level)+" AND w.owner_id = 0")
+		case searchquery.Kanji:
+			for _, k := range n.Kanji {
+				contains := param("%" + string(k) + "%")
+				conditions = append(conditions, `(w.word LIKE `+contains+` OR EXISTS (
+				SELECT 1 FROM word_forms f WHERE f.word_id = w.id AND f.form LIKE `+contains+`))`)
+			}
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"fmt"
+	"gaijin/internal/searchquery"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestPatternSQLUsesPlaceholders checks that what users type reaches the SQL only as arguments
+func TestPatternSQLUsesPlaceholders(t *testing.T) {
+	queries := []string{
+		`'; DROP TABLE words; --*`,
+		`食べ'* reading:*か'い`,
+		`"x' OR '1'='1" level:3`,
+		`kanji:水 pos:v5k ta?e*`,
+		`pos:adverb`,
+	}
+	// Every single-quoted string in the SQL is one the code wrote
+	literal := regexp.MustCompile(`'[^']*'`)
+	allowed := map[string]bool{"'primary'": true, "'; '": true, "'to '": true}
+
+	for _, input := range queries {
+		q, err := searchquery.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		var args []interface{}
+		param := func(value interface{}) string {
+			args = append(args, value)
+			return fmt.Sprintf("$%d", len(args))
+		}
+
+		matches, _ := patternMatches(q.Text(), param)
+		sql := strings.Join(append(matches, patternConditions(q, param)...), "\n")
+
+		for _, lit := range literal.FindAllString(sql, -1) {
+			if !allowed[lit] {
+				t.Errorf("%q: SQL has the literal %s, want only placeholders:\n%s", input, lit, sql)
+			}
+		}
+		for _, arg := range args {
+			if s, ok := arg.(string); ok && s != "" && strings.Contains(sql, s) {
+				t.Errorf("%q: SQL contains the argument %q:\n%s", input, s, sql)
+			}
+		}
+		if len(args) == 0 {
+			t.Errorf("%q: no arguments, want the query passed as placeholders", input)
+		}
+		for i := range args {
+			if !strings.Contains(sql, fmt.Sprintf("$%d", i+1)) {
+				t.Errorf("%q: SQL doesn't use $%d", input, i+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"regexp"
+	"testing"
+)
+
+// The typed text is compared with = as is, but only ever reaches LIKE escaped, so 100% or a_b
+// don't act as wildcards
+func TestMatchQueriesEscapeLike(t *testing.T) {
+	likeOf := regexp.MustCompile(`LIKE [^\n]*?(\$\d)`)
+	queries := map[string]string{
+		"kanaMatchQuery":    kanaMatchQuery("$1", "$2"),
+		"englishMatchQuery": englishMatchQuery("$1", "$2"),
+	}
+
+	for name, sql := range queries {
+		matches := likeOf.FindAllStringSubmatch(sql, -1)
+		if len(matches) == 0 {
+			t.Errorf("%s has no LIKE:\n%s", name, sql)
+		}
+		for _, m := range matches {
+			if m[1] != "$2" {
+				t.Errorf("%s: %q uses %s, want the escaped $2", name, m[0], m[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Sentence is an example sentence chosen for a word
+type Sentence struct {
+	ID         int
+	Japanese   string
+	English    string
+	KnownWords int // Other indexed words in the sentence that are in the user's deck
+	TotalWords int // Other indexed words in the sentence
+
+	// Japanese split around the word the sentence was picked for, for highlighting
+	Before string
+	Match  string
+	After  string
+}
+
+// SentenceWord links a sentence to a word through one of its indexed headwords
+type SentenceWord struct {
+	WordID      int
+	Surface     string // How the word appears in the sentence (e.g. 会った for 会う)
+	GoodExample bool   // Marked with ~ in the index: a checked, representative example
+}
+
+// maxSentenceCandidates caps how many sentences per word are scored for known words
+const maxSentenceCandidates = 500
+
+// GetExampleSentences picks one example sentence per word: the one using the most words
+// already in the user's deck, then the fewest words overall, preferring checked examples
+// Only sentences where the word's surface form can be found are used, so Match is always set
+// Words without sentences are missing from the result
+func (db *Database) GetExampleSentences(userID int, wordIDs []int) (map[int]*Sentence, error) {
+	result := make(map[int]*Sentence)
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT t.word_id, s.id, s.japanese, s.english, best.surface, best.known, best.total
+		FROM unnest($2::int[]) AS t(word_id)
+		CROSS JOIN LATERAL (
+			SELECT c.sentence_id, c.surface,
+				COUNT(o.word_id) FILTER (WHERE EXISTS (
+					SELECT 1 FROM sr WHERE sr.user_id = $1 AND sr.word_id = o.word_id
+				)) AS known,
+				COUNT(o.word_id) AS total
+			FROM (
+				SELECT sw.sentence_id, sw.word_id, sw.surface, sw.good_example
+				FROM sentence_words sw
+				JOIN sentences x ON x.id = sw.sentence_id
+				WHERE sw.word_id = t.word_id AND strpos(x.japanese, sw.surface) > 0
+				ORDER BY sw.good_example DESC, sw.sentence_id
+				LIMIT $3
+			) c
+			LEFT JOIN sentence_words o ON o.sentence_id = c.sentence_id AND o.word_id <> c.word_id
+			GROUP BY c.sentence_id, c.surface, c.good_example
+			ORDER BY known DESC, total ASC, c.good_example DESC, c.sentence_id
+			LIMIT 1
+		) best
+		JOIN sentences s ON s.id = best.sentence_id
+	`
+	rows, err := db.DB.Query(query, userID, pq.Array(wordIDs), maxSentenceCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get example sentences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var s Sentence
+		var surface string
+		if err := rows.Scan(&wordID, &s.ID, &s.Japanese, &s.English, &surface, &s.KnownWords, &s.TotalWords); err != nil {
+			return nil, fmt.Errorf("failed to scan example sentence: %w", err)
+		}
+		s.split(surface)
+		result[wordID] = &s
+	}
+	return result, nil
+}
+
+// split sets Before, Match and After around the first occurrence of surface
+func (s *Sentence) split(surface string) {
+	s.Before, s.Match, s.After = s.Japanese, "", ""
+	if i := strings.Index(s.Japanese, surface); surface != "" && i >= 0 {
+		s.Before, s.Match, s.After = s.Japanese[:i], surface, s.Japanese[i+len(surface):]
+	}
+}
+
+// GetSentence gets a sentence split around the given word, or nil if the word isn't linked to it
+func (db *Database) GetSentence(sentenceID int, wordID int) (*Sentence, error) {
+	var s Sentence
+	var surface string
+	err := db.DB.QueryRow(`
+		SELECT s.id, s.japanese, s.english, sw.surface
+		FROM sentences s
+		JOIN sentence_words sw ON sw.sentence_id = s.id
+		WHERE s.id = $1 AND sw.word_id = $2
+	`, sentenceID, wordID).Scan(&s.ID, &s.Japanese, &s.English, &surface)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentence: %w", err)
+	}
+	s.split(surface)
+	return &s, nil
+}
+
+// GetExampleSentence picks the example sentence for a single word, or nil if it has none
+func (db *Database) GetExampleSentence(userID int, wordID int) (*Sentence, error) {
+	sentences, err := db.GetExampleSentences(userID, []int{wordID})
+	if err != nil {
+		return nil, err
+	}
+	return sentences[wordID], nil
+}
+
+// AttachExampleSentences sets Example on each word
+func (db *Database) AttachExampleSentences(userID int, words []KanjiWord) error {
+	ids := make([]int, len(words))
+	for i := range words {
+		ids[i] = words[i].ID
+	}
+	sentences, err := db.GetExampleSentences(userID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range words {
+		words[i].Example = sentences[words[i].ID]
+	}
+	return nil
+}
+
+// SaveSentence upserts a sentence by its source ID and replaces its word links
+func (db *Database) SaveSentence(sourceID string, japanese string, english string, tokens string, links []SentenceWord) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sentenceID int
+	err = tx.QueryRow(`
+		INSERT INTO sentences (source_id, japanese, english, tokens)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source_id) DO UPDATE
+		SET japanese = EXCLUDED.japanese, english = EXCLUDED.english, tokens = EXCLUDED.tokens
+		RETURNING id
+	`, sourceID, japanese, english, tokens).Scan(&sentenceID)
+	if err != nil {
+		return fmt.Errorf("failed to save sentence: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM sentence_words WHERE sentence_id = $1`, sentenceID); err != nil {
+		return fmt.Errorf("failed to clear sentence words: %w", err)
+	}
+	for _, link := range links {
+		_, err := tx.Exec(`
+			INSERT INTO sentence_words (sentence_id, word_id, surface, good_example)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (sentence_id, word_id) DO NOTHING
+		`, sentenceID, link.WordID, link.Surface, link.GoodExample)
+		if err != nil {
+			return fmt.Errorf("failed to link sentence word: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit sentence: %w", err)
+	}
+	return nil
+}
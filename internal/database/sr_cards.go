@@ -0,0 +1,47 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// SRCard is one of a user's SR cards for a word, e.g. its meaning or pronunciation card
+type SRCard struct {
+	Type        string
+	Repetitions int
+	Interval    int // days
+	EF          float64
+	NextReview  time.Time
+	Suspended   bool
+}
+
+// GetWordSRCards returns the user's SR cards for the words, keyed by word ID
+// Words that aren't in the user's deck are left out
+func (db *Database) GetWordSRCards(userID int, wordIDs []int) (map[int][]SRCard, error) {
+	cards := make(map[int][]SRCard)
+	if len(wordIDs) == 0 {
+		return cards, nil
+	}
+	rows, err := db.DB.Query(`
+		SELECT word_id, type, repetitions, interval, ef, next_review, COALESCE(suspended, false)
+		FROM sr
+		WHERE user_id = $1 AND word_id = ANY($2)
+		ORDER BY word_id, type
+	`, userID, pq.Array(wordIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SR cards: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var card SRCard
+		if err := rows.Scan(&wordID, &card.Type, &card.Repetitions, &card.Interval, &card.EF, &card.NextReview, &card.Suspended); err != nil {
+			return nil, fmt.Errorf("failed to scan SR card: %w", err)
+		}
+		cards[wordID] = append(cards[wordID], card)
+	}
+	return cards, rows.Err()
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+// DefaultFrequencyCorpus orders words by words.frequency, the original frequency list
+const DefaultFrequencyCorpus = ""
+
+// frequencyCorpusLabels names the corpora we import lists for; other corpora show their key
+var frequencyCorpusLabels = map[string]string{
+	"news":   "News",
+	"novels": "Novels",
+	"anime":  "Anime & drama subtitles",
+	"web":    "Web",
+}
+
+// FrequencyCorpus is a corpus with imported frequency ranks
+type FrequencyCorpus struct {
+	Name      string // key in word_frequencies.corpus
+	Label     string
+	WordCount int // words with a rank in this corpus
+}
+
+// ValidFrequencyCorpusName reports whether name can be used as a corpus key
+func ValidFrequencyCorpusName(name string) bool {
+	if name == "" || len(name) > 50 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// frequencyRank returns the join and column giving a word's rank in a corpus
+// param is the placeholder holding the corpus name, e.g. "$4"; the default corpus needs no join
+func frequencyRank(corpus string, param string) (join string, column string) {
+	if corpus == DefaultFrequencyCorpus {
+		return "", "w.frequency"
+	}
+	return "LEFT JOIN word_frequencies wf ON wf.word_id = w.id AND wf.corpus = " + param, "wf.rank"
+}
+
+// GetFrequencyCorpora returns the corpora that have frequency ranks, largest first
+func (db *Database) GetFrequencyCorpora() ([]FrequencyCorpus, error) {
+	rows, err := db.DB.Query(`
+		SELECT corpus, COUNT(*)
+		FROM word_frequencies
+		GROUP BY corpus
+		ORDER BY COUNT(*) DESC, corpus
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get frequency corpora: %w", err)
+	}
+	defer rows.Close()
+
+	var corpora []FrequencyCorpus
+	for rows.Next() {
+		var c FrequencyCorpus
+		if err := rows.Scan(&c.Name, &c.WordCount); err != nil {
+			return nil, fmt.Errorf("failed to scan frequency corpus: %w", err)
+		}
+		c.Label = frequencyCorpusLabels[c.Name]
+		if c.Label == "" {
+			c.Label = c.Name
+		}
+		corpora = append(corpora, c)
+	}
+	return corpora, nil
+}
+
+// HasFrequencyCorpus reports whether ranks have been imported for a corpus
+func (db *Database) HasFrequencyCorpus(corpus string) (bool, error) {
+	if corpus == DefaultFrequencyCorpus {
+		return true, nil
+	}
+	var exists bool
+	err := db.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM word_frequencies WHERE corpus = $1)`, corpus).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check frequency corpus: %w", err)
+	}
+	return exists, nil
+}
+
+// ReplaceWordFrequencies replaces all ranks of a corpus with ranks (word ID -> rank)
+func (db *Database) ReplaceWordFrequencies(corpus string, ranks map[int]int) error {
+	if !ValidFrequencyCorpusName(corpus) {
+		return fmt.Errorf("invalid corpus name %q", corpus)
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM word_frequencies WHERE corpus = $1`, corpus); err != nil {
+		return fmt.Errorf("failed to clear word frequencies: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO word_frequencies (word_id, corpus, rank) VALUES ($1, $2, $3)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare word frequency insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for wordID, rank := range ranks {
+		if _, err := stmt.Exec(wordID, corpus, rank); err != nil {
+			return fmt.Errorf("failed to insert word frequency: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word frequencies: %w", err)
+	}
+	log.Printf("✅ Stored %d %s frequency ranks", len(ranks), corpus)
+	return nil
+}
+
+// GetWordFrequencyRanks returns the words' ranks in a corpus, keyed by word ID; unranked words are left out
+func (db *Database) GetWordFrequencyRanks(corpus string, wordIDs []int) (map[int]int, error) {
+	ranks := make(map[int]int)
+	if len(wordIDs) == 0 {
+		return ranks, nil
+	}
+	args := []interface{}{pq.Array(wordIDs)}
+	param := ""
+	if corpus != DefaultFrequencyCorpus {
+		args = append(args, corpus)
+		param = "$2"
+	}
+	join, rank := frequencyRank(corpus, param)
+	rows, err := db.DB.Query(`SELECT w.id, `+rank+` FROM words w `+join+` WHERE w.id = ANY($1) AND `+rank+` IS NOT NULL`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get frequency ranks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, r int
+		if err := rows.Scan(&id, &r); err != nil {
+			return nil, fmt.Errorf("failed to scan frequency rank: %w", err)
+		}
+		ranks[id] = r
+	}
+	return ranks, rows.Err()
+}
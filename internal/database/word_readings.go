@@ -0,0 +1,335 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gaijin/internal/jmdict"
+	"gaijin/internal/pitch"
+
+	"github.com/lib/pq"
+)
+
+// Variant kinds for word_forms and word_readings
+const (
+	VariantPrimary   = "primary"   // The form or reading the word is studied under
+	VariantAlternate = "alternate" // Also correct (e.g. まいつき for 毎月)
+	VariantRare      = "rare"      // Rare, outdated or irregular; searchable but not accepted as an answer
+)
+
+// WordForm is a written form of a word (e.g. 毎月, or ラジオカセット for ラジカセ)
+type WordForm struct {
+	Form string
+	Kind string
+}
+
+// WordReading is a kana reading of a word
+type WordReading struct {
+	Reading string
+	Kind    string
+	Accents []int // Pitch accent positions from pitch_accents (0 = heiban), empty if unknown
+}
+
+// Pitches returns the reading's known pitch accent patterns
+func (r WordReading) Pitches() []pitch.Pattern {
+	patterns := make([]pitch.Pattern, 0, len(r.Accents))
+	for _, accent := range r.Accents {
+		patterns = append(patterns, pitch.New(r.Reading, accent))
+	}
+	return patterns
+}
+
+// PitchReading returns the first reading with pitch accent data, preferring primary readings
+// Returns nil if no reading has any
+func PitchReading(readings []WordReading) *WordReading {
+	var found *WordReading
+	for i := range readings {
+		if len(readings[i].Accents) == 0 {
+			continue
+		}
+		if readings[i].Kind == VariantPrimary {
+			return &readings[i]
+		}
+		if found == nil {
+			found = &readings[i]
+		}
+	}
+	return found
+}
+
+// AcceptsReading reports whether answer is a primary or alternate reading of the word
+// Falls back to the "/"-separated furigana for words that haven't been migrated yet
+func (w *Word) AcceptsReading(answer string) bool {
+	answer = strings.TrimSpace(answer)
+	if len(w.Readings) == 0 {
+		return containsString(SplitAlternates(w.Furigana), answer)
+	}
+	for _, r := range w.Readings {
+		if r.Reading == answer && r.Kind != VariantRare {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryReading returns the word's main reading, or "" if it has none
+// Falls back to the first of the "/"-separated furigana for words that haven't been migrated yet
+func (w *Word) PrimaryReading() string {
+	for _, r := range w.Readings {
+		if r.Kind == VariantPrimary {
+			return r.Reading
+		}
+	}
+	if len(w.Readings) > 0 {
+		return w.Readings[0].Reading
+	}
+	if alternates := SplitAlternates(w.Furigana); len(alternates) > 0 {
+		return alternates[0]
+	}
+	return ""
+}
+
+// SplitAlternates splits a legacy "a / b" column value into its parts
+func SplitAlternates(s string) []string {
+	var parts []string
+	for _, part := range strings.Split(s, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// LegacyVariants builds forms and readings from the "/"-separated words.word and words.furigana
+// columns: the first entry of each is primary, the rest are alternates
+func LegacyVariants(word string, furigana string) ([]WordForm, []WordReading) {
+	var forms []WordForm
+	for i, form := range SplitAlternates(word) {
+		kind := VariantAlternate
+		if i == 0 {
+			kind = VariantPrimary
+		}
+		forms = append(forms, WordForm{Form: form, Kind: kind})
+	}
+
+	var readings []WordReading
+	for i, reading := range SplitAlternates(furigana) {
+		kind := VariantAlternate
+		if i == 0 {
+			kind = VariantPrimary
+		}
+		readings = append(readings, WordReading{Reading: reading, Kind: kind})
+	}
+
+	// Kana-only words are often stored without furigana: they are their own reading
+	if len(readings) == 0 {
+		for _, form := range forms {
+			if len(ExtractKanji(form.Form)) == 0 {
+				readings = append(readings, WordReading{Reading: form.Form, Kind: form.Kind})
+			}
+		}
+	}
+	return forms, readings
+}
+
+// VariantsFromJMdict builds forms and readings for a word from its JMdict entry
+// word is the form being studied and primaryReading the reading shown as its furigana
+func VariantsFromJMdict(entry *jmdict.Entry, word string, primaryReading string) ([]WordForm, []WordReading) {
+	forms := []WordForm{{Form: word, Kind: VariantPrimary}}
+	for _, k := range entry.Kanji {
+		if k.Text == word {
+			continue
+		}
+		kind := VariantAlternate
+		if containsAny(k.Info, "rK", "oK", "sK", "iK") {
+			kind = VariantRare
+		}
+		forms = append(forms, WordForm{Form: k.Text, Kind: kind})
+	}
+
+	if !entry.HasWriting(word) {
+		// Kana word: its only accepted reading is itself
+		return forms, []WordReading{{Reading: word, Kind: VariantPrimary}}
+	}
+
+	var readings []WordReading
+	for _, r := range entry.Readings {
+		if r.NoKanji || (len(r.Restrict) > 0 && !containsString(r.Restrict, word)) {
+			continue
+		}
+		kind := VariantAlternate
+		switch {
+		case r.Text == primaryReading:
+			kind = VariantPrimary
+		case containsAny(r.Info, "ok", "ik", "rk", "sk"):
+			kind = VariantRare
+		}
+		readings = append(readings, WordReading{Reading: r.Text, Kind: kind})
+	}
+	return forms, readings
+}
+
+// ReplaceWordVariants replaces a word's written forms and readings
+func (db *Database) ReplaceWordVariants(wordID int, forms []WordForm, readings []WordReading) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceWordVariants(tx, wordID, forms, readings); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word variants: %w", err)
+	}
+	return nil
+}
+
+// replaceWordVariants replaces a word's written forms and readings within tx
+func replaceWordVariants(tx *sql.Tx, wordID int, forms []WordForm, readings []WordReading) error {
+	if _, err := tx.Exec(`DELETE FROM word_forms WHERE word_id = $1`, wordID); err != nil {
+		return fmt.Errorf("failed to clear word forms: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM word_readings WHERE word_id = $1`, wordID); err != nil {
+		return fmt.Errorf("failed to clear word readings: %w", err)
+	}
+
+	for i, f := range forms {
+		_, err := tx.Exec(`
+			INSERT INTO word_forms (word_id, form, kind, position)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (word_id, form) DO NOTHING
+		`, wordID, f.Form, f.Kind, i)
+		if err != nil {
+			return fmt.Errorf("failed to insert word form %s: %w", f.Form, err)
+		}
+	}
+	for i, r := range readings {
+		_, err := tx.Exec(`
+			INSERT INTO word_readings (word_id, reading, kind, position)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (word_id, reading) DO NOTHING
+		`, wordID, r.Reading, r.Kind, i)
+		if err != nil {
+			return fmt.Errorf("failed to insert word reading %s: %w", r.Reading, err)
+		}
+	}
+	return nil
+}
+
+// GetWordForms returns the written forms of the given words in order, keyed by word ID
+func (db *Database) GetWordForms(wordIDs []int) (map[int][]WordForm, error) {
+	return wordForms(db.DB, wordIDs)
+}
+
+// wordForms is GetWordForms on a database or transaction
+func wordForms(q querier, wordIDs []int) (map[int][]WordForm, error) {
+	result := make(map[int][]WordForm)
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+	rows, err := q.Query(`
+		SELECT word_id, form, kind FROM word_forms
+		WHERE word_id = ANY($1)
+		ORDER BY word_id, position
+	`, pq.Array(wordIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word forms: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var f WordForm
+		if err := rows.Scan(&wordID, &f.Form, &f.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan word form: %w", err)
+		}
+		result[wordID] = append(result[wordID], f)
+	}
+	return result, nil
+}
+
+// GetWordReadings returns the readings of the given words in order, keyed by word ID
+func (db *Database) GetWordReadings(wordIDs []int) (map[int][]WordReading, error) {
+	result := make(map[int][]WordReading)
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+	// Pitch accents are keyed by (form, reading): use the first form of the word that has one
+	rows, err := db.DB.Query(`
+		SELECT r.word_id, r.reading, r.kind, COALESCE(pa.accents, '{}')
+		FROM word_readings r
+		LEFT JOIN LATERAL (
+			SELECT p.accents
+			FROM word_forms f
+			JOIN pitch_accents p ON p.form = f.form AND p.reading = r.reading
+			WHERE f.word_id = r.word_id
+			ORDER BY f.position
+			LIMIT 1
+		) pa ON TRUE
+		WHERE r.word_id = ANY($1)
+		ORDER BY r.word_id, r.position
+	`, pq.Array(wordIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word readings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var r WordReading
+		var accents pq.Int64Array
+		if err := rows.Scan(&wordID, &r.Reading, &r.Kind, &accents); err != nil {
+			return nil, fmt.Errorf("failed to scan word reading: %w", err)
+		}
+		for _, a := range accents {
+			r.Accents = append(r.Accents, int(a))
+		}
+		result[wordID] = append(result[wordID], r)
+	}
+	return result, nil
+}
+
+// LookupWordByVariant finds the word that text is a written form or reading of
+// Primary forms win over primary readings, then alternates, then rare variants
+// Only dictionary words are considered; returns nil if nothing matches
+func (db *Database) LookupWordByVariant(text string) (*Word, error) {
+	query := `
+		SELECT w.id, w.word, COALESCE(w.furigana, ''), COALESCE(w.romaji, ''), w.level,
+			COALESCE(w.definitions, ''), COALESCE(w.parts_of_speech, ''), w.hiragana_only, w.created_at
+		FROM words w
+		JOIN (
+			SELECT word_id, kind, 0 AS is_reading FROM word_forms WHERE form = $1
+			UNION ALL
+			SELECT word_id, kind, 1 AS is_reading FROM word_readings WHERE reading = $1
+		) v ON v.word_id = w.id
+		WHERE w.owner_id = 0
+		ORDER BY CASE v.kind WHEN 'primary' THEN 0 WHEN 'alternate' THEN 1 ELSE 2 END,
+			v.is_reading, w.level DESC, w.id
+		LIMIT 1
+	`
+	var word Word
+	err := db.DB.QueryRow(query, text).Scan(&word.ID, &word.Word, &word.Furigana, &word.Romaji, &word.Level,
+		&word.Definitions, &word.PartsOfSpeech, &word.HiraganaOnly, &word.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup word by variant: %w", err)
+	}
+	if err := db.attachWordDetails(&word); err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+func containsAny(list []string, values ...string) bool {
+	for _, v := range values {
+		if containsString(list, v) {
+			return true
+		}
+	}
+	return false
+}
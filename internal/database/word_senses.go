@@ -0,0 +1,361 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gaijin/internal/jmdict"
+
+	"github.com/lib/pq"
+)
+
+// PartOfSpeech is a part-of-speech tag using JMdict's codes (e.g. "v5k", "adj-i", "adv")
+type PartOfSpeech struct {
+	Code        string
+	Description string
+}
+
+// Sense is one meaning of a word, in dictionary order
+type Sense struct {
+	Order   int // 1-based
+	Glosses []string
+	POS     []PartOfSpeech
+	Tags    []string // Usage, field and dialect tags (e.g. "uk", "col", "comp", "ksb")
+}
+
+// Gloss returns the sense's glosses joined for display
+func (s Sense) Gloss() string {
+	return strings.Join(s.Glosses, "; ")
+}
+
+// HasPOS reports whether the sense has a part of speech whose code starts with prefix
+// e.g. HasPOS("v5") matches every godan verb class
+func (s Sense) HasPOS(prefix string) bool {
+	for _, p := range s.POS {
+		if strings.HasPrefix(p.Code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// posRule maps a part-of-speech description fragment onto a JMdict code
+type posRule struct {
+	Fragment string
+	Code     string
+}
+
+// posRules converts the free-text part-of-speech descriptions stored in words.parts_of_speech
+// (Jisho's wording, or JMdict's DTD wording) onto JMdict codes. Order matters: more specific
+// fragments must come before the general ones they contain.
+var posRules = []posRule{
+	{"iku/yuku special class", "v5k-s"},
+	{"aru special class", "v5aru"},
+	{"'u' ending (special class)", "v5u-s"},
+	{"'ru' ending (irregular verb)", "v5r-i"},
+	{"godan verb with 'ku' ending", "v5k"},
+	{"godan verb with 'gu' ending", "v5g"},
+	{"godan verb with 'su' ending", "v5s"},
+	{"godan verb with 'tsu' ending", "v5t"},
+	{"godan verb with 'nu' ending", "v5n"},
+	{"godan verb with 'bu' ending", "v5b"},
+	{"godan verb with 'mu' ending", "v5m"},
+	{"godan verb with 'ru' ending", "v5r"},
+	{"godan verb with 'u' ending", "v5u"},
+	{"kureru special class", "v1-s"},
+	{"ichidan verb - zuru", "vz"},
+	{"ichidan verb", "v1"},
+	{"kuru verb", "vk"},
+	{"suru verb - special class", "vs-s"},
+	{"suru verb - included", "vs-i"},
+	{"suru verb", "vs"},
+	{"takes the aux. verb suru", "vs"},
+	{"transitive verb", "vt"},
+	{"intransitive verb", "vi"},
+	{"auxiliary verb", "aux-v"},
+	{"auxiliary adjective", "aux-adj"},
+	{"yoi/ii class", "adj-ix"},
+	{"i-adjective", "adj-i"},
+	{"adjective (keiyoushi)", "adj-i"},
+	{"na-adjective", "adj-na"},
+	{"adjectival nouns or quasi-adjectives", "adj-na"},
+	{"no-adjective", "adj-no"},
+	{"genitive case particle 'no'", "adj-no"},
+	{"pre-noun adjectival", "adj-pn"},
+	{"taru-adjective", "adj-t"},
+	{"'taru' adjective", "adj-t"},
+	{"adverb taking the 'to' particle", "adv-to"},
+	{"adverbial noun", "n-adv"},
+	{"temporal noun", "n-t"},
+	{"adverb", "adv"},
+	{"expression", "exp"},
+	{"counter", "ctr"},
+	{"numeric", "num"},
+	{"pronoun", "pn"},
+	{"conjunction", "conj"},
+	{"interjection", "int"},
+	{"copula", "cop"},
+	{"particle", "prt"},
+	{"prefix", "pref"},
+	{"suffix", "suf"},
+	{"auxiliary", "aux"},
+	{"proper noun", "n-pr"},
+	{"noun", "n"},
+}
+
+// posDescriptions gives a readable description for codes that arrive without one
+var posDescriptions = map[string]string{
+	"n": "Noun", "n-pr": "Proper noun", "n-adv": "Adverbial noun", "n-t": "Temporal noun",
+	"v1": "Ichidan verb", "v1-s": "Ichidan verb - kureru special class", "vz": "Ichidan verb - zuru verb",
+	"v5u": "Godan verb with 'u' ending", "v5u-s": "Godan verb with 'u' ending (special class)",
+	"v5k": "Godan verb with 'ku' ending", "v5k-s": "Godan verb - Iku/Yuku special class",
+	"v5g": "Godan verb with 'gu' ending", "v5s": "Godan verb with 'su' ending",
+	"v5t": "Godan verb with 'tsu' ending", "v5n": "Godan verb with 'nu' ending",
+	"v5b": "Godan verb with 'bu' ending", "v5m": "Godan verb with 'mu' ending",
+	"v5r": "Godan verb with 'ru' ending", "v5r-i": "Godan verb with 'ru' ending (irregular verb)",
+	"v5aru": "Godan verb - -aru special class", "vk": "Kuru verb - special class",
+	"vs": "Suru verb", "vs-i": "Suru verb - included", "vs-s": "Suru verb - special class",
+	"vt": "Transitive verb", "vi": "Intransitive verb", "aux-v": "Auxiliary verb",
+	"adj-i": "I-adjective (keiyoushi)", "adj-ix": "I-adjective (keiyoushi) - yoi/ii class",
+	"adj-na": "Na-adjective (keiyodoshi)", "adj-no": "No-adjective", "adj-pn": "Pre-noun adjectival (rentaishi)",
+	"adj-t": "Taru-adjective", "adv": "Adverb (fukushi)", "adv-to": "Adverb taking the 'to' particle",
+	"exp": "Expressions (phrases, clauses, etc.)", "ctr": "Counter", "num": "Numeric", "pn": "Pronoun",
+	"conj": "Conjunction", "int": "Interjection (kandoushi)", "cop": "Copula", "prt": "Particle",
+	"pref": "Prefix", "suf": "Suffix", "aux": "Auxiliary", "aux-adj": "Auxiliary adjective",
+}
+
+// POSCode returns the JMdict code for a part-of-speech description, or a lowercase slug of
+// the description if it isn't recognised
+func POSCode(description string) string {
+	lower := strings.ToLower(strings.TrimSpace(description))
+	if _, ok := posDescriptions[lower]; ok {
+		// Already a code
+		return lower
+	}
+	for _, rule := range posRules {
+		if strings.Contains(lower, rule.Fragment) {
+			return rule.Code
+		}
+	}
+	return strings.Join(strings.Fields(lower), "-")
+}
+
+// POSFromDescription builds a PartOfSpeech from a free-text description
+func POSFromDescription(description string) PartOfSpeech {
+	return PartOfSpeech{Code: POSCode(description), Description: strings.TrimSpace(description)}
+}
+
+// POSFromCode builds a PartOfSpeech from a JMdict code, using tags for the description if given
+func POSFromCode(code string, tags map[string]string) PartOfSpeech {
+	description := tags[code]
+	if description == "" {
+		description = posDescriptions[code]
+	}
+	if description == "" {
+		description = code
+	}
+	return PartOfSpeech{Code: code, Description: description}
+}
+
+// LegacySenses converts semicolon-separated definitions and parts of speech into senses
+// The old strings don't record which part of speech belongs to which definition, so every
+// sense gets all of them
+func LegacySenses(definitions string, partsOfSpeech string) []Sense {
+	var pos []PartOfSpeech
+	seen := make(map[string]bool)
+	for _, p := range strings.Split(partsOfSpeech, ";") {
+		if strings.TrimSpace(p) == "" || strings.EqualFold(strings.TrimSpace(p), "unknown") {
+			continue
+		}
+		part := POSFromDescription(p)
+		if !seen[part.Code] {
+			seen[part.Code] = true
+			pos = append(pos, part)
+		}
+	}
+
+	var senses []Sense
+	for _, def := range strings.Split(definitions, ";") {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+		senses = append(senses, Sense{Order: len(senses) + 1, Glosses: []string{def}, POS: pos})
+	}
+	return senses
+}
+
+// SensesFromJMdict converts a JMdict entry's senses, using tags for part-of-speech descriptions
+func SensesFromJMdict(entry *jmdict.Entry, tags map[string]string) []Sense {
+	var senses []Sense
+	for _, s := range entry.Senses {
+		sense := Sense{Order: len(senses) + 1, Glosses: s.Glosses}
+		for _, code := range s.POS {
+			sense.POS = append(sense.POS, POSFromCode(code, tags))
+		}
+		sense.Tags = append(sense.Tags, s.Misc...)
+		sense.Tags = append(sense.Tags, s.Field...)
+		sense.Tags = append(sense.Tags, s.Dialect...)
+		senses = append(senses, sense)
+	}
+	return senses
+}
+
+// ReplaceWordSenses replaces a word's senses and their parts of speech
+func (db *Database) ReplaceWordSenses(wordID int, senses []Sense) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceWordSenses(tx, wordID, senses); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word senses: %w", err)
+	}
+	return nil
+}
+
+// replaceWordSenses replaces a word's senses within tx
+func replaceWordSenses(tx *sql.Tx, wordID int, senses []Sense) error {
+	_, err := tx.Exec(`DELETE FROM word_sense_pos WHERE sense_id IN (SELECT id FROM word_senses WHERE word_id = $1)`, wordID)
+	if err != nil {
+		return fmt.Errorf("failed to clear sense parts of speech: %w", err)
+	}
+	_, err = tx.Exec(`DELETE FROM word_senses WHERE word_id = $1`, wordID)
+	if err != nil {
+		return fmt.Errorf("failed to clear word senses: %w", err)
+	}
+
+	posIDs := make(map[string]int)
+	for _, sense := range senses {
+		var senseID int
+		err = tx.QueryRow(`
+			INSERT INTO word_senses (word_id, sense_order, glosses, tags)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, wordID, sense.Order, sense.Gloss(), pq.Array(sense.Tags)).Scan(&senseID)
+		if err != nil {
+			return fmt.Errorf("failed to insert word sense: %w", err)
+		}
+
+		for _, pos := range sense.POS {
+			posID, ok := posIDs[pos.Code]
+			if !ok {
+				err = tx.QueryRow(`
+					INSERT INTO part_of_speech (code, description)
+					VALUES ($1, $2)
+					ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code
+					RETURNING id
+				`, pos.Code, pos.Description).Scan(&posID)
+				if err != nil {
+					return fmt.Errorf("failed to upsert part of speech %s: %w", pos.Code, err)
+				}
+				posIDs[pos.Code] = posID
+			}
+			_, err = tx.Exec(`INSERT INTO word_sense_pos (sense_id, pos_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, senseID, posID)
+			if err != nil {
+				return fmt.Errorf("failed to link part of speech: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetWordSenses returns the senses of the given words in order, keyed by word ID
+func (db *Database) GetWordSenses(wordIDs []int) (map[int][]Sense, error) {
+	return wordSenses(db.DB, wordIDs)
+}
+
+// wordSenses is GetWordSenses on a database or transaction
+func wordSenses(q querier, wordIDs []int) (map[int][]Sense, error) {
+	result := make(map[int][]Sense)
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT ws.word_id, ws.id, ws.sense_order, ws.glosses, COALESCE(ws.tags, '{}'),
+			COALESCE(p.code, ''), COALESCE(p.description, '')
+		FROM word_senses ws
+		LEFT JOIN word_sense_pos wsp ON wsp.sense_id = ws.id
+		LEFT JOIN part_of_speech p ON p.id = wsp.pos_id
+		WHERE ws.word_id = ANY($1)
+		ORDER BY ws.word_id, ws.sense_order, p.id
+	`
+	rows, err := q.Query(query, pq.Array(wordIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word senses: %w", err)
+	}
+	defer rows.Close()
+
+	lastSenseID := 0
+	for rows.Next() {
+		var wordID, senseID, order int
+		var glosses, code, description string
+		var tags []string
+		if err := rows.Scan(&wordID, &senseID, &order, &glosses, pq.Array(&tags), &code, &description); err != nil {
+			return nil, fmt.Errorf("failed to scan word sense: %w", err)
+		}
+
+		// One row per part of speech: start a new sense when the sense ID changes
+		if senseID != lastSenseID {
+			result[wordID] = append(result[wordID], Sense{
+				Order:   order,
+				Glosses: strings.Split(glosses, "; "),
+				Tags:    tags,
+			})
+			lastSenseID = senseID
+		}
+		if code != "" {
+			senses := result[wordID]
+			senses[len(senses)-1].POS = append(senses[len(senses)-1].POS, PartOfSpeech{Code: code, Description: description})
+		}
+	}
+	return result, nil
+}
+
+// attachWordDetails loads the structured senses, written forms and readings for a single word
+func (db *Database) attachWordDetails(word *Word) error {
+	ids := []int{word.ID}
+	senses, err := db.GetWordSenses(ids)
+	if err != nil {
+		return err
+	}
+	forms, err := db.GetWordForms(ids)
+	if err != nil {
+		return err
+	}
+	readings, err := db.GetWordReadings(ids)
+	if err != nil {
+		return err
+	}
+	word.Senses = senses[word.ID]
+	word.Forms = forms[word.ID]
+	word.Readings = readings[word.ID]
+	return nil
+}
+
+// attachKanjiWordDetails loads the structured senses and readings for a list of words
+func (db *Database) attachKanjiWordDetails(words []KanjiWord) error {
+	ids := make([]int, len(words))
+	for i := range words {
+		ids[i] = words[i].ID
+	}
+	senses, err := db.GetWordSenses(ids)
+	if err != nil {
+		return err
+	}
+	readings, err := db.GetWordReadings(ids)
+	if err != nil {
+		return err
+	}
+	for i := range words {
+		words[i].Senses = senses[words[i].ID]
+		words[i].Readings = readings[words[i].ID]
+	}
+	return nil
+}
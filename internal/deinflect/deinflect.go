@@ -0,0 +1,285 @@
+// Package deinflect turns conjugated Japanese verbs and i-adjectives back into their dictionary form.
+// It works on text alone, so it returns every form the input could have come from;
+// callers keep the candidates that are real words.
+package deinflect
+
+import (
+	"gaijin/internal/conjugation"
+	"strings"
+)
+
+// Word classes a rule can take or produce. A candidate's Type says what kind of word
+// it must be for the chain of reasons that produced it to make sense.
+const (
+	TypeIchidan = 1 << iota // 食べる
+	TypeGodan               // 書く
+	TypeSuru                // する
+	TypeKuru                // 来る
+	TypeTe                  // て-form, before いる
+	TypeAdjI                // 高い, and verb forms that conjugate like one (食べない, 食べたい)
+)
+
+// Candidate is a possible uninflected form of the input
+type Candidate struct {
+	Word    string
+	Type    int      // 0 for the input itself
+	Reasons []string // inflections applied to Word to get the input, innermost first
+}
+
+// Chain explains how Word becomes the input, e.g. "causative → passive → negative past"
+func (c Candidate) Chain() string {
+	return strings.Join(c.Reasons, " → ")
+}
+
+type rule struct {
+	from   string
+	to     string
+	in     int // types the inflected text can be; 0 means it only ends a word
+	out    int // type of the deinflected text
+	reason string
+}
+
+// placeholder stands in for the part of a word that conjugation leaves alone, so the
+// conjugation package can spell out the endings of every verb in a class
+const placeholder = "□"
+
+// verbModels are the verbs the rules are read from: a placeholder verb for each regular
+// ending, and the verbs with their own quirks as themselves
+// The quirky verbs come first, so 行って finds 行く before the regular 行う.
+var verbModels = []struct {
+	dict  string
+	class conjugation.Class
+}{
+	{"行く", conjugation.GodanIku},
+	{"いく", conjugation.GodanIku},
+	{"ある", conjugation.GodanAru},
+	{"くださる", conjugation.GodanHonorific},
+	{"なさる", conjugation.GodanHonorific},
+	{"いらっしゃる", conjugation.GodanHonorific},
+	{"おっしゃる", conjugation.GodanHonorific},
+	{"くれる", conjugation.IchidanKureru},
+	{"問う", conjugation.GodanU},
+	{"する", conjugation.Suru},
+	{"来る", conjugation.Kuru},
+	{"くる", conjugation.Kuru},
+	{placeholder + "る", conjugation.Ichidan},
+	{placeholder + "う", conjugation.Godan},
+	{placeholder + "く", conjugation.Godan},
+	{placeholder + "ぐ", conjugation.Godan},
+	{placeholder + "す", conjugation.Godan},
+	{placeholder + "つ", conjugation.Godan},
+	{placeholder + "ぬ", conjugation.Godan},
+	{placeholder + "ぶ", conjugation.Godan},
+	{placeholder + "む", conjugation.Godan},
+	{placeholder + "る", conjugation.Godan},
+}
+
+// classTypes are the rule types of each conjugation class
+var classTypes = map[conjugation.Class]int{
+	conjugation.Ichidan:        TypeIchidan,
+	conjugation.IchidanKureru:  TypeIchidan,
+	conjugation.Godan:          TypeGodan,
+	conjugation.GodanIku:       TypeGodan,
+	conjugation.GodanAru:       TypeGodan,
+	conjugation.GodanHonorific: TypeGodan,
+	conjugation.GodanU:         TypeGodan,
+	conjugation.Suru:           TypeSuru,
+	conjugation.Kuru:           TypeKuru,
+}
+
+// verbForms are the conjugated verb forms a rule undoes
+var verbForms = []struct {
+	form       conjugation.Form
+	polarity   conjugation.Polarity
+	politeness conjugation.Politeness
+	in         int
+	reason     string
+}{
+	// ない and たい conjugate on as i-adjectives (食べなかった, 食べたくない)
+	{conjugation.NonPast, conjugation.Negative, conjugation.Plain, TypeAdjI, "negative"},
+	{conjugation.NonPast, conjugation.Affirmative, conjugation.Polite, 0, "polite"},
+	{conjugation.Past, conjugation.Affirmative, conjugation.Polite, 0, "polite past"},
+	{conjugation.NonPast, conjugation.Negative, conjugation.Polite, 0, "polite negative"},
+	{conjugation.Past, conjugation.Negative, conjugation.Polite, 0, "polite negative past"},
+	{conjugation.Volitional, conjugation.Affirmative, conjugation.Polite, 0, "polite volitional"},
+	{conjugation.Te, conjugation.Affirmative, conjugation.Polite, 0, "polite て-form"},
+	{conjugation.Desire, conjugation.Affirmative, conjugation.Plain, TypeAdjI, "want to"},
+	{conjugation.Te, conjugation.Affirmative, conjugation.Plain, TypeTe, "て-form"},
+	{conjugation.Past, conjugation.Affirmative, conjugation.Plain, 0, "past"},
+	{conjugation.Tara, conjugation.Affirmative, conjugation.Plain, 0, "conditional (たら)"},
+	{conjugation.Conditional, conjugation.Affirmative, conjugation.Plain, 0, "conditional (ば)"},
+	{conjugation.Imperative, conjugation.Affirmative, conjugation.Plain, 0, "imperative"},
+	{conjugation.Volitional, conjugation.Affirmative, conjugation.Plain, 0, "volitional"},
+	// These conjugate on as ichidan verbs (書かれる → 書かれない)
+	{conjugation.Potential, conjugation.Affirmative, conjugation.Plain, TypeIchidan, "potential"},
+	{conjugation.Passive, conjugation.Affirmative, conjugation.Plain, TypeIchidan, "passive"},
+	{conjugation.Causative, conjugation.Affirmative, conjugation.Plain, TypeIchidan, "causative"},
+}
+
+// adjectiveForms are the conjugated i-adjective forms a rule undoes, which also apply to
+// the ない and たい forms of verbs
+var adjectiveForms = []struct {
+	form       conjugation.AdjectiveForm
+	polarity   conjugation.Polarity
+	politeness conjugation.Politeness
+	in         int
+	reason     string
+}{
+	{conjugation.AdjectivePast, conjugation.Affirmative, conjugation.Plain, 0, "past"},
+	{conjugation.AdjectiveNonPast, conjugation.Negative, conjugation.Plain, TypeAdjI, "negative"},
+	{conjugation.AdjectiveNonPast, conjugation.Affirmative, conjugation.Polite, 0, "polite"},
+	{conjugation.AdjectiveNonPast, conjugation.Negative, conjugation.Polite, 0, "polite negative"},
+	{conjugation.AdjectivePast, conjugation.Negative, conjugation.Polite, 0, "polite negative past"},
+	{conjugation.AdjectiveTe, conjugation.Affirmative, conjugation.Plain, 0, "て-form"},
+	{conjugation.Adverbial, conjugation.Affirmative, conjugation.Plain, 0, "adverbial"},
+	{conjugation.AdjectiveConditional, conjugation.Affirmative, conjugation.Plain, 0, "conditional (ば)"},
+	{conjugation.Seeming, conjugation.Affirmative, conjugation.Plain, 0, "looks (そう)"},
+	{conjugation.Excessive, conjugation.Affirmative, conjugation.Plain, TypeIchidan, "too (すぎる)"},
+}
+
+// rules is built once from the conjugation package
+var rules = buildRules()
+
+func buildRules() []rule {
+	var rs []rule
+	for _, m := range verbModels {
+		v, err := conjugation.New(m.dict, "", m.class)
+		if err != nil {
+			panic("deinflect: " + err.Error())
+		}
+		to := strings.TrimPrefix(m.dict, placeholder)
+		add := func(from string, in int, reason string) {
+			if from = strings.TrimPrefix(from, placeholder); from != "" {
+				rs = append(rs, rule{from: from, to: to, in: in, out: classTypes[m.class], reason: reason})
+			}
+		}
+
+		for _, f := range verbForms {
+			text := v.Conjugate(f.form, f.polarity, f.politeness).Text
+			switch {
+			case f.form == conjugation.Passive && text == v.Conjugate(conjugation.Potential, f.polarity, f.politeness).Text:
+				continue // added as "potential or passive"
+			case f.form == conjugation.Potential && text == v.Conjugate(conjugation.Passive, f.polarity, f.politeness).Text:
+				add(text, f.in, "potential or passive")
+				continue
+			}
+			add(text, f.in, f.reason)
+		}
+
+		// The stems the literary ず and the bare masu stem are built from
+		negative := v.Conjugate(conjugation.NonPast, conjugation.Negative, conjugation.Plain).Text
+		add(strings.TrimSuffix(negative, "ない")+"ず", 0, "negative (literary)")
+		polite := v.Conjugate(conjugation.NonPast, conjugation.Affirmative, conjugation.Polite).Text
+		add(strings.TrimSuffix(polite, "ます"), 0, "masu stem")
+	}
+
+	adj, err := conjugation.NewAdjective(placeholder+"い", "", conjugation.IAdjective)
+	if err != nil {
+		panic("deinflect: " + err.Error())
+	}
+	addAdj := func(from string, in int, reason string) {
+		rs = append(rs, rule{from: strings.TrimPrefix(from, placeholder), to: "い", in: in, out: TypeAdjI, reason: reason})
+	}
+	for _, f := range adjectiveForms {
+		c := adj.Conjugate(f.form, f.polarity, f.politeness)
+		addAdj(c.Text, f.in, f.reason)
+		for _, alt := range c.Alts {
+			reason := f.reason
+			if f.form == conjugation.AdjectiveConditional {
+				reason = "conditional (たら)"
+			}
+			addAdj(alt, f.in, reason)
+		}
+	}
+	// Forms the conjugation tables don't show
+	addAdj("かろう", 0, "presumptive")
+	addAdj("さ", 0, "noun (さ)")
+
+	// ている and its contraction てる conjugate as ichidan verbs after a て-form
+	for _, te := range []string{"て", "で"} {
+		rs = append(rs,
+			rule{from: te + "いる", to: te, in: TypeIchidan, out: TypeTe, reason: "progressive"},
+			rule{from: te + "る", to: te, in: TypeIchidan, out: TypeTe, reason: "progressive"},
+		)
+	}
+	return rs
+}
+
+// Deinflect returns the input and every form it could be an inflection of, shortest
+// chains first
+func Deinflect(text string) []Candidate {
+	type key struct {
+		word string
+		typ  int
+	}
+	candidates := []Candidate{{Word: text}}
+	seen := map[key]bool{{text, 0}: true}
+
+	for i := 0; i < len(candidates); i++ {
+		c := candidates[i]
+		for _, r := range rules {
+			if c.Type != 0 && c.Type&r.in == 0 {
+				continue
+			}
+			if !strings.HasSuffix(c.Word, r.from) {
+				continue
+			}
+			word := strings.TrimSuffix(c.Word, r.from) + r.to
+			if word == "" || seen[key{word, r.out}] {
+				continue
+			}
+			seen[key{word, r.out}] = true
+			reasons := append([]string{r.reason}, c.Reasons...)
+			candidates = append(candidates, Candidate{Word: word, Type: r.out, Reasons: reasons})
+		}
+	}
+	return candidates
+}
+
+// posPrefixes are the JMdict part-of-speech codes (or their prefixes) a word of each type has
+var posPrefixes = map[int][]string{
+	TypeIchidan: {"v1"},
+	TypeGodan:   {"v5"},
+	TypeSuru:    {"vs"},
+	TypeKuru:    {"vk"},
+	TypeAdjI:    {"adj-i"},
+}
+
+// POSPrefixes returns the JMdict part-of-speech code prefixes a word must have one of to be
+// the candidate, or nil for the input itself, which can be any word
+func (c Candidate) POSPrefixes() []string {
+	var prefixes []string
+	for _, t := range []int{TypeIchidan, TypeGodan, TypeSuru, TypeKuru, TypeAdjI} {
+		if c.Type&t != 0 {
+			prefixes = append(prefixes, posPrefixes[t]...)
+		}
+	}
+	return prefixes
+}
+
+// FitsPOS reports whether a word with these JMdict part-of-speech codes can be the candidate,
+// e.g. only a v1 verb can be a candidate an ichidan rule produced
+// Words without codes fit any candidate, and the input itself fits any word
+func (c Candidate) FitsPOS(codes []string) bool {
+	if c.Type == 0 || len(codes) == 0 {
+		return true
+	}
+	for _, prefix := range c.POSPrefixes() {
+		for _, code := range codes {
+			if strings.HasPrefix(code, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Matches reports whether text is word itself or an inflection of it
+func Matches(text string, word string) bool {
+	for _, c := range Deinflect(text) {
+		if c.Word == word {
+			return true
+		}
+	}
+	return false
+}
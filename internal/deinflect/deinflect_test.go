@@ -0,0 +1,113 @@
+package deinflect
+
+import "testing"
+
+// find returns the first candidate for word, and whether there is one
+func find(candidates []Candidate, word string) (Candidate, bool) {
+	for _, c := range candidates {
+		if c.Word == word {
+			return c, true
+		}
+	}
+	return Candidate{}, false
+}
+
+func TestDeinflect(t *testing.T) {
+	tests := []struct {
+		text, word string
+		typ        int
+		chain      string
+	}{
+		{"食べさせられなかった", "食べる", TypeIchidan, "causative → potential or passive → negative → past"},
+		{"来なかった", "来る", TypeKuru, "negative → past"},
+		{"こなかった", "くる", TypeKuru, "negative → past"},
+		{"読ませる", "読む", TypeGodan, "causative"},
+		{"書かれない", "書く", TypeGodan, "passive → negative"},
+		{"行きます", "行く", TypeGodan, "polite"},
+		{"しよう", "する", TypeSuru, "volitional"},
+		{"ください", "くださる", TypeGodan, "imperative"},
+		{"食べている", "食べる", TypeIchidan, "て-form → progressive"},
+		{"読んでた", "読む", TypeGodan, "て-form → progressive → past"},
+		{"高くなかった", "高い", TypeAdjI, "negative → past"},
+		{"高そう", "高い", TypeAdjI, "looks (そう)"},
+		{"食べず", "食べる", TypeIchidan, "negative (literary)"},
+	}
+
+	for _, tt := range tests {
+		c, ok := find(Deinflect(tt.text), tt.word)
+		if !ok {
+			t.Errorf("Deinflect(%s) has no candidate %s", tt.text, tt.word)
+			continue
+		}
+		if c.Type != tt.typ || c.Chain() != tt.chain {
+			t.Errorf("Deinflect(%s) candidate %s = type %d, %q, want type %d, %q", tt.text, tt.word, c.Type, c.Chain(), tt.typ, tt.chain)
+		}
+	}
+}
+
+// 行って could be 行う or 行く, but 行く is the reading people mean, so it comes first
+func TestDeinflectIku(t *testing.T) {
+	for _, c := range Deinflect("行って") {
+		if c.Chain() != "て-form" {
+			continue
+		}
+		if c.Word != "行く" {
+			t.Errorf("first て-form candidate of 行って = %s, want 行く", c.Word)
+		}
+		return
+	}
+	t.Error("Deinflect(行って) has no て-form candidate")
+}
+
+func TestFitsPOS(t *testing.T) {
+	tests := []struct {
+		text, word string
+		codes      []string
+		want       bool
+	}{
+		{"食べさせられなかった", "食べる", []string{"v1", "vt"}, true},
+		{"読ませる", "読む", []string{"v5m", "vt"}, true},
+		{"来なかった", "来る", []string{"vk", "vi"}, true},
+		// Only an ichidan verb can drop る for ない, so 見なかった isn't the noun 見る
+		{"見なかった", "見る", []string{"n"}, false},
+		// かかない is かく's negative; only an ichidan かかる could also make it, and かかる is godan
+		{"かかない", "かかる", []string{"v5r"}, false},
+		{"かかない", "かく", []string{"v5k"}, true},
+		{"高くない", "高い", []string{"v1"}, false},
+		{"高くない", "高い", nil, true},
+	}
+
+	for _, tt := range tests {
+		c, ok := find(Deinflect(tt.text), tt.word)
+		if !ok {
+			t.Errorf("Deinflect(%s) has no candidate %s", tt.text, tt.word)
+			continue
+		}
+		if got := c.FitsPOS(tt.codes); got != tt.want {
+			t.Errorf("Deinflect(%s) candidate %s (%s) FitsPOS(%v) = %t, want %t", tt.text, tt.word, c.Chain(), tt.codes, got, tt.want)
+		}
+	}
+
+	// The input itself fits any word
+	if c := Deinflect("食べる")[0]; !c.FitsPOS([]string{"n"}) {
+		t.Errorf("input candidate FitsPOS(n) = false, want true")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		text, word string
+		want       bool
+	}{
+		{"食べさせられなかった", "食べる", true},
+		{"行って", "行く", true},
+		{"食べる", "食べる", true},
+		{"食べた", "飲む", false},
+	}
+
+	for _, tt := range tests {
+		if got := Matches(tt.text, tt.word); got != tt.want {
+			t.Errorf("Matches(%s, %s) = %t, want %t", tt.text, tt.word, got, tt.want)
+		}
+	}
+}
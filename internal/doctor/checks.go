@@ -0,0 +1,256 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"gaijin/internal/database"
+
+	"github.com/lib/pq"
+)
+
+// dictionaryWord is the part of a words row the script checks look at
+type dictionaryWord struct {
+	ID           int
+	Word         string
+	Furigana     string
+	Level        int
+	HiraganaOnly bool
+	KatakanaOnly bool
+}
+
+func (w dictionaryWord) String() string {
+	if w.Level == database.CustomWordLevel {
+		return w.Word
+	}
+	return fmt.Sprintf("%s (N%d)", w.Word, w.Level)
+}
+
+// loadWords returns every word, including users' custom words
+func loadWords(db *database.Database) ([]dictionaryWord, error) {
+	rows, err := db.DB.Query(`
+		SELECT id, word, COALESCE(furigana, ''), level, COALESCE(hiragana_only, false), COALESCE(katakana_only, false)
+		FROM words
+		ORDER BY level DESC, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words: %w", err)
+	}
+	defer rows.Close()
+
+	var words []dictionaryWord
+	for rows.Next() {
+		var w dictionaryWord
+		if err := rows.Scan(&w.ID, &w.Word, &w.Furigana, &w.Level, &w.HiraganaOnly, &w.KatakanaOnly); err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		words = append(words, w)
+	}
+	return words, rows.Err()
+}
+
+// findWords flags the words for which problem returns a description
+func findWords(db *database.Database, problem func(w dictionaryWord) string) ([]Problem, error) {
+	words, err := loadWords(db)
+	if err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	for _, w := range words {
+		if detail := problem(w); detail != "" {
+			problems = append(problems, Problem{ID: w.ID, Detail: w.String() + ": " + detail})
+		}
+	}
+	return problems, nil
+}
+
+// problemIDs returns the row IDs of problems
+func problemIDs(problems []Problem) []int64 {
+	ids := make([]int64, len(problems))
+	for i, p := range problems {
+		ids[i] = int64(p.ID)
+	}
+	return ids
+}
+
+// queryProblems runs a query returning (id, detail) rows
+func queryProblems(db *database.Database, query string) ([]Problem, error) {
+	rows, err := db.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var problems []Problem
+	for rows.Next() {
+		var p Problem
+		if err := rows.Scan(&p.ID, &p.Detail); err != nil {
+			return nil, err
+		}
+		problems = append(problems, p)
+	}
+	return problems, rows.Err()
+}
+
+// execCount runs a statement and returns the rows it changed
+func execCount(db *database.Database, query string, args ...interface{}) (int64, error) {
+	result, err := db.DB.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func findFuriganaWithKanji(db *database.Database) ([]Problem, error) {
+	return findWords(db, func(w dictionaryWord) string {
+		if hasKanji(w.Furigana) {
+			return "furigana " + w.Furigana
+		}
+		return ""
+	})
+}
+
+func katakanaFlagProblem(w dictionaryWord) string {
+	if katakana := isKatakana(w.Word); katakana != w.KatakanaOnly {
+		return fmt.Sprintf("katakana_only is %t, should be %t", w.KatakanaOnly, katakana)
+	}
+	return ""
+}
+
+func findKatakanaFlags(db *database.Database) ([]Problem, error) {
+	return findWords(db, katakanaFlagProblem)
+}
+
+func fixKatakanaFlags(db *database.Database) (int64, error) {
+	problems, err := findWords(db, katakanaFlagProblem)
+	if err != nil {
+		return 0, err
+	}
+	return execCount(db, `UPDATE words SET katakana_only = NOT COALESCE(katakana_only, false) WHERE id = ANY($1)`,
+		pq.Int64Array(problemIDs(problems)))
+}
+
+// hiraganaFlagProblem only judges kana words: for words with kanji, hiragana_only means
+// "usually written in kana", which the characters can't tell us
+func hiraganaFlagProblem(w dictionaryWord) string {
+	switch {
+	case isHiragana(w.Word) && !w.HiraganaOnly:
+		return "written in hiragana but hiragana_only is false"
+	case isKatakana(w.Word) && w.HiraganaOnly:
+		return "written in katakana but hiragana_only is true"
+	}
+	return ""
+}
+
+func findHiraganaFlags(db *database.Database) ([]Problem, error) {
+	return findWords(db, hiraganaFlagProblem)
+}
+
+func fixHiraganaFlags(db *database.Database) (int64, error) {
+	problems, err := findWords(db, hiraganaFlagProblem)
+	if err != nil {
+		return 0, err
+	}
+	return execCount(db, `UPDATE words SET hiragana_only = NOT COALESCE(hiragana_only, false) WHERE id = ANY($1)`,
+		pq.Int64Array(problemIDs(problems)))
+}
+
+func findDuplicateWords(db *database.Database) ([]Problem, error) {
+	return queryProblems(db, `
+		SELECT MIN(id), word || ' at levels ' || string_agg('N' || level, ', ' ORDER BY level DESC)
+		FROM words
+		WHERE owner_id = 0
+		GROUP BY word
+		HAVING COUNT(*) > 1
+		ORDER BY MIN(id)
+	`)
+}
+
+func findOrphanSR(db *database.Database) ([]Problem, error) {
+	return queryProblems(db, `
+		SELECT sr.id, 'user ' || sr.user_id || ' ' || sr.type || ' card for missing word ' || sr.word_id
+		FROM sr
+		WHERE NOT EXISTS (SELECT 1 FROM words w WHERE w.id = sr.word_id)
+		ORDER BY sr.id
+	`)
+}
+
+func fixOrphanSR(db *database.Database) (int64, error) {
+	return execCount(db, `DELETE FROM sr WHERE NOT EXISTS (SELECT 1 FROM words w WHERE w.id = sr.word_id)`)
+}
+
+// orphanSRKana matches sr_kana rows whose kana is missing from the table their kana_type names
+const orphanSRKana = `
+	(sk.kana_type = 'hiragana' AND NOT EXISTS (SELECT 1 FROM hiragana h WHERE h.id = sk.kana_id))
+	OR (sk.kana_type = 'katakana' AND NOT EXISTS (SELECT 1 FROM katakana k WHERE k.id = sk.kana_id))
+	OR sk.kana_type NOT IN ('hiragana', 'katakana')`
+
+func findOrphanSRKana(db *database.Database) ([]Problem, error) {
+	return queryProblems(db, `
+		SELECT sk.id, 'user ' || sk.user_id || ' card for missing ' || sk.kana_type || ' ' || sk.kana_id
+		FROM sr_kana sk
+		WHERE `+orphanSRKana+`
+		ORDER BY sk.id
+	`)
+}
+
+func fixOrphanSRKana(db *database.Database) (int64, error) {
+	return execCount(db, `DELETE FROM sr_kana sk WHERE `+orphanSRKana)
+}
+
+func findKanaScript(db *database.Database) ([]Problem, error) {
+	var problems []Problem
+	for _, table := range []string{"hiragana", "katakana"} {
+		rows, err := queryProblems(db, `SELECT id, character FROM `+table+` ORDER BY id`)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			ok := isHiragana(row.Detail)
+			if table == "katakana" {
+				ok = isKatakana(row.Detail)
+			}
+			if !ok {
+				problems = append(problems, Problem{ID: row.ID, Detail: fmt.Sprintf("%s in the %s table", row.Detail, table)})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// missingDefinitions matches words with no definitions text
+const missingDefinitions = `COALESCE(TRIM(w.definitions), '') = ''`
+
+func findMissingDefinitions(db *database.Database) ([]Problem, error) {
+	return queryProblems(db, `
+		SELECT w.id, w.word || ' (N' || w.level || ')' ||
+			CASE WHEN EXISTS (SELECT 1 FROM word_senses s WHERE s.word_id = w.id)
+				THEN ': has senses' ELSE ': no senses either' END
+		FROM words w
+		WHERE `+missingDefinitions+`
+		ORDER BY w.level DESC, w.id
+	`)
+}
+
+func fixMissingDefinitions(db *database.Database) (int64, error) {
+	return execCount(db, `
+		UPDATE words w
+		SET definitions = (SELECT string_agg(s.glosses, '; ' ORDER BY s.sense_order) FROM word_senses s WHERE s.word_id = w.id)
+		WHERE `+missingDefinitions+`
+		AND EXISTS (SELECT 1 FROM word_senses s WHERE s.word_id = w.id)
+	`)
+}
+
+func findMissingJLPTWords(db *database.Database) ([]Problem, error) {
+	problems, err := queryProblems(db, `
+		SELECT jv.id, jv.word || ' (N' || jv.level || ') ' || COALESCE(jv.furigana, '') || ' - ' || jv.meaning
+		FROM jlpt_vocabulary jv
+		LEFT JOIN words w ON jv.word = w.word AND jv.level = w.level AND w.owner_id = 0
+		WHERE w.id IS NULL
+		ORDER BY jv.level DESC, jv.id
+	`)
+	for i := range problems {
+		problems[i].Detail = strings.TrimSpace(problems[i].Detail)
+	}
+	return problems, err
+}
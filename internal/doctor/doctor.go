@@ -0,0 +1,195 @@
+// Package doctor checks the word, kana and SR tables for data problems and fixes the safe ones.
+// It's run with `gaijin doctor`.
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"gaijin/internal/database"
+)
+
+// sampleSize is how many problems each check prints
+const sampleSize = 20
+
+// Problem is one row a check flagged
+type Problem struct {
+	ID     int    // row ID in the checked table
+	Detail string // what's wrong, e.g. "沢山 (N5): furigana たくさん沢山"
+}
+
+// Check is a single integrity check
+type Check struct {
+	Name string
+	Help string // what the check looks for and, when fixable, what fixing does
+
+	find func(db *database.Database) ([]Problem, error)
+	fix  func(db *database.Database) (int64, error) // nil when there's no safe fix
+}
+
+// Fixable reports whether the check has a safe automatic fix
+func (c Check) Fixable() bool {
+	return c.fix != nil
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Check    Check
+	Problems []Problem
+	Fixed    int64 // rows changed by the fix, when run with fix
+}
+
+// Checks lists every check in report order
+var Checks = []Check{
+	{
+		Name: "furigana with kanji",
+		Help: "words whose furigana contains kanji; fix these by hand or with a correction",
+		find: findFuriganaWithKanji,
+	},
+	{
+		Name: "katakana_only flag",
+		Help: "katakana_only disagrees with the word's characters; fixing sets it from the characters",
+		find: findKatakanaFlags,
+		fix:  fixKatakanaFlags,
+	},
+	{
+		Name: "hiragana_only flag",
+		Help: "kana words whose hiragana_only disagrees with their script; fixing sets it from the characters",
+		find: findHiraganaFlags,
+		fix:  fixHiraganaFlags,
+	},
+	{
+		Name: "duplicate words",
+		Help: "the same dictionary word at more than one level; merge by hand, both may have SR cards",
+		find: findDuplicateWords,
+	},
+	{
+		Name: "orphan SR rows",
+		Help: "sr rows whose word no longer exists; fixing deletes them",
+		find: findOrphanSR,
+		fix:  fixOrphanSR,
+	},
+	{
+		Name: "orphan kana SR rows",
+		Help: "sr_kana rows whose kana no longer exists; fixing deletes them",
+		find: findOrphanSRKana,
+		fix:  fixOrphanSRKana,
+	},
+	{
+		Name: "kana table script",
+		Help: "hiragana or katakana table rows written in the wrong script",
+		find: findKanaScript,
+	},
+	{
+		Name: "missing definitions",
+		Help: "words without definitions; fixing fills them from the word's senses when it has any",
+		find: findMissingDefinitions,
+		fix:  fixMissingDefinitions,
+	},
+	{
+		Name: "JLPT words missing",
+		Help: "jlpt_vocabulary entries without a words row; rerun scripts/import_jmdict.go",
+		find: findMissingJLPTWords,
+	},
+}
+
+// Run runs every check, applying the safe fixes when fix is set
+// Problems are found before fixing, so the report shows what was wrong
+func Run(db *database.Database, fix bool) ([]Result, error) {
+	var results []Result
+	for _, check := range Checks {
+		problems, err := check.find(db)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", check.Name, err)
+		}
+		result := Result{Check: check, Problems: problems}
+		if fix && check.Fixable() && len(problems) > 0 {
+			result.Fixed, err = check.fix(db)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to fix: %w", check.Name, err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// PrintReport writes the results, with a sample of each check's problems
+func PrintReport(w io.Writer, results []Result, fix bool) {
+	total, fixable := 0, 0
+	for _, r := range results {
+		if len(r.Problems) == 0 {
+			fmt.Fprintf(w, "✅ %s\n", r.Check.Name)
+			continue
+		}
+
+		total += len(r.Problems)
+		status := ""
+		switch {
+		case fix && r.Check.Fixable():
+			status = fmt.Sprintf(" — fixed %d rows", r.Fixed)
+		case r.Check.Fixable():
+			fixable += len(r.Problems)
+			status = " — fixable with --fix"
+		}
+		fmt.Fprintf(w, "❌ %s: %d%s\n", r.Check.Name, len(r.Problems), status)
+		fmt.Fprintf(w, "   %s\n", r.Check.Help)
+		for i, p := range r.Problems {
+			if i == sampleSize {
+				fmt.Fprintf(w, "   ... and %d more\n", len(r.Problems)-sampleSize)
+				break
+			}
+			fmt.Fprintf(w, "   #%d %s\n", p.ID, p.Detail)
+		}
+	}
+
+	fmt.Fprintln(w, strings.Repeat("━", 50))
+	switch {
+	case total == 0:
+		fmt.Fprintln(w, "🎉 No problems found")
+	case fixable > 0:
+		fmt.Fprintf(w, "📋 %d problems, %d safe to fix: run `gaijin doctor --fix`\n", total, fixable)
+	default:
+		fmt.Fprintf(w, "📋 %d problems\n", total)
+	}
+}
+
+// isHiragana reports whether s is written only in hiragana (with prolonged sound marks)
+func isHiragana(s string) bool {
+	return onlyScript(s, func(r rune) bool { return r >= 'ぁ' && r <= 'ゟ' })
+}
+
+// isKatakana reports whether s is written only in katakana, matching scripts/import_jmdict.go
+func isKatakana(s string) bool {
+	return onlyScript(s, func(r rune) bool {
+		return r >= '゠' && r <= 'ヿ' || r >= 'ㇰ' && r <= 'ㇿ' || r >= '･' && r <= 'ﾟ'
+	})
+}
+
+// onlyScript reports whether s has at least one rune in the script and nothing else but
+// punctuation that kana words share, full or half width
+func onlyScript(s string, inScript func(rune) bool) bool {
+	found := false
+	for _, r := range s {
+		switch {
+		case r == ' ' || r == '・' || r == 'ー' || r == '〜' || r == '～' || r == '･' || r == 'ｰ':
+		case inScript(r):
+			found = true
+		default:
+			return false
+		}
+	}
+	return found
+}
+
+// hasKanji reports whether s contains any kanji
+func hasKanji(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package doctor
+
+import "testing"
+
+func TestScripts(t *testing.T) {
+	tests := []struct {
+		s                  string
+		hiragana, katakana bool
+	}{
+		{"たべる", true, false},
+		{"コーヒー", false, true},
+		{"ー", false, false},
+		{"・", false, false},
+		{"〜", false, false},
+		{"", false, false},
+		{"ゆっくり・と", true, false},
+		{"ｺｰﾋｰ", false, true},
+		{"ﾃﾚﾋﾞ", false, true},
+		{"アイスクリーム・ケーキ", false, true},
+		{"ㇰ", false, true},
+		{"ｰ", false, false},
+		{"･", false, false},
+		{"食べる", false, false},
+		{"カタカナとひらがな", false, false},
+		{"テレビ局", false, false},
+		{"abc", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := isHiragana(tt.s); got != tt.hiragana {
+			t.Errorf("isHiragana(%q) = %t, want %t", tt.s, got, tt.hiragana)
+		}
+		if got := isKatakana(tt.s); got != tt.katakana {
+			t.Errorf("isKatakana(%q) = %t, want %t", tt.s, got, tt.katakana)
+		}
+	}
+}
+
+func TestFlagProblems(t *testing.T) {
+	tests := []struct {
+		word                         dictionaryWord
+		hiraganaProblem, katakanaBad bool
+	}{
+		{dictionaryWord{Word: "たべる", HiraganaOnly: true}, false, false},
+		{dictionaryWord{Word: "たべる"}, true, false},
+		{dictionaryWord{Word: "コーヒー", KatakanaOnly: true}, false, false},
+		{dictionaryWord{Word: "コーヒー"}, false, true},
+		{dictionaryWord{Word: "コーヒー", HiraganaOnly: true, KatakanaOnly: true}, true, false},
+		{dictionaryWord{Word: "ｺｰﾋｰ", KatakanaOnly: true}, false, false},
+		{dictionaryWord{Word: "ー", KatakanaOnly: true}, false, true},
+		// hiragana_only on a kanji word means "usually written in kana", which is left alone
+		{dictionaryWord{Word: "迄", HiraganaOnly: true}, false, false},
+		{dictionaryWord{Word: "食べる"}, false, false},
+		{dictionaryWord{Word: "テレビ局", KatakanaOnly: true}, false, true},
+	}
+
+	for _, tt := range tests {
+		if got := hiraganaFlagProblem(tt.word) != ""; got != tt.hiraganaProblem {
+			t.Errorf("hiraganaFlagProblem(%+v) found a problem: %t, want %t", tt.word, got, tt.hiraganaProblem)
+		}
+		if got := katakanaFlagProblem(tt.word) != ""; got != tt.katakanaBad {
+			t.Errorf("katakanaFlagProblem(%+v) found a problem: %t, want %t", tt.word, got, tt.katakanaBad)
+		}
+	}
+}
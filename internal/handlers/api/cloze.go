@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"gaijin/internal/database"
+	"gaijin/internal/deinflect"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HandleAnswerCloze checks the word typed into the blank of a sentence cloze card
+func (h *StudyHandler) HandleAnswerCloze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	answer := strings.TrimSpace(r.FormValue("answer"))
+	if answer == "" {
+		http.Error(w, "Answer is required", http.StatusBadRequest)
+		return
+	}
+
+	srID, err := strconv.Atoi(r.FormValue("word-id"))
+	if err != nil {
+		http.Error(w, "Failed to parse word ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	owned, err := h.db.IsUserSRCard(userID, srID, database.SRTypeCloze)
+	if err != nil {
+		http.Error(w, "Failed to check card: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !owned {
+		http.Error(w, "Cloze card not found", http.StatusNotFound)
+		return
+	}
+	word, err := h.db.LookupWordBySRId(srID)
+	if err != nil {
+		http.Error(w, "Failed to lookup word by ID: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sentenceID, err := strconv.Atoi(r.FormValue("sentence-id"))
+	if err != nil {
+		http.Error(w, "Failed to parse sentence ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	sentence, err := h.db.GetSentence(sentenceID, word.ID)
+	if err != nil {
+		http.Error(w, "Failed to get sentence: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if sentence == nil {
+		http.Error(w, "Sentence not found for word", http.StatusNotFound)
+		return
+	}
+
+	isCorrect := clozeAccepts(word, sentence.Match, answer)
+
+	userSettings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		http.Error(w, "Failed to get user settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timeMs, err := strconv.Atoi(r.FormValue("time"))
+	if err != nil {
+		http.Error(w, "Failed to parse time: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	knowIt := timeMs < userSettings.SRTimeJapanese
+
+	returnURL := r.FormValue("return-url")
+	if returnURL == "" {
+		returnURL = "/study/cloze"
+	}
+
+	// If correct AND fast (knowIt), auto-rate as 5 and move to the next card
+	if isCorrect && knowIt {
+		err = h.db.UpdateSRWord(srID, 5)
+		if err != nil {
+			http.Error(w, "Failed to update SR: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		successURL := returnURL
+		if strings.Contains(returnURL, "?") {
+			successURL += "&success=true"
+		} else {
+			successURL += "?success=true"
+		}
+		http.Redirect(w, r, successURL, http.StatusSeeOther)
+		return
+	}
+
+	correctParam := "false"
+	if isCorrect {
+		correctParam = "true"
+	}
+	http.Redirect(w, r, fmt.Sprintf("/study/answer?sr_id=%d&type=cloze&correct=%s&answer=%s&sentence_id=%d&return-url=%s",
+		srID, correctParam, url.QueryEscape(answer), sentenceID, returnURL), http.StatusSeeOther)
+}
+
+// clozeAccepts reports whether answer fills the blank: the form used in the sentence, or the
+// same conjugation of the word in another spelling or reading (書いた and かいた both fill 書いた,
+// but 書く and 書かない don't)
+func clozeAccepts(word *database.Word, surface string, answer string) bool {
+	if answer == surface {
+		return true
+	}
+
+	forms := database.SplitAlternates(word.Word)
+	for _, f := range word.Forms {
+		if f.Kind != database.VariantRare {
+			forms = append(forms, f.Form)
+		}
+	}
+	isWord := func(s string) bool {
+		for _, form := range forms {
+			if s == form {
+				return true
+			}
+		}
+		return word.AcceptsReading(s)
+	}
+
+	answers := deinflect.Deinflect(answer)
+	for _, s := range deinflect.Deinflect(surface) {
+		if !isWord(s.Word) {
+			continue
+		}
+		for _, a := range answers {
+			if a.Type == s.Type && a.Chain() == s.Chain() && isWord(a.Word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HandleInitializeCloze turns on sentence cloze cards for every word in the user's deck
+func (h *StudyHandler) HandleInitializeCloze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.db.InitializeUserSRCloze(userID); err != nil {
+		http.Error(w, "Failed to initialize cloze cards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/study/cloze", http.StatusSeeOther)
+}
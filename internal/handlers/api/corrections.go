@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"gaijin/internal/auth"
+	"gaijin/internal/database"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CorrectionHandler handles proposing and moderating dictionary corrections
+type CorrectionHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+// NewCorrectionHandler creates a new correction handler
+func NewCorrectionHandler(db *database.Database, auth *auth.Auth) *CorrectionHandler {
+	return &CorrectionHandler{
+		db:   db,
+		auth: auth,
+	}
+}
+
+// HandleProposeCorrection queues a user's proposed fix to a word and returns to the form
+func (h *CorrectionHandler) HandleProposeCorrection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wordID, err := strconv.Atoi(r.FormValue("word_id"))
+	if err != nil {
+		http.Error(w, "Invalid word ID", http.StatusBadRequest)
+		return
+	}
+
+	formURL := fmt.Sprintf("/corrections/new?word_id=%d&return-url=%s", wordID, url.QueryEscape(r.FormValue("return-url")))
+	_, err = h.db.ProposeCorrection(userID, wordID, r.FormValue("field"), r.FormValue("proposed"), r.FormValue("note"))
+	if err != nil {
+		http.Redirect(w, r, formURL+"&error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, formURL+"&submitted=1", http.StatusSeeOther)
+}
+
+// HandleApplyCorrection applies a pending correction to its word (admins only)
+func (h *CorrectionHandler) HandleApplyCorrection(w http.ResponseWriter, r *http.Request) {
+	h.reviewCorrection(w, r, h.db.ApplyCorrection)
+}
+
+// HandleRejectCorrection rejects a pending correction (admins only)
+func (h *CorrectionHandler) HandleRejectCorrection(w http.ResponseWriter, r *http.Request) {
+	h.reviewCorrection(w, r, h.db.RejectCorrection)
+}
+
+// reviewCorrection is the shared handler for applying and rejecting corrections
+func (h *CorrectionHandler) reviewCorrection(w http.ResponseWriter, r *http.Request, review func(id int, adminID int, note string) error) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	adminID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid correction ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := review(id, adminID, r.FormValue("review_note")); err != nil {
+		http.Error(w, "Failed to review correction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/corrections", http.StatusSeeOther)
+}
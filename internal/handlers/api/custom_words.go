@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"gaijin/internal/auth"
+	"gaijin/internal/database"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CustomWordHandler handles creating, editing and promoting users' private words
+type CustomWordHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+// NewCustomWordHandler creates a new custom word handler
+func NewCustomWordHandler(db *database.Database, auth *auth.Auth) *CustomWordHandler {
+	return &CustomWordHandler{
+		db:   db,
+		auth: auth,
+	}
+}
+
+// HandleSaveCustomWord creates a custom word, or updates one when word_id is set
+// Optionally adds a new word straight to the user's SR deck
+func (h *CustomWordHandler) HandleSaveCustomWord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	word := database.CustomWord{
+		Word:          r.FormValue("word"),
+		Readings:      r.FormValue("readings"),
+		Romaji:        r.FormValue("romaji"),
+		Definitions:   r.FormValue("definitions"),
+		PartsOfSpeech: r.FormValue("parts_of_speech"),
+		Tags:          r.FormValue("tags"),
+	}
+
+	if wordIDStr := r.FormValue("word_id"); wordIDStr != "" {
+		wordID, err := strconv.Atoi(wordIDStr)
+		if err != nil {
+			http.Error(w, "Invalid word ID", http.StatusBadRequest)
+			return
+		}
+		if err := h.db.UpdateCustomWord(userID, wordID, word); err != nil {
+			redirectCustomWords(w, r, fmt.Sprintf("edit=%d&error=%s", wordID, url.QueryEscape(err.Error())))
+			return
+		}
+		redirectCustomWords(w, r, "saved=1")
+		return
+	}
+
+	wordID, err := h.db.CreateCustomWord(userID, word)
+	if err != nil {
+		redirectCustomWords(w, r, "error="+url.QueryEscape(err.Error()))
+		return
+	}
+	if r.FormValue("add_to_deck") == "on" {
+		if err := h.db.AddWordToSR(userID, wordID); err != nil {
+			redirectCustomWords(w, r, "error="+url.QueryEscape("word saved, but adding it to your deck failed: "+err.Error()))
+			return
+		}
+	}
+	redirectCustomWords(w, r, "saved=1")
+}
+
+// HandleDeleteCustomWord deletes one of the user's custom words and its SR cards
+func (h *CustomWordHandler) HandleDeleteCustomWord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wordID, err := strconv.Atoi(r.FormValue("word_id"))
+	if err != nil {
+		http.Error(w, "Invalid word ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteCustomWord(userID, wordID); err != nil {
+		redirectCustomWords(w, r, "error="+url.QueryEscape(err.Error()))
+		return
+	}
+	redirectCustomWords(w, r, "deleted=1")
+}
+
+// HandlePromoteCustomWord asks the moderators to add a custom word to the shared dictionary
+func (h *CustomWordHandler) HandlePromoteCustomWord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wordID, err := strconv.Atoi(r.FormValue("word_id"))
+	if err != nil {
+		http.Error(w, "Invalid word ID", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.db.ProposeCorrection(userID, wordID, database.CorrectionPromote, r.FormValue("level"), r.FormValue("note"))
+	if err != nil {
+		redirectCustomWords(w, r, "error="+url.QueryEscape(err.Error()))
+		return
+	}
+	redirectCustomWords(w, r, "proposed=1")
+}
+
+// redirectCustomWords returns to the My Words page with a status query
+func redirectCustomWords(w http.ResponseWriter, r *http.Request, query string) {
+	http.Redirect(w, r, "/words/custom?"+query, http.StatusSeeOther)
+}
@@ -0,0 +1,322 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"gaijin/internal/auth"
+	"gaijin/internal/database"
+	"gaijin/internal/searchquery"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DictionaryHandler serves the dictionary as JSON for scripts and the browser extension
+type DictionaryHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+func NewDictionaryHandler(db *database.Database, auth *auth.Auth) *DictionaryHandler {
+	return &DictionaryHandler{db: db, auth: auth}
+}
+
+// APIWord is a word with the calling user's SR status
+type APIWord struct {
+	ID            int             `json:"id"`
+	Word          string          `json:"word"`
+	Furigana      string          `json:"furigana"`
+	Level         int             `json:"level"` // JLPT level, 0 for custom words
+	Custom        bool            `json:"custom"`
+	Frequency     int             `json:"frequency,omitempty"` // rank in the user's frequency corpus
+	Definitions   string          `json:"definitions"`
+	PartsOfSpeech []string        `json:"parts_of_speech"` // JMdict codes across all senses
+	Forms         []APIForm       `json:"forms,omitempty"` // only on /api/v1/words/{id}
+	Readings      []APIReading    `json:"readings"`
+	Senses        []APISense      `json:"senses"`
+	Deinflection  string          `json:"deinflection,omitempty"` // how the search query inflects the word
+	SR            APIWordSRStatus `json:"sr"`
+}
+
+// APIForm is a written form of a word
+type APIForm struct {
+	Form string `json:"form"`
+	Kind string `json:"kind"` // primary, alternate or rare
+}
+
+// APIReading is a kana reading of a word
+type APIReading struct {
+	Reading      string `json:"reading"`
+	Kind         string `json:"kind"`          // primary, alternate or rare
+	PitchAccents []int  `json:"pitch_accents"` // downstep positions, 0 for heiban
+}
+
+// APISense is one meaning of a word
+type APISense struct {
+	Order   int      `json:"order"`
+	Glosses []string `json:"glosses"`
+	POS     []APIPOS `json:"pos"`
+	Tags    []string `json:"tags"`
+}
+
+// APIPOS is a part-of-speech tag
+type APIPOS struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// APIWordSRStatus is where a word is in the user's SR deck
+type APIWordSRStatus struct {
+	InDeck    bool        `json:"in_deck"`
+	Suspended bool        `json:"suspended"`
+	Cards     []APISRCard `json:"cards"`
+}
+
+// APISRCard is one of the user's SR cards for a word
+type APISRCard struct {
+	Type        string    `json:"type"`
+	Repetitions int       `json:"repetitions"`
+	Interval    int       `json:"interval_days"`
+	EF          float64   `json:"ease_factor"`
+	NextReview  time.Time `json:"next_review"`
+	Suspended   bool      `json:"suspended"`
+}
+
+// APIFacet is a search filter value and how many results have it
+type APIFacet struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// APISearchResponse is one page of search results
+type APISearchResponse struct {
+	Query   string                `json:"query"`
+	Type    string                `json:"type"` // japanese, romaji or english
+	Total   int                   `json:"total"`
+	Page    int                   `json:"page"`
+	PerPage int                   `json:"per_page"`
+	Pages   int                   `json:"pages"`
+	Words   []APIWord             `json:"words"`
+	Facets  map[string][]APIFacet `json:"facets"` // level, pos and learned, before filtering
+}
+
+// writeAPIError writes a JSON error body
+func writeAPIError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// writeAPIJSON writes a JSON response body
+func writeAPIJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// newAPIWord converts a word's details and SR cards
+func newAPIWord(senses []database.Sense, readings []database.WordReading, cards []database.SRCard) APIWord {
+	word := APIWord{
+		PartsOfSpeech: []string{},
+		Readings:      make([]APIReading, len(readings)),
+		Senses:        make([]APISense, len(senses)),
+		SR:            APIWordSRStatus{InDeck: len(cards) > 0, Cards: make([]APISRCard, len(cards))},
+	}
+
+	seen := make(map[string]bool)
+	for i, s := range senses {
+		sense := APISense{Order: s.Order, Glosses: s.Glosses, POS: make([]APIPOS, len(s.POS)), Tags: s.Tags}
+		if sense.Tags == nil {
+			sense.Tags = []string{}
+		}
+		for j, p := range s.POS {
+			sense.POS[j] = APIPOS{Code: p.Code, Description: p.Description}
+			if !seen[p.Code] {
+				seen[p.Code] = true
+				word.PartsOfSpeech = append(word.PartsOfSpeech, p.Code)
+			}
+		}
+		word.Senses[i] = sense
+	}
+
+	for i, r := range readings {
+		accents := r.Accents
+		if accents == nil {
+			accents = []int{}
+		}
+		word.Readings[i] = APIReading{Reading: r.Reading, Kind: r.Kind, PitchAccents: accents}
+	}
+
+	// Suspending a word suspends all its cards, so any suspended card means the word is
+	for i, c := range cards {
+		word.SR.Cards[i] = APISRCard{
+			Type:        c.Type,
+			Repetitions: c.Repetitions,
+			Interval:    c.Interval,
+			EF:          c.EF,
+			NextReview:  c.NextReview,
+			Suspended:   c.Suspended,
+		}
+		word.SR.Suspended = word.SR.Suspended || c.Suspended
+	}
+	return word
+}
+
+// apiFacets converts search facets
+func apiFacets(facets []database.SearchFacet) []APIFacet {
+	out := make([]APIFacet, len(facets))
+	for i, f := range facets {
+		out[i] = APIFacet{Value: f.Value, Label: f.Label, Count: f.Count}
+	}
+	return out
+}
+
+// HandleSearch searches the dictionary like /search does, patterns included
+// Query parameters: q, and optionally level, pos, learned, page and per_page
+func (h *DictionaryHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		writeAPIError(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	params := r.URL.Query()
+	query := params.Get("q")
+	if query == "" {
+		writeAPIError(w, "Missing query parameter q", http.StatusBadRequest)
+		return
+	}
+	page, _ := strconv.Atoi(params.Get("page"))
+	perPage, _ := strconv.Atoi(params.Get("per_page"))
+
+	results, err := h.db.SearchWords(userID, query, database.SearchOptions{
+		Level:   params.Get("level"),
+		POS:     params.Get("pos"),
+		Learned: params.Get("learned"),
+		Page:    page,
+		PerPage: perPage,
+	})
+	var syntaxErr *searchquery.Error
+	if errors.As(err, &syntaxErr) {
+		writeAPIError(w, "Invalid query: "+syntaxErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeAPIError(w, "Failed to search words: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ids := make([]int, len(results.Words))
+	for i, word := range results.Words {
+		ids[i] = word.ID
+	}
+	cards, err := h.db.GetWordSRCards(userID, ids)
+	if err != nil {
+		writeAPIError(w, "Failed to get SR status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := APISearchResponse{
+		Query:   query,
+		Type:    results.Type,
+		Total:   results.Total,
+		Page:    results.Page,
+		PerPage: results.PerPage,
+		Pages:   results.Pages(),
+		Words:   make([]APIWord, len(results.Words)),
+		Facets: map[string][]APIFacet{
+			"level":   apiFacets(results.Levels),
+			"pos":     apiFacets(results.POS),
+			"learned": apiFacets(results.Learned),
+		},
+	}
+	for i, word := range results.Words {
+		apiWord := newAPIWord(word.Senses, word.Readings, cards[word.ID])
+		apiWord.ID = word.ID
+		apiWord.Word = word.Word
+		apiWord.Furigana = word.Furigana
+		apiWord.Level = word.Level
+		apiWord.Custom = word.Custom
+		apiWord.Frequency = word.Frequency
+		apiWord.Definitions = word.Definitions
+		apiWord.Deinflection = word.Deinflection
+		response.Words[i] = apiWord
+	}
+
+	writeAPIJSON(w, response)
+}
+
+// HandleWord returns one word with its forms, by ID
+func (h *DictionaryHandler) HandleWord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		writeAPIError(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wordID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || wordID <= 0 {
+		writeAPIError(w, "Invalid word ID", http.StatusBadRequest)
+		return
+	}
+
+	// Other users' custom words don't exist as far as this user is concerned
+	visible, err := h.db.CanSeeWord(userID, wordID)
+	if err != nil {
+		writeAPIError(w, "Failed to get word: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var word *database.Word
+	if visible {
+		word, err = h.db.GetWordByID(wordID)
+		if err != nil {
+			writeAPIError(w, "Failed to get word: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if word == nil {
+		writeAPIError(w, "Word not found", http.StatusNotFound)
+		return
+	}
+
+	cards, err := h.db.GetWordSRCards(userID, []int{wordID})
+	if err != nil {
+		writeAPIError(w, "Failed to get SR status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	settings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		writeAPIError(w, "Failed to get settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ranks, err := h.db.GetWordFrequencyRanks(settings.FrequencyCorpus, []int{wordID})
+	if err != nil {
+		writeAPIError(w, "Failed to get frequency: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	apiWord := newAPIWord(word.Senses, word.Readings, cards[wordID])
+	apiWord.ID = word.ID
+	apiWord.Word = word.Word
+	apiWord.Furigana = word.Furigana
+	apiWord.Level = word.Level
+	apiWord.Custom = word.Level == database.CustomWordLevel
+	apiWord.Frequency = ranks[wordID]
+	apiWord.Definitions = word.Definitions
+	apiWord.Forms = make([]APIForm, len(word.Forms))
+	for i, f := range word.Forms {
+		apiWord.Forms[i] = APIForm{Form: f.Form, Kind: f.Kind}
+	}
+
+	writeAPIJSON(w, apiWord)
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gaijin/internal/auth"
+	"gaijin/internal/database"
+	"gaijin/internal/handwriting"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// KanjiHandler handles kanji study and progression API endpoints
+type KanjiHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+// NewKanjiHandler creates a new kanji handler with database and auth dependencies
+func NewKanjiHandler(db *database.Database, auth *auth.Auth) *KanjiHandler {
+	return &KanjiHandler{
+		db:   db,
+		auth: auth,
+	}
+}
+
+// HandleAddKanji adds a single kanji to the user's SR kanji deck
+func (h *KanjiHandler) HandleAddKanji(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	kanjiID, err := strconv.Atoi(r.FormValue("kanji_id"))
+	if err != nil || kanjiID <= 0 {
+		http.Error(w, "Invalid kanji ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.db.AddKanjiToSR(userID, kanjiID)
+	if errors.Is(err, database.ErrLocked) {
+		http.Error(w, "Kanji is locked: "+err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to add kanji: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Check if this is an HTMX request
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<span class="learn-status learned">✓ Added</span>`))
+		return
+	}
+
+	http.Redirect(w, r, r.Header.Get("Referer"), http.StatusSeeOther)
+}
+
+// HandleAnswerKanji handles English meaning answer submission for kanji study
+func (h *KanjiHandler) HandleAnswerKanji(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	answer := r.FormValue("answer")
+	if answer == "" {
+		http.Error(w, "Answer is required", http.StatusBadRequest)
+		return
+	}
+
+	srID, err := strconv.Atoi(r.FormValue("kanji-id"))
+	if err != nil {
+		http.Error(w, "Failed to parse kanji ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	kanji, ownerID, err := h.db.LookupKanjiBySRId(srID)
+	if err != nil {
+		http.Error(w, "Failed to lookup kanji by ID: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if ownerID != userID {
+		http.Error(w, "Unauthorized access to SR record", http.StatusForbidden)
+		return
+	}
+
+	// The answer has to be a whole meaning, not part of one
+	isCorrect := kanji.AcceptsMeaning(answer)
+
+	userSettings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		http.Error(w, "Failed to get user settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timeMs, err := strconv.Atoi(r.FormValue("time"))
+	if err != nil {
+		http.Error(w, "Failed to parse time: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	knowIt := timeMs < userSettings.SRTimeEnglish
+
+	returnURL := r.FormValue("return-url")
+	if returnURL == "" {
+		returnURL = "/study/kanji"
+	}
+
+	// If correct AND fast (knowIt), auto-rate as 5 and move to next kanji
+	if isCorrect && knowIt {
+		err = h.db.UpdateSRKanji(srID, 5)
+		if err != nil {
+			http.Error(w, "Failed to update SR kanji: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, returnURL, http.StatusSeeOther)
+		return
+	}
+
+	// Otherwise redirect to answer page for manual rating
+	redirectURL := fmt.Sprintf("/study/kanji/answer?sr_id=%d&correct=%t&answer=%s&return-url=%s",
+		srID, isCorrect, url.QueryEscape(answer), url.QueryEscape(returnURL))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// HandleSubmitKanjiRating handles the submission of a quality rating for kanji SR
+func (h *KanjiHandler) HandleSubmitKanjiRating(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srID, err := strconv.Atoi(r.FormValue("sr_id"))
+	if err != nil {
+		http.Error(w, "Invalid SR ID", http.StatusBadRequest)
+		return
+	}
+
+	_, ownerID, err := h.db.LookupKanjiBySRId(srID)
+	if err != nil {
+		http.Error(w, "SR record not found: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	if ownerID != userID {
+		http.Error(w, "Unauthorized access to SR record", http.StatusForbidden)
+		return
+	}
+
+	quality, err := strconv.Atoi(r.FormValue("quality"))
+	if err != nil {
+		http.Error(w, "Invalid quality rating", http.StatusBadRequest)
+		return
+	}
+
+	err = h.db.UpdateSRKanji(srID, quality)
+	if err != nil {
+		http.Error(w, "Failed to update SR kanji: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	returnURL := r.FormValue("return-url")
+	if returnURL == "" {
+		returnURL = "/study/kanji"
+	}
+
+	http.Redirect(w, r, returnURL, http.StatusSeeOther)
+}
+
+// HandleGetKanjiStrokes returns the stroke order paths for a kanji as JSON
+func (h *KanjiHandler) HandleGetKanjiStrokes(w http.ResponseWriter, r *http.Request) {
+	kanji := r.URL.Query().Get("kanji")
+	if kanji == "" {
+		http.Error(w, "Kanji parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	strokes, err := h.db.GetKanjiStrokes(kanji)
+	if err != nil {
+		http.Error(w, "Failed to get kanji strokes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if strokes == nil {
+		http.Error(w, "Kanji not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(strokes)
+}
+
+// HandwritingRequest is the body posted by the handwriting quiz
+type HandwritingRequest struct {
+	SRID    int                  `json:"sr_id"`
+	Strokes []handwriting.Stroke `json:"strokes"` // In 109x109 KanjiVG coordinates
+}
+
+// HandleCheckHandwriting grades a hand-drawn kanji against its reference stroke data
+// The returned grade is submitted to /study/kanji/rate like any other kanji answer
+func (h *KanjiHandler) HandleCheckHandwriting(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var req HandwritingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	kanji, ownerID, err := h.db.LookupKanjiBySRId(req.SRID)
+	if err != nil {
+		http.Error(w, "SR record not found: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	if ownerID != userID {
+		http.Error(w, "Unauthorized access to SR record", http.StatusForbidden)
+		return
+	}
+
+	strokes, err := h.db.GetKanjiStrokes(kanji.Character)
+	if err != nil {
+		http.Error(w, "Failed to get kanji strokes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if strokes == nil || len(strokes.Strokes) == 0 {
+		http.Error(w, "No stroke data for this kanji", http.StatusNotFound)
+		return
+	}
+
+	reference := make([]handwriting.Stroke, 0, len(strokes.Strokes))
+	for _, path := range strokes.Strokes {
+		stroke, err := handwriting.ParsePath(path)
+		if err != nil {
+			http.Error(w, "Failed to parse stroke data: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reference = append(reference, stroke)
+	}
+
+	result := handwriting.Grade(reference, req.Strokes)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -157,17 +157,15 @@ func (h *KanjiConfusionHandler) HandleLinkKanji(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Extract first kanji from each word
+	// Extract first kanji from each word, unless the caller says which kanji is confused
 	kanji1 := extractFirstKanji(currentWord.Word)
+	if kanji := r.FormValue("kanji"); kanji != "" {
+		kanji1 = kanji
+	}
 	kanji2 := similarKanji
 
 	// Insert confusion pair
-	insertQuery := `
-		INSERT INTO kanji_confusion (kanji_1, kanji_2, word1_id, word2_id, user_id)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT DO NOTHING
-	`
-	_, err = h.db.DB.Exec(insertQuery, kanji1, kanji2, wordID, similarWordID, userID)
+	err = h.db.LinkConfusionPair(userID, wordID, similarWordID, kanji1, kanji2, "")
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -185,16 +183,7 @@ func (h *KanjiConfusionHandler) HandleLinkKanji(w http.ResponseWriter, r *http.R
 
 // Helper function to extract kanji from a string
 func extractKanji(s string) []rune {
-	var kanji []rune
-	for _, r := range s {
-		// Kanji range: U+4E00 to U+9FFF, with some extensions
-		if (r >= 0x4E00 && r <= 0x9FFF) ||
-			(r >= 0x3400 && r <= 0x4DBF) || // CJK Extension A
-			(r >= 0x20000 && r <= 0x2A6DF) { // CJK Extension B (rare)
-			kanji = append(kanji, r)
-		}
-	}
-	return kanji
+	return database.ExtractKanji(s)
 }
 
 // Helper function to extract first kanji
@@ -224,6 +213,7 @@ func (h *KanjiConfusionHandler) findWordsWithSimilarKanji(kanji []rune, excludeW
 			WHERE id != $1
 			AND level = $2
 			AND word LIKE '%' || $3 || '%'
+			AND owner_id = 0
 			LIMIT 5
 		`
 		rows, err := h.db.DB.Query(query, excludeWordID, level, string(k))
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"gaijin/internal/auth"
 	"gaijin/internal/database"
 	"net/http"
@@ -66,6 +67,10 @@ func (h *LearnHandler) HandleAddWord(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.db.AddWordToSR(userID, wordID)
+	if errors.Is(err, database.ErrLocked) {
+		http.Error(w, "Word is locked: "+err.Error(), http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to add word: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -111,7 +116,8 @@ func (h *LearnHandler) HandleAddWords(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.db.AddMultipleWordsToSR(userID, req.WordIDs)
+	// Words kanji progression keeps locked are skipped and counted separately
+	added, err := h.db.AddMultipleWordsToSR(userID, req.WordIDs)
 	if err != nil {
 		http.Error(w, "Failed to add words: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -121,7 +127,8 @@ func (h *LearnHandler) HandleAddWords(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
 		"message": "Words added to study deck",
-		"count":   len(req.WordIDs),
+		"count":   added,
+		"locked":  len(req.WordIDs) - added,
 	})
 }
 
@@ -168,16 +175,16 @@ func (h *LearnHandler) HandleAddAllOnPage(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Collect word IDs that aren't already learned
+	// Collect word IDs that aren't already learned (locked words wait for their kanji)
 	var wordIDs []int
 	for _, word := range words {
-		if !word.IsLearned {
+		if !word.IsLearned && !word.IsLocked {
 			wordIDs = append(wordIDs, word.ID)
 		}
 	}
 
 	if len(wordIDs) > 0 {
-		err = h.db.AddMultipleWordsToSR(userID, wordIDs)
+		_, err = h.db.AddMultipleWordsToSR(userID, wordIDs)
 		if err != nil {
 			http.Error(w, "Failed to add words: "+err.Error(), http.StatusInternalServerError)
 			return
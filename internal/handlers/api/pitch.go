@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"gaijin/internal/database"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HandleAnswerPitch checks the accent pattern picked on a pitch accent card
+func (h *StudyHandler) HandleAnswerPitch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	accent, err := strconv.Atoi(r.FormValue("answer"))
+	if err != nil {
+		http.Error(w, "Answer must be an accent position", http.StatusBadRequest)
+		return
+	}
+
+	srID, err := strconv.Atoi(r.FormValue("word-id"))
+	if err != nil {
+		http.Error(w, "Failed to parse word ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	owned, err := h.db.IsUserSRCard(userID, srID, database.SRTypePitch)
+	if err != nil {
+		http.Error(w, "Failed to check card: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !owned {
+		http.Error(w, "Pitch accent card not found", http.StatusNotFound)
+		return
+	}
+	word, err := h.db.LookupWordBySRId(srID)
+	if err != nil {
+		http.Error(w, "Failed to lookup word by ID: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Any of the reading's listed patterns counts (some words have more than one)
+	isCorrect := false
+	if reading := database.PitchReading(word.Readings); reading != nil {
+		for _, a := range reading.Accents {
+			if a == accent {
+				isCorrect = true
+				break
+			}
+		}
+	}
+
+	userSettings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		http.Error(w, "Failed to get user settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timeMs, err := strconv.Atoi(r.FormValue("time"))
+	if err != nil {
+		http.Error(w, "Failed to parse time: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	knowIt := timeMs < userSettings.SRTimeJapanese
+
+	returnURL := r.FormValue("return-url")
+	if returnURL == "" {
+		returnURL = "/study/pitch"
+	}
+
+	// If correct AND fast (knowIt), auto-rate as 5 and move to the next card
+	if isCorrect && knowIt {
+		err = h.db.UpdateSRWord(srID, 5)
+		if err != nil {
+			http.Error(w, "Failed to update SR: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		successURL := returnURL
+		if strings.Contains(returnURL, "?") {
+			successURL += "&success=true"
+		} else {
+			successURL += "?success=true"
+		}
+		http.Redirect(w, r, successURL, http.StatusSeeOther)
+		return
+	}
+
+	correctParam := "false"
+	if isCorrect {
+		correctParam = "true"
+	}
+	http.Redirect(w, r, fmt.Sprintf("/study/answer?sr_id=%d&type=pitch&correct=%s&answer=%d&return-url=%s", srID, correctParam, accent, returnURL), http.StatusSeeOther)
+}
+
+// HandleInitializePitch turns on pitch accent cards for every word in the user's deck
+func (h *StudyHandler) HandleInitializePitch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.db.InitializeUserSRPitch(userID); err != nil {
+		http.Error(w, "Failed to initialize pitch accent cards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/study/pitch", http.StatusSeeOther)
+}
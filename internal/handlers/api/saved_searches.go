@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"gaijin/internal/auth"
+	"gaijin/internal/database"
+	"gaijin/internal/searchquery"
+	"net/http"
+	"strconv"
+)
+
+type SavedSearchHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+func NewSavedSearchHandler(db *database.Database, auth *auth.Auth) *SavedSearchHandler {
+	return &SavedSearchHandler{db: db, auth: auth}
+}
+
+// redirectBack returns to the page the form was on, or to /learn where saved searches are listed
+func redirectBack(w http.ResponseWriter, r *http.Request) {
+	target := r.Header.Get("Referer")
+	if target == "" {
+		target = "/learn"
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// HandleSaveSearch pins a search with its filters (form fields q, level, pos and learned)
+func (h *SavedSearchHandler) HandleSaveSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.FormValue("q")
+	if query == "" {
+		http.Error(w, "q is required", http.StatusBadRequest)
+		return
+	}
+	// A saved search is run again later, so it has to parse now
+	var syntaxErr *searchquery.Error
+	if _, err := searchquery.Parse(query); errors.As(err, &syntaxErr) {
+		http.Error(w, "Invalid query: "+syntaxErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := database.SearchOptions{
+		Level:   r.FormValue("level"),
+		POS:     r.FormValue("pos"),
+		Learned: r.FormValue("learned"),
+	}
+	if _, err := h.db.SaveSearch(userID, query, opts); err != nil {
+		http.Error(w, "Failed to save search: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	redirectBack(w, r)
+}
+
+// HandleDeleteSavedSearch unpins a saved search (form field id)
+func (h *SavedSearchHandler) HandleDeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteSavedSearch(userID, id); err != nil {
+		http.Error(w, "Failed to delete saved search: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	redirectBack(w, r)
+}
+
+// HandleAddSavedSearchToDeck adds every word a saved search finds to the user's SR deck (form field id)
+// Words already in the deck are skipped
+func (h *SavedSearchHandler) HandleAddSavedSearchToDeck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.db.GetSavedSearch(userID, id)
+	if err != nil {
+		http.Error(w, "Failed to get saved search: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if saved == nil {
+		http.Error(w, "Saved search not found", http.StatusNotFound)
+		return
+	}
+
+	opts := saved.Options()
+	opts.Learned = "unlearned"
+	wordIDs, err := h.db.SearchWordIDs(userID, saved.Query, opts)
+	if err != nil {
+		http.Error(w, "Failed to search words: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(wordIDs) > 0 {
+		// Words kanji progression keeps locked are skipped until their kanji are learned
+		_, err = h.db.AddMultipleWordsToSR(userID, wordIDs)
+		if err != nil {
+			http.Error(w, "Failed to add words: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	redirectBack(w, r)
+}
@@ -69,6 +69,32 @@ func (h *SettingsHandler) HandleUpdateSettings(w http.ResponseWriter, r *http.Re
 	// Parse show_hiragana_mostly checkbox (if not checked, FormValue returns empty string)
 	showHiraganaMostly := r.FormValue("show_hiragana_mostly") == "on"
 
+	// Parse kanji progression settings (unlock stage defaults to 2 repetitions)
+	kanjiProgression := r.FormValue("kanji_progression") == "on"
+	unlockStage := 2
+	if stageStr := r.FormValue("unlock_stage"); stageStr != "" {
+		unlockStage, err = strconv.Atoi(stageStr)
+		if err != nil || unlockStage < 1 || unlockStage > 10 {
+			http.Error(w, "Invalid unlock_stage (must be 1-10)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Parse auto_link_confusions checkbox
+	autoLinkConfusions := r.FormValue("auto_link_confusions") == "on"
+
+	// Parse the frequency corpus (empty for the default list); it must have imported ranks
+	frequencyCorpus := r.FormValue("frequency_corpus")
+	hasCorpus, err := h.db.HasFrequencyCorpus(frequencyCorpus)
+	if err != nil {
+		http.Error(w, "Failed to check frequency corpus: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !hasCorpus {
+		http.Error(w, "Unknown frequency_corpus", http.StatusBadRequest)
+		return
+	}
+
 	// Update user settings
 	settings := &database.UserSettings{
 		UserID:             userID,
@@ -81,6 +107,10 @@ func (h *SettingsHandler) HandleUpdateSettings(w http.ResponseWriter, r *http.Re
 		Key4:               key4,
 		Key5:               key5,
 		ShowHiraganaMostly: showHiraganaMostly,
+		KanjiProgression:   kanjiProgression,
+		UnlockStage:        unlockStage,
+		AutoLinkConfusions: autoLinkConfusions,
+		FrequencyCorpus:    frequencyCorpus,
 	}
 
 	err = h.db.UpdateUserSettings(userID, settings)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gaijin/internal/auth"
 	"gaijin/internal/database"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -52,16 +53,8 @@ func (h *StudyHandler) HandleAnswerPronunciation(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Validate answer against furigana (hiragana reading)
-	// Support alternate readings separated by "/" (e.g., "まいげつ / まいつき")
-	answerTrimmed := strings.TrimSpace(answer)
-	isCorrect := false
-	for _, reading := range strings.Split(word.Furigana, "/") {
-		if answerTrimmed == strings.TrimSpace(reading) {
-			isCorrect = true
-			break
-		}
-	}
+	// Validate answer against the word's primary and alternate readings (e.g. まいげつ and まいつき)
+	isCorrect := word.AcceptsReading(answer)
 
 	userSettings, err := h.db.GetUserSettings(userID)
 	if err != nil {
@@ -77,6 +70,11 @@ func (h *StudyHandler) HandleAnswerPronunciation(w http.ResponseWriter, r *http.
 	}
 	knowIt := timeMs < userSettings.SRTimeJapanese
 
+	// Learn from wrong answers that belong to a look-alike word
+	if !isCorrect && userSettings.AutoLinkConfusions {
+		h.captureConfusion(userID, word, answer, "pronunciation")
+	}
+
 	// Get return URL (default to /study if not provided)
 	returnURL := r.FormValue("return-url")
 	if returnURL == "" {
@@ -172,6 +170,11 @@ func (h *StudyHandler) HandleAnswerMeaning(w http.ResponseWriter, r *http.Reques
 	}
 	knowIt := timeMs < userSettings.SRTimeEnglish
 
+	// Learn from wrong answers that belong to a look-alike word
+	if !isCorrect && userSettings.AutoLinkConfusions {
+		h.captureConfusion(userID, word, answer, "meaning")
+	}
+
 	// Get return URL (default to /study if not provided)
 	returnURL := r.FormValue("return-url")
 	if returnURL == "" {
@@ -205,6 +208,23 @@ func (h *StudyHandler) HandleAnswerMeaning(w http.ResponseWriter, r *http.Reques
 	http.Redirect(w, r, fmt.Sprintf("/study/answer?sr_id=%d&type=meaning&correct=%s&answer=%s&return-url=%s", srID, correctParam, answer, returnURL), http.StatusSeeOther)
 }
 
+// captureConfusion links the reviewed word with a look-alike word when the wrong answer belongs to it
+// Failures are logged rather than returned so they never block answering
+func (h *StudyHandler) captureConfusion(userID int, word *database.Word, answer string, answerType string) {
+	confused, err := h.db.FindConfusableWord(word, answer, answerType)
+	if err != nil {
+		log.Printf("Error checking for confusable word: %v", err)
+		return
+	}
+	if confused == nil {
+		return
+	}
+	err = h.db.LinkConfusionPair(userID, word.ID, confused.ID, confused.Kanji1, confused.Kanji2, "Auto-linked from wrong "+answerType+" answer")
+	if err != nil {
+		log.Printf("Error linking confusion pair: %v", err)
+	}
+}
+
 // HandleSubmitRating handles the manual quality rating submission (0-5)
 func (h *StudyHandler) HandleSubmitRating(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
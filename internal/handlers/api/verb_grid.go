@@ -0,0 +1,233 @@
+package api
+
+import (
+	"encoding/json"
+	"gaijin/internal/conjugation"
+	"net/http"
+	"strings"
+)
+
+// GridRequest is the verb grid's controls: the voice and aspect built onto the verb, then the
+// mood, tense and polarity of the result
+type GridRequest struct {
+	Verb       string `json:"verb"`
+	Potential  bool   `json:"potential"`
+	Passive    bool   `json:"passive"`
+	Causative  bool   `json:"causative"`
+	Continuous bool   `json:"continuous"`
+	Completion bool   `json:"completion"`
+	Resultant  bool   `json:"resultant"`
+	Past       bool   `json:"past"`
+	Negative   bool   `json:"negative"`
+	Mood       string `json:"mood"` // plain, te, volitional, conditional, desiderative, deontic or imperative
+}
+
+// GridResponse is the construction at each of the grid's formalities
+type GridResponse struct {
+	Valid    bool                        `json:"valid"`
+	Error    string                      `json:"error,omitempty"`
+	Verb     string                      `json:"verb"`
+	Reading  string                      `json:"reading,omitempty"`
+	VerbType string                      `json:"verbType,omitempty"`
+	Cells    map[string]ConjugationEntry `json:"cells"`
+}
+
+// gridFormalities are the grid's rows; casual and standard speech spell verbs the same, as do
+// polite and formal
+var gridFormalities = []struct {
+	name       string
+	politeness conjugation.Politeness
+}{
+	{"casual", conjugation.Plain},
+	{"standard", conjugation.Plain},
+	{"polite", conjugation.Polite},
+	{"formal", conjugation.Polite},
+}
+
+// HandleConjugateGrid conjugates a verb for the verb grid: the controls' construction, with its
+// reading and alternatives, at each formality
+func (h *VerbHandler) HandleConjugateGrid(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req GridRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fail := func(status int, message string) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(GridResponse{Valid: false, Error: message})
+	}
+
+	verb := strings.TrimSpace(req.Verb)
+	word, errMsg, status := h.checkWord(verb)
+	if errMsg != "" {
+		fail(status, errMsg)
+		return
+	}
+	// The grid's constructions are for verbs; adjectives have their own chart
+	if class, ok := adjectiveClass(word); ok {
+		fail(http.StatusBadRequest, verb+" is an adjective ("+adjectiveTypeLabels[class]+"), not a verb")
+		return
+	}
+
+	v, err := conjugation.New(verb, verbReading(verb, word), verbClass(verb, word))
+	if err != nil {
+		fail(http.StatusOK, "Cannot conjugate verb: "+err.Error())
+		return
+	}
+	verbs, err := gridVerbs(v, req)
+	if err != nil {
+		fail(http.StatusOK, "Cannot conjugate verb: "+err.Error())
+		return
+	}
+
+	cells := make(map[string]ConjugationEntry)
+	for _, f := range gridFormalities {
+		c, err := gridConjugate(verbs, req, f.politeness)
+		if err != nil {
+			fail(http.StatusOK, "Cannot conjugate verb: "+err.Error())
+			return
+		}
+		cells[f.name] = newConjugationEntry(c, "")
+	}
+
+	json.NewEncoder(w).Encode(GridResponse{
+		Valid:    true,
+		Verb:     verb,
+		Reading:  v.Reading,
+		VerbType: verbTypeLabels[v.Class],
+		Cells:    cells,
+	})
+}
+
+// gridVerbs builds the voice and aspect onto the verb (食べる → 食べさせられている), returning the
+// verbs the mood is conjugated on: the construction, then its alternatives (てある for ている)
+func gridVerbs(v conjugation.Verb, req GridRequest) ([]conjugation.Verb, error) {
+	// Potential, passive and causative forms are ichidan verbs themselves
+	derive := func(v conjugation.Verb, form conjugation.Form) (conjugation.Verb, error) {
+		c := v.Conjugate(form, conjugation.Affirmative, conjugation.Plain)
+		return conjugation.New(c.Text, c.Reading, conjugation.Ichidan)
+	}
+	// Aspects add a verb after the て-form (食べて + いる)
+	after := func(v conjugation.Verb, aux string, class conjugation.Class) (conjugation.Verb, error) {
+		c := v.Conjugate(conjugation.Te, conjugation.Affirmative, conjugation.Plain)
+		reading := ""
+		if c.Reading != "" {
+			reading = c.Reading + aux
+		}
+		return conjugation.New(c.Text+aux, reading, class)
+	}
+
+	var err error
+	for _, voice := range []struct {
+		on   bool
+		form conjugation.Form
+	}{
+		{req.Causative, conjugation.Causative},
+		{req.Passive, conjugation.Passive},
+		{req.Potential, conjugation.Potential},
+	} {
+		if voice.on {
+			if v, err = derive(v, voice.form); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if req.Completion {
+		if v, err = after(v, "しまう", conjugation.Godan); err != nil {
+			return nil, err
+		}
+	}
+	if !req.Continuous && !req.Resultant {
+		return []conjugation.Verb{v}, nil
+	}
+	verbs := make([]conjugation.Verb, 0, 2)
+	progressive, err := after(v, "いる", conjugation.Ichidan)
+	if err != nil {
+		return nil, err
+	}
+	verbs = append(verbs, progressive)
+	// A resultant state is also てある, though not after てしまう
+	if req.Resultant && !req.Completion {
+		result, err := after(v, "ある", conjugation.GodanAru)
+		if err != nil {
+			return nil, err
+		}
+		verbs = append(verbs, result)
+	}
+	return verbs, nil
+}
+
+// gridConjugate conjugates the verbs in the request's mood, tense and polarity, the first as the
+// form and the rest as its alternatives
+func gridConjugate(verbs []conjugation.Verb, req GridRequest, politeness conjugation.Politeness) (conjugation.Conjugation, error) {
+	polarity := conjugation.Affirmative
+	if req.Negative {
+		polarity = conjugation.Negative
+	}
+
+	var result conjugation.Conjugation
+	for i, v := range verbs {
+		c, err := gridMood(v, req, polarity, politeness)
+		if err != nil {
+			return conjugation.Conjugation{}, err
+		}
+		if i == 0 {
+			result = c
+			continue
+		}
+		result.Alts = append(result.Alts, c.Text)
+		result.AltReadings = append(result.AltReadings, c.Reading)
+		result.Alts = append(result.Alts, c.Alts...)
+		result.AltReadings = append(result.AltReadings, c.AltReadings...)
+	}
+	return result, nil
+}
+
+// gridMood conjugates one verb in the request's mood
+// Desiderative たい forms conjugate as an i-adjective and deontic べき forms as a na-adjective,
+// so they can be past or negative too.
+func gridMood(v conjugation.Verb, req GridRequest, polarity conjugation.Polarity, politeness conjugation.Politeness) (conjugation.Conjugation, error) {
+	adjectiveForm := conjugation.AdjectiveNonPast
+	form := conjugation.NonPast
+	if req.Past {
+		adjectiveForm, form = conjugation.AdjectivePast, conjugation.Past
+	}
+
+	switch req.Mood {
+	case "te":
+		form = conjugation.Te
+	case "volitional":
+		form = conjugation.Volitional
+	case "imperative":
+		form = conjugation.Imperative
+	case "conditional":
+		form = conjugation.Conditional
+	case "desiderative":
+		c := v.Conjugate(conjugation.Desire, conjugation.Affirmative, conjugation.Plain)
+		a, err := conjugation.NewAdjective(c.Text, c.Reading, conjugation.IAdjective)
+		if err != nil {
+			return conjugation.Conjugation{}, err
+		}
+		return a.Conjugate(adjectiveForm, polarity, politeness), nil
+	case "deontic":
+		c := v.Conjugate(conjugation.NonPast, conjugation.Affirmative, conjugation.Plain)
+		reading := ""
+		if c.Reading != "" {
+			reading = c.Reading + "べき"
+		}
+		a, err := conjugation.NewAdjective(c.Text+"べき", reading, conjugation.NaAdjective)
+		if err != nil {
+			return conjugation.Conjugation{}, err
+		}
+		return a.Conjugate(adjectiveForm, polarity, politeness), nil
+	}
+	return v.Conjugate(form, polarity, politeness), nil
+}
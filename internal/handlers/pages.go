@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"gaijin/internal/database"
+	"gaijin/internal/kana"
+	"gaijin/internal/pitch"
+	"gaijin/internal/searchquery"
 	"html/template"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -42,6 +48,40 @@ type AnswerData struct {
 	Key3        string // keyboard shortcut for rating 3
 	Key4        string // keyboard shortcut for rating 4
 	Key5        string // keyboard shortcut for rating 5
+
+	ConfusedWith    *database.ConfusableWord // look-alike word the wrong answer belongs to, if any
+	ConfusionLinked bool                     // whether ConfusedWith is already in the user's kanji_confusion pairs
+
+	Pitches     []pitch.Pattern // pitch accent patterns of the word's reading, if known
+	PitchAnswer *pitch.Pattern  // the pattern picked on a pitch accent card
+
+	Example *database.Sentence // example sentence using the most words the user knows
+
+	WordID int // for reporting a data problem with the word
+}
+
+// PitchStudyData holds data for the pitch accent quiz page
+type PitchStudyData struct {
+	Title            string
+	SRID             int
+	KanjiWord        string
+	Reading          string
+	Definitions      string
+	Options          []pitch.Pattern // every possible pattern for the reading
+	NoCards          bool            // When user has no pitch cards due for review
+	NeverInitialized bool            // True if user has never turned on pitch accent cards
+	ReturnURL        string
+}
+
+// ClozeStudyData holds data for the sentence cloze page
+type ClozeStudyData struct {
+	Title            string
+	SRID             int
+	Sentence         *database.Sentence // Match is the blank
+	Hint             string             // the word's definitions
+	NoCards          bool               // When user has no cloze cards due for review
+	NeverInitialized bool               // True if user has never turned on cloze cards
+	ReturnURL        string
 }
 
 type VisualConfusionData struct {
@@ -90,6 +130,35 @@ type KanaAnswerData struct {
 	Key5       string
 }
 
+// KanjiStudyData holds data for the kanji study page
+type KanjiStudyData struct {
+	Title            string
+	SRKanjiID        int
+	Character        string
+	NoKanji          bool // When user has no kanji due for review
+	NeverInitialized bool // True if user has never added kanji to their deck
+	ReturnURL        string
+}
+
+// KanjiAnswerData holds data for the kanji answer/rating page
+type KanjiAnswerData struct {
+	Title      string
+	SRID       int
+	Character  string
+	Meanings   string
+	Onyomi     string
+	Kunyomi    string
+	IsCorrect  bool
+	UserAnswer string
+	ReturnURL  string
+	Key0       string
+	Key1       string
+	Key2       string
+	Key3       string
+	Key4       string
+	Key5       string
+}
+
 // MAYBE rename dashboard
 func (h *PageHandler) HandleHome(w http.ResponseWriter, r *http.Request) {
 	// Parse both the base layout and the page content
@@ -118,6 +187,9 @@ type ProfileData struct {
 	UserInfo     *database.UserInfo
 	UserSettings *database.UserSettings
 	Success      bool // for showing success message after saving
+	IsAdmin      bool // links to the correction queue
+
+	FrequencyCorpora []database.FrequencyCorpus // corpora the Learn page can be ordered by
 }
 
 func (h *PageHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
@@ -142,6 +214,12 @@ func (h *PageHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	corpora, err := h.db.GetFrequencyCorpora()
+	if err != nil {
+		http.Error(w, "Failed to get frequency corpora: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	tmpl, err := template.ParseFiles(
 		"templates/layout/base.html",
 		"templates/pages/profile.html",
@@ -159,6 +237,9 @@ func (h *PageHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 		UserInfo:     userInfo,
 		UserSettings: userSettings,
 		Success:      success,
+		IsAdmin:      h.auth.IsAdmin(r),
+
+		FrequencyCorpora: corpora,
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
@@ -178,7 +259,7 @@ func (h *PageHandler) HandleStudy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the next word to study
-	srWord, err := h.db.GetNextSRWord(userID)
+	srWord, err := h.db.GetNextSRWord(userID, nil)
 	if err != nil {
 		http.Error(w, "Failed to get study word: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -312,6 +393,80 @@ func (h *PageHandler) HandleStudyAdverbs(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// HandleStudySavedSearch studies the words a saved search finds as their own deck
+// Only words already in the SR deck are due; "Add all to deck" on the Learn page adds the rest
+func (h *PageHandler) HandleStudySavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+	saved, err := h.db.GetSavedSearch(userID, id)
+	if err != nil {
+		http.Error(w, "Failed to get saved search: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if saved == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	wordIDs, err := h.db.SearchWordIDs(userID, saved.Query, saved.Options())
+	if err != nil {
+		http.Error(w, "Failed to search words: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// A search that finds nothing leaves nothing to study, rather than the whole deck
+	var srWord *database.SRWord
+	if len(wordIDs) > 0 {
+		srWord, err = h.db.GetNextSRWord(userID, wordIDs)
+		if err != nil {
+			http.Error(w, "Failed to get study word: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/study.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	studyData := StudyData{
+		Title:   "Study: " + saved.Query,
+		NoWords: srWord == nil,
+	}
+	if srWord != nil {
+		// Map SR type to study mode
+		studyData.StudyMode = "reading"
+		if srWord.Type == "english meaning" {
+			studyData.StudyMode = "meaning"
+		}
+		studyData.SRWordID = srWord.SRID
+		studyData.KanjiWord = srWord.Word.Word
+		studyData.Furigana = srWord.Word.Furigana
+		studyData.Romaji = srWord.Word.Romaji
+		studyData.Definitions = srWord.Word.Definitions
+		studyData.ReturnURL = "/study/saved/" + strconv.Itoa(saved.ID)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", studyData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // HandleStudyAnswer shows the answer page with the correct answer and rating options
 func (h *PageHandler) HandleStudyAnswer(w http.ResponseWriter, r *http.Request) {
 	// Get current user
@@ -334,9 +489,9 @@ func (h *PageHandler) HandleStudyAnswer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Get type (pronunciation or meaning)
+	// Get type (pronunciation, meaning, pitch or cloze)
 	studyType := r.URL.Query().Get("type")
-	if studyType != "pronunciation" && studyType != "meaning" {
+	if studyType != "pronunciation" && studyType != "meaning" && studyType != "pitch" && studyType != "cloze" {
 		http.Error(w, "Invalid type", http.StatusBadRequest)
 		return
 	}
@@ -381,6 +536,48 @@ func (h *PageHandler) HandleStudyAnswer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Pitch accent of the reading, shown with the answer
+	var pitches []pitch.Pattern
+	var pitchAnswer *pitch.Pattern
+	if reading := database.PitchReading(word.Readings); reading != nil {
+		pitches = reading.Pitches()
+		if accent, err := strconv.Atoi(userAnswer); err == nil && studyType == "pitch" {
+			picked := pitch.New(reading.Reading, accent)
+			pitchAnswer = &picked
+		}
+	}
+
+	// A cloze card shows the sentence it was asked with, other cards the best current example
+	var example *database.Sentence
+	sentenceID, parseErr := strconv.Atoi(r.URL.Query().Get("sentence_id"))
+	if studyType == "cloze" && parseErr == nil {
+		example, err = h.db.GetSentence(sentenceID, word.ID)
+	} else {
+		example, err = h.db.GetExampleSentence(userID, word.ID)
+	}
+	if err != nil {
+		http.Error(w, "Failed to get example sentence: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Check whether the wrong answer is the reading or meaning of a look-alike word
+	var confusedWith *database.ConfusableWord
+	confusionLinked := false
+	if !isCorrect && userAnswer != "" && studyType != "pitch" && studyType != "cloze" {
+		confusedWith, err = h.db.FindConfusableWord(word, userAnswer, studyType)
+		if err != nil {
+			http.Error(w, "Failed to check for confusable word: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if confusedWith != nil {
+			confusionLinked, err = h.db.IsConfusionLinked(userID, word.ID, confusedWith.ID)
+			if err != nil {
+				http.Error(w, "Failed to check confusion pair: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
 	tmpl, err := template.ParseFiles(
 		"templates/layout/base.html",
 		"templates/pages/answer.html",
@@ -406,11 +603,334 @@ func (h *PageHandler) HandleStudyAnswer(w http.ResponseWriter, r *http.Request)
 		Key3:        userSettings.Key3,
 		Key4:        userSettings.Key4,
 		Key5:        userSettings.Key5,
+
+		ConfusedWith:    confusedWith,
+		ConfusionLinked: confusionLinked,
+
+		Pitches:     pitches,
+		PitchAnswer: pitchAnswer,
+
+		Example: example,
+
+		WordID: word.ID,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = tmpl.ExecuteTemplate(w, "base", answerData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleStudyPitch shows the pitch accent quiz: pick the accent pattern of a word's reading
+func (h *PageHandler) HandleStudyPitch(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srWord, err := h.db.GetNextSRPitchWord(userID)
+	if err != nil {
+		http.Error(w, "Failed to get pitch accent card: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/study_pitch.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	studyData := PitchStudyData{Title: "Study Pitch Accent", ReturnURL: "/study/pitch"}
+	var reading *database.WordReading
+	if srWord != nil {
+		reading = database.PitchReading(srWord.Word.Readings)
+	}
+	if reading == nil {
+		// Nothing due, or the word has lost its pitch data since the card was added
+		hasPitch, err := h.db.HasUserSRPitch(userID)
+		if err != nil {
+			http.Error(w, "Database error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		studyData.NoCards = true
+		studyData.NeverInitialized = !hasPitch
+	} else {
+		studyData.SRID = srWord.SRID
+		studyData.KanjiWord = srWord.Word.Word
+		studyData.Reading = reading.Reading
+		studyData.Definitions = srWord.Word.Definitions
+		studyData.Options = pitch.Patterns(reading.Reading)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", studyData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleStudyCloze shows a sentence cloze card: an example sentence with the word blanked out
+func (h *PageHandler) HandleStudyCloze(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srWord, err := h.db.GetNextSRClozeWord(userID)
+	if err != nil {
+		http.Error(w, "Failed to get cloze card: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var sentence *database.Sentence
+	if srWord != nil {
+		sentence, err = h.db.GetExampleSentence(userID, srWord.Word.ID)
+		if err != nil {
+			http.Error(w, "Failed to get example sentence: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/study_cloze.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	studyData := ClozeStudyData{Title: "Study Sentences", ReturnURL: "/study/cloze"}
+	if sentence == nil {
+		hasCloze, err := h.db.HasUserSRCloze(userID)
+		if err != nil {
+			http.Error(w, "Database error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		studyData.NoCards = true
+		studyData.NeverInitialized = !hasCloze
+	} else {
+		studyData.SRID = srWord.SRID
+		studyData.Sentence = sentence
+		studyData.Hint = srWord.Word.Definitions
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", studyData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SuggestCorrectionData holds data for the page where users propose a fix to a word
+type SuggestCorrectionData struct {
+	Title     string
+	Word      *database.Word
+	Fields    []string          // correctable fields, in display order
+	Current   map[string]string // current value of each field
+	Submitted bool              // a correction was just queued
+	Error     string            // why the last proposal was refused
+	ReturnURL string
+}
+
+// HandleSuggestCorrection shows the form for flagging a word with a proposed correction
+func (h *PageHandler) HandleSuggestCorrection(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wordID, err := strconv.Atoi(r.URL.Query().Get("word_id"))
+	if err != nil {
+		http.Error(w, "Invalid word ID", http.StatusBadRequest)
+		return
+	}
+
+	visible, err := h.db.CanSeeWord(userID, wordID)
+	if err != nil {
+		http.Error(w, "Failed to get word: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	word, err := h.db.GetWordByID(wordID)
+	if err != nil {
+		http.Error(w, "Failed to get word: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if word == nil || !visible {
+		http.Error(w, "Word not found", http.StatusNotFound)
+		return
+	}
+
+	current, err := h.db.GetWordFieldValues(wordID)
+	if err != nil {
+		http.Error(w, "Failed to get word: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/suggest_correction.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pageData := SuggestCorrectionData{
+		Title:     "Suggest a Correction",
+		Word:      word,
+		Fields:    database.CorrectionFields,
+		Current:   current,
+		Submitted: r.URL.Query().Get("submitted") == "1",
+		Error:     r.URL.Query().Get("error"),
+		ReturnURL: r.URL.Query().Get("return-url"),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", pageData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// CorrectionDiff is a pending correction with its change split for highlighting
+type CorrectionDiff struct {
+	database.WordCorrection
+	Prefix  string // unchanged start
+	Removed string
+	Added   string
+	Suffix  string // unchanged end
+}
+
+// AdminCorrectionsData holds data for the correction moderation queue
+type AdminCorrectionsData struct {
+	Title       string
+	Corrections []CorrectionDiff
+	RecentEdits []database.WordEdit
+}
+
+// diffStrings splits a change into the common prefix and suffix and the differing middles
+func diffStrings(old string, new string) (prefix, removed, added, suffix string) {
+	a, b := []rune(old), []rune(new)
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA--
+		endB--
+	}
+	return string(a[:start]), string(a[start:endA]), string(b[start:endB]), string(a[endA:])
+}
+
+// HandleAdminCorrections shows pending corrections as diffs against the words' current values
+func (h *PageHandler) HandleAdminCorrections(w http.ResponseWriter, r *http.Request) {
+	corrections, err := h.db.GetPendingCorrections()
+	if err != nil {
+		http.Error(w, "Failed to get corrections: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	edits, err := h.db.GetWordEdits(0, 50)
+	if err != nil {
+		http.Error(w, "Failed to get edit history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/admin_corrections.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pageData := AdminCorrectionsData{Title: "Correction Queue", RecentEdits: edits}
+	for _, c := range corrections {
+		d := CorrectionDiff{WordCorrection: c}
+		d.Prefix, d.Removed, d.Added, d.Suffix = diffStrings(c.CurrentValue, c.ProposedValue)
+		pageData.Corrections = append(pageData.Corrections, d)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", pageData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// CustomWordsData holds data for the My Words page
+type CustomWordsData struct {
+	Title   string
+	Words   []database.CustomWord
+	Editing *database.CustomWord // the word being edited, nil when adding a new one
+	Message string
+	Error   string
+}
+
+// HandleCustomWords shows the user's private words and the form for adding or editing one
+func (h *PageHandler) HandleCustomWords(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	words, err := h.db.GetCustomWords(userID)
+	if err != nil {
+		http.Error(w, "Failed to get custom words: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	pageData := CustomWordsData{
+		Title: "My Words",
+		Words: words,
+		Error: query.Get("error"),
+	}
+	switch {
+	case query.Get("saved") == "1":
+		pageData.Message = "✓ Word saved"
+	case query.Get("deleted") == "1":
+		pageData.Message = "✓ Word deleted"
+	case query.Get("proposed") == "1":
+		pageData.Message = "✓ Thanks! Your word is waiting for review before it joins the dictionary."
+	}
+	if editID, err := strconv.Atoi(query.Get("edit")); err == nil {
+		for i := range words {
+			if words[i].ID == editID {
+				pageData.Editing = &words[i]
+				break
+			}
+		}
 	}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/custom_words.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	err = tmpl.ExecuteTemplate(w, "base", answerData)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = tmpl.ExecuteTemplate(w, "base", pageData)
 	if err != nil {
 		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -615,6 +1135,192 @@ func (h *PageHandler) HandleStudyKanaAnswer(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// HandleStudyKanji handles the kanji study page
+func (h *PageHandler) HandleStudyKanji(w http.ResponseWriter, r *http.Request) {
+	// Get current user
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srKanji, err := h.db.GetNextSRKanji(userID)
+	if err != nil {
+		http.Error(w, "Failed to get study kanji: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/study_kanji.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	studyData := KanjiStudyData{Title: "Study Kanji"}
+	if srKanji == nil {
+		hasKanji, err := h.db.HasUserSRKanji(userID)
+		if err != nil {
+			http.Error(w, "Database error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		studyData.NoKanji = true
+		studyData.NeverInitialized = !hasKanji
+	} else {
+		studyData.SRKanjiID = srKanji.SRID
+		studyData.Character = srKanji.Kanji.Character
+		studyData.ReturnURL = "/study/kanji"
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = tmpl.ExecuteTemplate(w, "base", studyData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// KanjiWritingData holds data for the kanji handwriting quiz page
+type KanjiWritingData struct {
+	Title            string
+	SRKanjiID        int
+	Character        string // Revealed after grading
+	Meanings         string
+	Onyomi           string
+	Kunyomi          string
+	StrokeCount      int
+	NoKanji          bool // When user has no kanji due for review
+	NeverInitialized bool // True if user has never added kanji to their deck
+	ReturnURL        string
+}
+
+// HandleStudyKanjiWriting shows the next due kanji as a handwriting card: the user sees its
+// meaning and readings and draws it
+func (h *PageHandler) HandleStudyKanjiWriting(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srKanji, err := h.db.GetNextSRKanji(userID)
+	if err != nil {
+		http.Error(w, "Failed to get study kanji: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/study_kanji_write.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writingData := KanjiWritingData{Title: "Write Kanji", ReturnURL: "/study/kanji/write"}
+	if srKanji == nil {
+		hasKanji, err := h.db.HasUserSRKanji(userID)
+		if err != nil {
+			http.Error(w, "Database error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writingData.NoKanji = true
+		writingData.NeverInitialized = !hasKanji
+	} else {
+		writingData.SRKanjiID = srKanji.SRID
+		writingData.Character = srKanji.Kanji.Character
+		writingData.Meanings = srKanji.Kanji.Meanings
+		writingData.Onyomi = srKanji.Kanji.Onyomi
+		writingData.Kunyomi = srKanji.Kanji.Kunyomi
+		writingData.StrokeCount = srKanji.Kanji.StrokeCount
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = tmpl.ExecuteTemplate(w, "base", writingData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleStudyKanjiAnswer shows the answer page for kanji with rating options
+func (h *PageHandler) HandleStudyKanjiAnswer(w http.ResponseWriter, r *http.Request) {
+	// Get current user
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	srID, err := strconv.Atoi(r.URL.Query().Get("sr_id"))
+	if err != nil {
+		http.Error(w, "Invalid SR ID", http.StatusBadRequest)
+		return
+	}
+
+	kanji, ownerID, err := h.db.LookupKanjiBySRId(srID)
+	if err != nil {
+		http.Error(w, "Failed to get kanji: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if ownerID != userID {
+		http.Error(w, "Unauthorized access to SR record", http.StatusForbidden)
+		return
+	}
+
+	returnURL := r.URL.Query().Get("return-url")
+	if returnURL == "" {
+		returnURL = "/study/kanji"
+	}
+
+	// Get user settings for keyboard shortcuts
+	userSettings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		http.Error(w, "Failed to get user settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/answer_kanji.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	answerData := KanjiAnswerData{
+		Title:      "Answer",
+		SRID:       srID,
+		Character:  kanji.Character,
+		Meanings:   kanji.Meanings,
+		Onyomi:     kanji.Onyomi,
+		Kunyomi:    kanji.Kunyomi,
+		IsCorrect:  r.URL.Query().Get("correct") == "true",
+		UserAnswer: r.URL.Query().Get("answer"),
+		ReturnURL:  returnURL,
+		Key0:       "0",
+		Key1:       userSettings.Key1,
+		Key2:       userSettings.Key2,
+		Key3:       userSettings.Key3,
+		Key4:       userSettings.Key4,
+		Key5:       userSettings.Key5,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = tmpl.ExecuteTemplate(w, "base", answerData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // HandleVisualConfusion shows a page for practicing visually similar kanji
 func (h *PageHandler) HandleVisualConfusion(w http.ResponseWriter, r *http.Request) {
 	// Get current user
@@ -699,6 +1405,21 @@ type LearnData struct {
 	ProgressPercent int         // Progress percentage for progress bar
 	PrevPage        int         // Previous page number
 	NextPage        int         // Next page number
+
+	// Kanji progression mode
+	KanjiProgression bool                  // Whether the user has kanji-first progression enabled
+	UnlockStage      int                   // SR repetitions a kanji needs to unlock words
+	UnlockedKanji    []database.LearnKanji // Kanji ready to be added to the deck
+	UpcomingKanji    []database.LearnKanji // Kanji still waiting on their components
+
+	RecentSearches []string          // for the search box's dropdown
+	SavedSearches  []SavedSearchLink // pinned searches, to run, add to the deck or study
+}
+
+// SavedSearchLink is a saved search on the Learn page
+type SavedSearchLink struct {
+	database.SavedSearch
+	URL string // the search page with the saved filters
 }
 
 // HandleLearn shows the Learn page where users can discover new words in batches
@@ -813,6 +1534,21 @@ func (h *PageHandler) HandleLearn(w http.ResponseWriter, r *http.Request) {
 		IsActive:     level == 0,
 	})
 
+	// In kanji progression mode, show which kanji can be learned next
+	userSettings, err := h.db.GetUserSettings(userID)
+	if err != nil {
+		http.Error(w, "Failed to get user settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var unlockedKanji, upcomingKanji []database.LearnKanji
+	if userSettings.KanjiProgression {
+		unlockedKanji, upcomingKanji, err = h.db.GetKanjiForLearning(userID, userSettings.UnlockStage, batchSize)
+		if err != nil {
+			http.Error(w, "Failed to get kanji: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Calculate progress percentage
 	progressPercent := 0
 	if totalWords > 0 {
@@ -828,6 +1564,21 @@ func (h *PageHandler) HandleLearn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recent, err := h.db.GetRecentSearches(userID, recentSearchesShown)
+	if err != nil {
+		http.Error(w, "Failed to get recent searches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	saved, err := h.db.GetSavedSearches(userID)
+	if err != nil {
+		http.Error(w, "Failed to get saved searches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	savedLinks := make([]SavedSearchLink, len(saved))
+	for i, s := range saved {
+		savedLinks[i] = SavedSearchLink{SavedSearch: s, URL: searchURL(s.Query, s.Options())}
+	}
+
 	learnData := LearnData{
 		Title:           "Learn",
 		Words:           words,
@@ -841,6 +1592,14 @@ func (h *PageHandler) HandleLearn(w http.ResponseWriter, r *http.Request) {
 		ProgressPercent: progressPercent,
 		PrevPage:        page - 1,
 		NextPage:        page + 1,
+
+		KanjiProgression: userSettings.KanjiProgression,
+		UnlockStage:      userSettings.UnlockStage,
+		UnlockedKanji:    unlockedKanji,
+		UpcomingKanji:    upcomingKanji,
+
+		RecentSearches: recent,
+		SavedSearches:  savedLinks,
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -869,6 +1628,12 @@ type KanjiLookupData struct {
 
 // HandleKanjiLookup shows all words containing a specific kanji
 func (h *PageHandler) HandleKanjiLookup(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
 	// Get the kanji from query parameter
 	kanji := r.URL.Query().Get("kanji")
 	if kanji == "" {
@@ -882,6 +1647,10 @@ func (h *PageHandler) HandleKanjiLookup(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Failed to search words: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if err := h.db.AttachExampleSentences(userID, words); err != nil {
+		http.Error(w, "Failed to get example sentences: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	// Group words by level
 	levelGroups := make(map[int][]database.KanjiWord)
@@ -926,18 +1695,125 @@ func (h *PageHandler) HandleKanjiLookup(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// KanjiCoverageData holds data for the kanji coverage report page
+type KanjiCoverageData struct {
+	Title    string
+	Coverage *database.KanjiCoverage
+}
+
+// HandleKanjiCoverage shows which kanji the user knows and which words they can now read
+func (h *PageHandler) HandleKanjiCoverage(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	coverage, err := h.db.GetKanjiCoverage(userID, 30)
+	if err != nil {
+		http.Error(w, "Failed to get kanji coverage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := template.ParseFiles(
+		"templates/layout/base.html",
+		"templates/pages/kanji_coverage.html",
+	)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	coverageData := KanjiCoverageData{
+		Title:    "Kanji Coverage",
+		Coverage: coverage,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = tmpl.ExecuteTemplate(w, "base", coverageData)
+	if err != nil {
+		http.Error(w, "Template execution error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // SearchResultsData holds data for the search results page
 type SearchResultsData struct {
-	Title        string
-	Query        string
-	SearchType   string // "japanese" or "english"
-	WordsByLevel []WordsByLevel
-	WordCount    int
-	NoResults    bool
+	Title   string
+	Query   string
+	Kana    string // the romaji query in hiragana, for romaji searches
+	Results *database.SearchResults
+
+	QueryError string // why a pattern query doesn't parse, e.g. "level:7: level must be 1 to 5"
+
+	// Facet links toggle one filter and keep the others
+	LevelFacets   []SearchFacetLink
+	POSFacets     []SearchFacetLink
+	LearnedFacets []SearchFacetLink
+	Filtered      bool   // whether any filter is on
+	ClearURL      string // the search without filters
+	PrevURL       string // empty on the first page
+	NextURL       string // empty on the last page
+
+	Options        database.SearchOptions // the filters that are on, for saving the search
+	SavedSearchID  int                    // the saved search this is, 0 if it isn't saved
+	RecentSearches []string               // for the search box's dropdown
+}
+
+// recentSearchesShown is how many recent searches the search box suggests
+const recentSearchesShown = 10
+
+// SearchFacetLink is a facet value on the search page
+type SearchFacetLink struct {
+	database.SearchFacet
+	URL    string
+	Active bool
+}
+
+// searchURL links to the search page for the query with these filters
+func searchURL(query string, opts database.SearchOptions) string {
+	values := url.Values{"q": {query}}
+	if opts.Level != "" {
+		values.Set("level", opts.Level)
+	}
+	if opts.POS != "" {
+		values.Set("pos", opts.POS)
+	}
+	if opts.Learned != "" {
+		values.Set("learned", opts.Learned)
+	}
+	if opts.Page > 1 {
+		values.Set("page", strconv.Itoa(opts.Page))
+	}
+	return "/search?" + values.Encode()
+}
+
+// searchFacetLinks links each facet value, turning it on, or off when it's the active one
+// Changing a filter goes back to the first page
+func searchFacetLinks(query string, opts database.SearchOptions, facets []database.SearchFacet, active string,
+	set func(opts *database.SearchOptions, value string)) []SearchFacetLink {
+	opts.Page = 1
+	links := make([]SearchFacetLink, len(facets))
+	for i, facet := range facets {
+		if facet.Value == active {
+			set(&opts, "")
+		} else {
+			set(&opts, facet.Value)
+		}
+		links[i] = SearchFacetLink{SearchFacet: facet, URL: searchURL(query, opts), Active: facet.Value == active}
+	}
+	return links
 }
 
 // HandleSearch shows search results for words
 func (h *PageHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.auth.GetCurrentUser(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
 	// Get the search query
 	query := r.URL.Query().Get("q")
 	if query == "" {
@@ -946,27 +1822,52 @@ func (h *PageHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	opts := database.SearchOptions{
+		Level:   r.URL.Query().Get("level"),
+		POS:     r.URL.Query().Get("pos"),
+		Learned: r.URL.Query().Get("learned"),
+		Page:    page,
+	}
+
 	// Search for words
-	words, searchType, err := h.db.SearchWords(query)
+	results, err := h.db.SearchWords(userID, query, opts)
+	queryError := ""
+	var syntaxErr *searchquery.Error
+	if errors.As(err, &syntaxErr) {
+		// Show the page with the mistake, so the query can be fixed in the search box
+		results = &database.SearchResults{Page: 1, PerPage: database.SearchPageSize}
+		queryError, err = syntaxErr.Error(), nil
+	}
 	if err != nil {
 		http.Error(w, "Failed to search words: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	// Group words by level
-	levelGroups := make(map[int][]database.KanjiWord)
-	for _, word := range words {
-		levelGroups[word.Level] = append(levelGroups[word.Level], word)
+	if err := h.db.AttachExampleSentences(userID, results.Words); err != nil {
+		http.Error(w, "Failed to get example sentences: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// Convert to ordered slice (N5 first, then N4, etc.)
-	var wordsByLevel []WordsByLevel
-	for _, lvl := range []int{5, 4, 3, 2, 1} {
-		if wordsAtLevel, ok := levelGroups[lvl]; ok {
-			wordsByLevel = append(wordsByLevel, WordsByLevel{
-				Level: lvl,
-				Words: wordsAtLevel,
-			})
+	// Remember the search, unless it didn't parse; losing it isn't worth failing the page over
+	if queryError == "" {
+		if err := h.db.RecordSearch(userID, query); err != nil {
+			log.Printf("Error recording search: %v", err)
+		}
+	}
+	recent, err := h.db.GetRecentSearches(userID, recentSearchesShown)
+	if err != nil {
+		http.Error(w, "Failed to get recent searches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	saved, err := h.db.GetSavedSearches(userID)
+	if err != nil {
+		http.Error(w, "Failed to get saved searches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	savedID := 0
+	for _, s := range saved {
+		if s.Query == query && s.Level == opts.Level && s.POS == opts.POS && s.Learned == opts.Learned {
+			savedID = s.ID
 		}
 	}
 
@@ -979,13 +1880,32 @@ func (h *PageHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	opts.Page = results.Page
 	searchData := SearchResultsData{
-		Title:        "Search: " + query,
-		Query:        query,
-		SearchType:   searchType,
-		WordsByLevel: wordsByLevel,
-		WordCount:    len(words),
-		NoResults:    len(words) == 0,
+		Title:      "Search: " + query,
+		Query:      query,
+		Kana:       kana.ToHiragana(query),
+		Results:    results,
+		QueryError: queryError,
+		LevelFacets: searchFacetLinks(query, opts, results.Levels, opts.Level,
+			func(o *database.SearchOptions, v string) { o.Level = v }),
+		POSFacets: searchFacetLinks(query, opts, results.POS, opts.POS,
+			func(o *database.SearchOptions, v string) { o.POS = v }),
+		LearnedFacets: searchFacetLinks(query, opts, results.Learned, opts.Learned,
+			func(o *database.SearchOptions, v string) { o.Learned = v }),
+		Filtered:       opts.Level != "" || opts.POS != "" || opts.Learned != "",
+		ClearURL:       searchURL(query, database.SearchOptions{}),
+		Options:        opts,
+		SavedSearchID:  savedID,
+		RecentSearches: recent,
+	}
+	if prev := results.PrevPage(); prev > 0 {
+		opts.Page = prev
+		searchData.PrevURL = searchURL(query, opts)
+	}
+	if next := results.NextPage(); next > 0 {
+		opts.Page = next
+		searchData.NextURL = searchURL(query, opts)
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
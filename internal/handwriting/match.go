@@ -0,0 +1,211 @@
+package handwriting
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// samplePoints is how many points each stroke is resampled to before comparing
+	samplePoints = 16
+	// goodDistance is the mean point distance (in 109x109 units) for a stroke to count as correct
+	goodDistance = 12.0
+	// roughDistance is the mean point distance for a stroke to get partial credit
+	roughDistance = 20.0
+)
+
+// Stroke feedback statuses
+const (
+	StatusGood      = "good"
+	StatusRough     = "rough"
+	StatusDirection = "direction"
+	StatusOrder     = "order"
+	StatusShape     = "shape"
+	StatusMissing   = "missing"
+	StatusExtra     = "extra"
+)
+
+// StrokeFeedback describes how well a single stroke matched the reference
+type StrokeFeedback struct {
+	Number  int    `json:"number"` // 1-based stroke number
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Result is the outcome of grading a drawn kanji
+type Result struct {
+	Grade           int              `json:"grade"`   // SR quality rating 0-5
+	Correct         bool             `json:"correct"` // Grade of 3 or more
+	ExpectedStrokes int              `json:"expected_strokes"`
+	DrawnStrokes    int              `json:"drawn_strokes"`
+	Strokes         []StrokeFeedback `json:"strokes"`
+}
+
+// Grade compares drawn strokes against the reference strokes, checking stroke count,
+// order, direction and rough shape. The drawing is scaled and centred onto the reference
+// first, so it doesn't need to fill the same box.
+func Grade(reference []Stroke, drawn []Stroke) Result {
+	result := Result{
+		ExpectedStrokes: len(reference),
+		DrawnStrokes:    len(drawn),
+	}
+	if len(reference) == 0 {
+		return result
+	}
+
+	ref := make([]Stroke, len(reference))
+	for i, s := range reference {
+		ref[i] = s.resample(samplePoints)
+	}
+	draw := make([]Stroke, 0, len(drawn))
+	for _, s := range alignTo(drawn, reference) {
+		if len(s) > 0 {
+			draw = append(draw, s.resample(samplePoints))
+		}
+	}
+
+	total := len(ref)
+	if len(draw) > total {
+		total = len(draw)
+	}
+
+	score := 0.0
+	for i := 0; i < total; i++ {
+		feedback := StrokeFeedback{Number: i + 1}
+		switch {
+		case i >= len(draw):
+			feedback.Status = StatusMissing
+			feedback.Message = "Stroke not drawn"
+		case i >= len(ref):
+			feedback.Status = StatusExtra
+			feedback.Message = fmt.Sprintf("Extra stroke (this kanji has %d)", len(ref))
+		default:
+			feedback.Status, feedback.Message = compareStroke(draw[i], ref, i)
+		}
+
+		switch feedback.Status {
+		case StatusGood:
+			score += 1
+		case StatusRough:
+			score += 0.5
+		}
+		result.Strokes = append(result.Strokes, feedback)
+	}
+
+	result.Grade = gradeFromScore(score / float64(total))
+	result.Correct = result.Grade >= 3
+	return result
+}
+
+// compareStroke checks drawn stroke i against reference stroke i, falling back to
+// checking direction and the other reference strokes to explain a mismatch
+func compareStroke(drawn Stroke, ref []Stroke, i int) (string, string) {
+	d := meanDistance(drawn, ref[i])
+	if d <= goodDistance {
+		return StatusGood, "Good"
+	}
+
+	if reversed := meanDistance(drawn.reversed(), ref[i]); reversed <= goodDistance && reversed < d {
+		return StatusDirection, "Drawn in the wrong direction"
+	}
+
+	for j := range ref {
+		if j != i && meanDistance(drawn, ref[j]) <= goodDistance {
+			return StatusOrder, fmt.Sprintf("This looks like stroke %d — check the stroke order", j+1)
+		}
+	}
+
+	if d <= roughDistance {
+		return StatusRough, "Close, but the shape is a little off"
+	}
+	return StatusShape, "Shape or position doesn't match"
+}
+
+// gradeFromScore maps the fraction of correct strokes onto an SR quality rating
+func gradeFromScore(fraction float64) int {
+	switch {
+	case fraction >= 1:
+		return 5
+	case fraction >= 0.85:
+		return 4
+	case fraction >= 0.7:
+		return 3
+	case fraction >= 0.5:
+		return 2
+	case fraction > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// alignTo scales and translates the drawn strokes so their bounding box is centred on
+// the reference bounding box, with the larger dimension matching
+func alignTo(drawn []Stroke, reference []Stroke) []Stroke {
+	dMin, dMax, ok := bounds(drawn)
+	if !ok {
+		return drawn
+	}
+	rMin, rMax, _ := bounds(reference)
+
+	dSize := math.Max(dMax.X-dMin.X, dMax.Y-dMin.Y)
+	rSize := math.Max(rMax.X-rMin.X, rMax.Y-rMin.Y)
+	scale := 1.0
+	if dSize > 0 {
+		scale = rSize / dSize
+	}
+	dCenter := Point{(dMin.X + dMax.X) / 2, (dMin.Y + dMax.Y) / 2}
+	rCenter := Point{(rMin.X + rMax.X) / 2, (rMin.Y + rMax.Y) / 2}
+
+	aligned := make([]Stroke, len(drawn))
+	for i, s := range drawn {
+		aligned[i] = make(Stroke, len(s))
+		for j, p := range s {
+			aligned[i][j] = Point{
+				X: rCenter.X + (p.X-dCenter.X)*scale,
+				Y: rCenter.Y + (p.Y-dCenter.Y)*scale,
+			}
+		}
+	}
+	return aligned
+}
+
+// bounds returns the bounding box of all points in the strokes
+func bounds(strokes []Stroke) (Point, Point, bool) {
+	min := Point{math.Inf(1), math.Inf(1)}
+	max := Point{math.Inf(-1), math.Inf(-1)}
+	found := false
+	for _, s := range strokes {
+		for _, p := range s {
+			min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+			max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+			found = true
+		}
+	}
+	return min, max, found
+}
+
+// meanDistance is the average distance between corresponding points of two resampled strokes
+func meanDistance(a, b Stroke) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if n == 0 {
+		return math.Inf(1)
+	}
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += distance(a[i], b[i])
+	}
+	return total / float64(n)
+}
+
+// reversed returns the stroke drawn from end to start
+func (s Stroke) reversed() Stroke {
+	r := make(Stroke, len(s))
+	for i, p := range s {
+		r[len(s)-1-i] = p
+	}
+	return r
+}
@@ -0,0 +1,292 @@
+// Package handwriting grades hand-drawn kanji against KanjiVG reference strokes
+package handwriting
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Point is a position in KanjiVG's 109x109 coordinate space
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Stroke is a single stroke as an ordered list of points
+type Stroke []Point
+
+// curveSegments is how many line segments each Bézier curve is flattened into
+const curveSegments = 8
+
+// ParsePath flattens SVG path data (as used by KanjiVG) into a list of points
+// Supports the M, L, H, V, C, S, Q, T and Z commands in absolute and relative form
+func ParsePath(d string) (Stroke, error) {
+	tokens, err := tokenizePath(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var points Stroke
+	var current, start, lastControl Point
+	var command byte
+	var prevCommand byte
+
+	i := 0
+	next := func() (float64, error) {
+		if i >= len(tokens) || tokens[i].isCommand {
+			return 0, fmt.Errorf("path %q: missing number after %c", d, command)
+		}
+		i++
+		return tokens[i-1].value, nil
+	}
+	nextPoint := func(relative bool) (Point, error) {
+		x, err := next()
+		if err != nil {
+			return Point{}, err
+		}
+		y, err := next()
+		if err != nil {
+			return Point{}, err
+		}
+		if relative {
+			return Point{current.X + x, current.Y + y}, nil
+		}
+		return Point{x, y}, nil
+	}
+
+	for i < len(tokens) {
+		if tokens[i].isCommand {
+			command = tokens[i].command
+			i++
+		} else if command == 0 {
+			return nil, fmt.Errorf("path %q: number before first command", d)
+		}
+		relative := command >= 'a' && command <= 'z'
+
+		switch command {
+		case 'M', 'm':
+			p, err := nextPoint(relative)
+			if err != nil {
+				return nil, err
+			}
+			current, start = p, p
+			points = append(points, p)
+			// Subsequent coordinate pairs are implicit line-to commands
+			if relative {
+				command = 'l'
+			} else {
+				command = 'L'
+			}
+		case 'L', 'l':
+			p, err := nextPoint(relative)
+			if err != nil {
+				return nil, err
+			}
+			current = p
+			points = append(points, p)
+		case 'H', 'h':
+			x, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if relative {
+				x += current.X
+			}
+			current = Point{x, current.Y}
+			points = append(points, current)
+		case 'V', 'v':
+			y, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if relative {
+				y += current.Y
+			}
+			current = Point{current.X, y}
+			points = append(points, current)
+		case 'C', 'c', 'S', 's':
+			var c1 Point
+			if command == 'S' || command == 's' {
+				// First control point is the reflection of the previous curve's second one
+				c1 = current
+				if prevCommand == 'C' || prevCommand == 'c' || prevCommand == 'S' || prevCommand == 's' {
+					c1 = Point{2*current.X - lastControl.X, 2*current.Y - lastControl.Y}
+				}
+			} else {
+				p, err := nextPoint(relative)
+				if err != nil {
+					return nil, err
+				}
+				c1 = p
+			}
+			c2, err := nextPoint(relative)
+			if err != nil {
+				return nil, err
+			}
+			end, err := nextPoint(relative)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, cubicBezier(current, c1, c2, end)...)
+			lastControl, current = c2, end
+		case 'Q', 'q', 'T', 't':
+			var c Point
+			if command == 'T' || command == 't' {
+				c = current
+				if prevCommand == 'Q' || prevCommand == 'q' || prevCommand == 'T' || prevCommand == 't' {
+					c = Point{2*current.X - lastControl.X, 2*current.Y - lastControl.Y}
+				}
+			} else {
+				p, err := nextPoint(relative)
+				if err != nil {
+					return nil, err
+				}
+				c = p
+			}
+			end, err := nextPoint(relative)
+			if err != nil {
+				return nil, err
+			}
+			// Elevate the quadratic curve to a cubic one
+			c1 := Point{current.X + 2.0/3.0*(c.X-current.X), current.Y + 2.0/3.0*(c.Y-current.Y)}
+			c2 := Point{end.X + 2.0/3.0*(c.X-end.X), end.Y + 2.0/3.0*(c.Y-end.Y)}
+			points = append(points, cubicBezier(current, c1, c2, end)...)
+			lastControl, current = c, end
+		case 'Z', 'z':
+			current = start
+			points = append(points, start)
+		default:
+			return nil, fmt.Errorf("path %q: unsupported command %c", d, command)
+		}
+		prevCommand = command
+	}
+
+	return points, nil
+}
+
+// cubicBezier samples a cubic Bézier curve, excluding its start point
+func cubicBezier(p0, p1, p2, p3 Point) Stroke {
+	points := make(Stroke, 0, curveSegments)
+	for step := 1; step <= curveSegments; step++ {
+		t := float64(step) / curveSegments
+		u := 1 - t
+		points = append(points, Point{
+			X: u*u*u*p0.X + 3*u*u*t*p1.X + 3*u*t*t*p2.X + t*t*t*p3.X,
+			Y: u*u*u*p0.Y + 3*u*u*t*p1.Y + 3*u*t*t*p2.Y + t*t*t*p3.Y,
+		})
+	}
+	return points
+}
+
+type pathToken struct {
+	isCommand bool
+	command   byte
+	value     float64
+}
+
+// tokenizePath splits SVG path data into commands and numbers
+// Numbers may be separated by spaces, commas, or just a sign ("1.5-2" is two numbers)
+func tokenizePath(d string) ([]pathToken, error) {
+	var tokens []pathToken
+	for i := 0; i < len(d); {
+		c := d[i]
+		switch {
+		case c == ' ' || c == ',' || c == '\n' || c == '\t' || c == '\r':
+			i++
+		case isPathCommand(c):
+			tokens = append(tokens, pathToken{isCommand: true, command: c})
+			i++
+		default:
+			j := i
+			if d[j] == '-' || d[j] == '+' {
+				j++
+			}
+			seenDot, seenExp := false, false
+			for j < len(d) {
+				ch := d[j]
+				if ch >= '0' && ch <= '9' {
+					j++
+				} else if ch == '.' && !seenDot && !seenExp {
+					seenDot = true
+					j++
+				} else if (ch == 'e' || ch == 'E') && !seenExp {
+					seenExp = true
+					j++
+					if j < len(d) && (d[j] == '-' || d[j] == '+') {
+						j++
+					}
+				} else {
+					break
+				}
+			}
+			value, err := strconv.ParseFloat(d[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: invalid number at offset %d", d, i)
+			}
+			tokens = append(tokens, pathToken{value: value})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func isPathCommand(c byte) bool {
+	switch c {
+	case 'M', 'm', 'L', 'l', 'H', 'h', 'V', 'v', 'C', 'c', 'S', 's', 'Q', 'q', 'T', 't', 'Z', 'z':
+		return true
+	}
+	return false
+}
+
+// length returns the total arc length of the stroke
+func (s Stroke) length() float64 {
+	total := 0.0
+	for i := 1; i < len(s); i++ {
+		total += distance(s[i-1], s[i])
+	}
+	return total
+}
+
+// resample returns n points spaced evenly along the stroke
+func (s Stroke) resample(n int) Stroke {
+	if len(s) == 0 {
+		return nil
+	}
+	total := s.length()
+	if total == 0 || len(s) == 1 {
+		result := make(Stroke, n)
+		for i := range result {
+			result[i] = s[0]
+		}
+		return result
+	}
+
+	interval := total / float64(n-1)
+	result := Stroke{s[0]}
+	accumulated := 0.0
+	prev := s[0]
+	for i := 1; i < len(s) && len(result) < n; {
+		d := distance(prev, s[i])
+		if accumulated+d >= interval && d > 0 {
+			t := (interval - accumulated) / d
+			p := Point{prev.X + t*(s[i].X-prev.X), prev.Y + t*(s[i].Y-prev.Y)}
+			result = append(result, p)
+			prev = p
+			accumulated = 0
+		} else {
+			accumulated += d
+			prev = s[i]
+			i++
+		}
+	}
+	// Floating point error can leave us one point short
+	for len(result) < n {
+		result = append(result, s[len(s)-1])
+	}
+	return result
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
@@ -0,0 +1,186 @@
+// Package jmdict streams JMdict dictionary files (the EDRDG XML release or the
+// JMdict-simplified JSON conversion) into a common Entry type
+package jmdict
+
+import "strings"
+
+// Entry is a single JMdict entry
+type Entry struct {
+	Seq      int       `json:"seq"`
+	Kanji    []Kanji   `json:"kanji,omitempty"` // Written forms
+	Readings []Reading `json:"readings"`
+	Senses   []Sense   `json:"senses"`
+}
+
+// Kanji is a written form of an entry (k_ele)
+type Kanji struct {
+	Text     string   `json:"text"`
+	Info     []string `json:"info,omitempty"`     // ke_inf tag codes, e.g. "ateji", "rK"
+	Priority []string `json:"priority,omitempty"` // ke_pri markers, e.g. "news1", "ichi1", "nf12"
+	Common   bool     `json:"common"`
+}
+
+// Reading is a kana reading of an entry (r_ele)
+type Reading struct {
+	Text     string   `json:"text"`
+	NoKanji  bool     `json:"no_kanji,omitempty"` // Not a true reading of the written forms
+	Restrict []string `json:"restrict,omitempty"` // Only applies to these written forms
+	Info     []string `json:"info,omitempty"`     // re_inf tag codes, e.g. "ok", "ik"
+	Priority []string `json:"priority,omitempty"`
+	Common   bool     `json:"common"`
+}
+
+// Sense is one meaning of an entry
+type Sense struct {
+	POS             []string `json:"pos"`                        // Part-of-speech tag codes, e.g. "v5k", "adj-i"
+	Misc            []string `json:"misc,omitempty"`             // e.g. "uk" (usually kana)
+	Field           []string `json:"field,omitempty"`            // e.g. "comp", "med"
+	Dialect         []string `json:"dialect,omitempty"`          // e.g. "ksb"
+	Info            []string `json:"info,omitempty"`             // Free-text sense notes
+	RestrictKanji   []string `json:"restrict_kanji,omitempty"`   // stagk
+	RestrictReading []string `json:"restrict_reading,omitempty"` // stagr
+	Glosses         []string `json:"glosses"`
+}
+
+// commonPriorities are the priority markers JMdict treats as "common" words
+var commonPriorities = map[string]bool{
+	"news1": true, "ichi1": true, "spec1": true, "spec2": true, "gai1": true,
+}
+
+// isCommon reports whether any marker makes the element common
+func isCommon(priority []string) bool {
+	for _, p := range priority {
+		if commonPriorities[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCommon reports whether any written form or reading of the entry is common
+func (e *Entry) IsCommon() bool {
+	for _, k := range e.Kanji {
+		if k.Common {
+			return true
+		}
+	}
+	for _, r := range e.Readings {
+		if r.Common {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWriting reports whether text is one of the entry's written forms
+func (e *Entry) HasWriting(text string) bool {
+	for _, k := range e.Kanji {
+		if k.Text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// HasReading reports whether text is one of the entry's readings
+func (e *Entry) HasReading(text string) bool {
+	for _, r := range e.Readings {
+		if r.Text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadingsFor returns the readings that apply to a written form, skipping outdated ones
+// For kana-only entries pass an empty string
+func (e *Entry) ReadingsFor(writing string) []string {
+	var readings []string
+	for _, r := range e.Readings {
+		if contains(r.Info, "ok") {
+			continue
+		}
+		if writing != "" {
+			if r.NoKanji || (len(r.Restrict) > 0 && !contains(r.Restrict, writing)) {
+				continue
+			}
+		}
+		readings = append(readings, r.Text)
+	}
+	return readings
+}
+
+// WritingsFor returns the written forms that a reading applies to, skipping rare and outdated ones
+// unless nothing else is left
+func (e *Entry) WritingsFor(reading string) []string {
+	var writings, rare []string
+	for _, r := range e.Readings {
+		if r.Text != reading || r.NoKanji {
+			continue
+		}
+		for _, k := range e.Kanji {
+			if len(r.Restrict) > 0 && !contains(r.Restrict, k.Text) {
+				continue
+			}
+			if contains(k.Info, "rK") || contains(k.Info, "oK") || contains(k.Info, "sK") {
+				rare = append(rare, k.Text)
+			} else {
+				writings = append(writings, k.Text)
+			}
+		}
+	}
+	if len(writings) == 0 {
+		return rare
+	}
+	return writings
+}
+
+// UsuallyKana reports whether any sense is marked as usually written in kana
+func (e *Entry) UsuallyKana() bool {
+	for _, s := range e.Senses {
+		if contains(s.Misc, "uk") {
+			return true
+		}
+	}
+	return false
+}
+
+// Glosses returns every gloss across all senses in order
+func (e *Entry) Glosses() []string {
+	var glosses []string
+	for _, s := range e.Senses {
+		glosses = append(glosses, s.Glosses...)
+	}
+	return glosses
+}
+
+// POS returns the distinct part-of-speech codes across all senses in order
+func (e *Entry) POS() []string {
+	var pos []string
+	for _, s := range e.Senses {
+		for _, p := range s.POS {
+			if !contains(pos, p) {
+				pos = append(pos, p)
+			}
+		}
+	}
+	return pos
+}
+
+// entityCode turns an unexpanded XML entity reference like "&v5k;" into its code "v5k"
+func entityCode(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "&") && strings.HasSuffix(s, ";") {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
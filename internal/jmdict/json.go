@@ -0,0 +1,138 @@
+package jmdict
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type jsonWord struct {
+	ID    string `json:"id"`
+	Kanji []struct {
+		Common bool     `json:"common"`
+		Text   string   `json:"text"`
+		Tags   []string `json:"tags"`
+	} `json:"kanji"`
+	Kana []struct {
+		Common         bool     `json:"common"`
+		Text           string   `json:"text"`
+		Tags           []string `json:"tags"`
+		AppliesToKanji []string `json:"appliesToKanji"`
+	} `json:"kana"`
+	Sense []struct {
+		PartOfSpeech   []string `json:"partOfSpeech"`
+		AppliesToKanji []string `json:"appliesToKanji"`
+		AppliesToKana  []string `json:"appliesToKana"`
+		Field          []string `json:"field"`
+		Dialect        []string `json:"dialect"`
+		Misc           []string `json:"misc"`
+		Info           []string `json:"info"`
+		Gloss          []struct {
+			Lang string `json:"lang"`
+			Text string `json:"text"`
+		} `json:"gloss"`
+	} `json:"sense"`
+}
+
+// ParseJSON streams a JMdict-simplified JSON file (github.com/scriptin/jmdict-simplified),
+// calling fn for each word with English glosses. It returns the file's tag descriptions.
+// JMdict-simplified only keeps a "common" flag, so Priority is left empty.
+func ParseJSON(r io.Reader, fn func(*Entry) error) (map[string]string, error) {
+	decoder := json.NewDecoder(r)
+	tags := make(map[string]string)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return tags, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return tags, err
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "tags":
+			if err := decoder.Decode(&tags); err != nil {
+				return tags, fmt.Errorf("failed to decode tags: %w", err)
+			}
+		case "words":
+			if err := expectDelim(decoder, '['); err != nil {
+				return tags, err
+			}
+			for decoder.More() {
+				var word jsonWord
+				if err := decoder.Decode(&word); err != nil {
+					return tags, fmt.Errorf("failed to decode word: %w", err)
+				}
+				if err := fn(word.toEntry()); err != nil {
+					return tags, err
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return tags, err
+			}
+		default:
+			// Skip metadata we don't use (version, dictDate, ...)
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return tags, err
+			}
+		}
+	}
+	return tags, nil
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, token)
+	}
+	return nil
+}
+
+func (w *jsonWord) toEntry() *Entry {
+	seq, _ := strconv.Atoi(w.ID)
+	entry := &Entry{Seq: seq}
+	for _, k := range w.Kanji {
+		entry.Kanji = append(entry.Kanji, Kanji{Text: k.Text, Info: k.Tags, Common: k.Common})
+	}
+	for _, r := range w.Kana {
+		reading := Reading{Text: r.Text, Info: r.Tags, Common: r.Common}
+		// "*" means all written forms; an empty list means none
+		if len(r.AppliesToKanji) == 0 && len(entry.Kanji) > 0 {
+			reading.NoKanji = true
+		} else if !contains(r.AppliesToKanji, "*") {
+			reading.Restrict = r.AppliesToKanji
+		}
+		entry.Readings = append(entry.Readings, reading)
+	}
+	for _, s := range w.Sense {
+		sense := Sense{
+			POS:     s.PartOfSpeech,
+			Misc:    s.Misc,
+			Field:   s.Field,
+			Dialect: s.Dialect,
+			Info:    s.Info,
+		}
+		if !contains(s.AppliesToKanji, "*") {
+			sense.RestrictKanji = s.AppliesToKanji
+		}
+		if !contains(s.AppliesToKana, "*") {
+			sense.RestrictReading = s.AppliesToKana
+		}
+		for _, g := range s.Gloss {
+			if g.Lang == "" || g.Lang == "eng" {
+				sense.Glosses = append(sense.Glosses, g.Text)
+			}
+		}
+		if len(sense.Glosses) > 0 {
+			entry.Senses = append(entry.Senses, sense)
+		}
+	}
+	return entry
+}
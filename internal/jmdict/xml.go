@@ -0,0 +1,136 @@
+package jmdict
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// entityPattern matches entity declarations in the JMdict DTD, e.g. <!ENTITY v5k "Godan verb with 'ku' ending">
+var entityPattern = regexp.MustCompile(`<!ENTITY\s+(\S+)\s+"([^"]*)">`)
+
+type xmlEntry struct {
+	Seq  int `xml:"ent_seq"`
+	KEle []struct {
+		Keb string   `xml:"keb"`
+		Inf []string `xml:"ke_inf"`
+		Pri []string `xml:"ke_pri"`
+	} `xml:"k_ele"`
+	REle []struct {
+		Reb     string    `xml:"reb"`
+		NoKanji *struct{} `xml:"re_nokanji"`
+		Restr   []string  `xml:"re_restr"`
+		Inf     []string  `xml:"re_inf"`
+		Pri     []string  `xml:"re_pri"`
+	} `xml:"r_ele"`
+	Sense []struct {
+		StagK []string `xml:"stagk"`
+		StagR []string `xml:"stagr"`
+		Pos   []string `xml:"pos"`
+		Field []string `xml:"field"`
+		Misc  []string `xml:"misc"`
+		Inf   []string `xml:"s_inf"`
+		Dial  []string `xml:"dial"`
+		Gloss []struct {
+			Lang string `xml:"lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+// ParseXML streams a JMdict XML file, calling fn for each entry with English glosses
+// It returns the tag descriptions declared in the DTD (e.g. "v5k" → "Godan verb with 'ku' ending")
+func ParseXML(r io.Reader, fn func(*Entry) error) (map[string]string, error) {
+	decoder := xml.NewDecoder(r)
+	// Non-strict mode leaves the DTD's entities (&v5k; etc.) unexpanded, which gives us the tag codes
+	decoder.Strict = false
+
+	tags := make(map[string]string)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return tags, err
+		}
+
+		switch t := token.(type) {
+		case xml.Directive:
+			for _, m := range entityPattern.FindAllStringSubmatch(string(t), -1) {
+				tags[m[1]] = m[2]
+			}
+		case xml.StartElement:
+			if t.Name.Local != "entry" {
+				continue
+			}
+			var raw xmlEntry
+			if err := decoder.DecodeElement(&raw, &t); err != nil {
+				return tags, fmt.Errorf("failed to decode entry: %w", err)
+			}
+			if err := fn(raw.toEntry()); err != nil {
+				return tags, err
+			}
+		}
+	}
+	return tags, nil
+}
+
+func (raw *xmlEntry) toEntry() *Entry {
+	entry := &Entry{Seq: raw.Seq}
+	for _, k := range raw.KEle {
+		entry.Kanji = append(entry.Kanji, Kanji{
+			Text:     k.Keb,
+			Info:     entityCodes(k.Inf),
+			Priority: k.Pri,
+			Common:   isCommon(k.Pri),
+		})
+	}
+	for _, r := range raw.REle {
+		entry.Readings = append(entry.Readings, Reading{
+			Text:     r.Reb,
+			NoKanji:  r.NoKanji != nil,
+			Restrict: r.Restr,
+			Info:     entityCodes(r.Inf),
+			Priority: r.Pri,
+			Common:   isCommon(r.Pri),
+		})
+	}
+
+	var lastPOS []string
+	for _, s := range raw.Sense {
+		sense := Sense{
+			POS:             entityCodes(s.Pos),
+			Misc:            entityCodes(s.Misc),
+			Field:           entityCodes(s.Field),
+			Dialect:         entityCodes(s.Dial),
+			Info:            s.Inf,
+			RestrictKanji:   s.StagK,
+			RestrictReading: s.StagR,
+		}
+		// A sense without <pos> inherits the previous sense's parts of speech
+		if len(sense.POS) == 0 {
+			sense.POS = lastPOS
+		}
+		lastPOS = sense.POS
+
+		for _, g := range s.Gloss {
+			if g.Lang == "" || g.Lang == "eng" {
+				sense.Glosses = append(sense.Glosses, g.Text)
+			}
+		}
+		if len(sense.Glosses) > 0 {
+			entry.Senses = append(entry.Senses, sense)
+		}
+	}
+	return entry
+}
+
+func entityCodes(values []string) []string {
+	codes := make([]string, len(values))
+	for i, v := range values {
+		codes[i] = entityCode(v)
+	}
+	return codes
+}
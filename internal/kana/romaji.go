@@ -0,0 +1,280 @@
+// Package kana converts romaji to hiragana and katakana, matching the rules in
+// static/js/romajiToHiragana.js so search understands what study inputs accept,
+// and kana back to Hepburn romaji.
+package kana
+
+import "strings"
+
+// romajiToHiragana is the syllable table, the same as romanjiToHiraganaMap in romajiToHiragana.js
+var romajiToHiragana = map[string]string{
+	// Vowels
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+
+	// K-line
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+
+	// S-line
+	"sa": "さ", "shi": "し", "si": "し", "su": "す", "se": "せ", "so": "そ",
+	"sha": "しゃ", "sya": "しゃ", "shya": "しゃ",
+	"shu": "しゅ", "syu": "しゅ", "shyu": "しゅ",
+	"sho": "しょ", "syo": "しょ", "shyo": "しょ",
+
+	// T-line
+	"ta": "た", "chi": "ち", "ti": "ち", "tsu": "つ", "tu": "つ", "te": "て", "to": "と",
+	"cha": "ちゃ", "tya": "ちゃ", "chya": "ちゃ",
+	"chu": "ちゅ", "tyu": "ちゅ", "chyu": "ちゅ",
+	"cho": "ちょ", "tyo": "ちょ", "chyo": "ちょ",
+
+	// N-line
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+
+	// H-line
+	"ha": "は", "hi": "ひ", "fu": "ふ", "hu": "ふ", "he": "へ", "ho": "ほ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+
+	// M-line
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+
+	// Y-line
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+
+	// R-line
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+
+	// W-line
+	"wa": "わ", "wi": "ゐ", "we": "ゑ", "wo": "を",
+
+	// N
+	"nn": "ん",
+
+	// G-line (dakuten)
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+
+	// Z-line (dakuten)
+	"za": "ざ", "ji": "じ", "zi": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"ja": "じゃ", "jya": "じゃ", "zya": "じゃ", "jia": "じゃ",
+	"ju": "じゅ", "jyu": "じゅ", "zyu": "じゅ", "jiu": "じゅ",
+	"jo": "じょ", "jyo": "じょ", "zyo": "じょ", "jio": "じょ",
+
+	// D-line (dakuten)
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+
+	// B-line (dakuten)
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+
+	// P-line (handakuten)
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+
+	// Additional combinations
+	"kwa": "くゎ", "gwa": "ぐゎ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+}
+
+// macrons spells out Hepburn long vowels, e.g. tōkyō
+var macrons = strings.NewReplacer("ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "ou", "â", "aa", "î", "ii", "û", "uu", "ê", "ee", "ô", "ou")
+
+const consonants = "bcdfghjklmnpqrstvwxyz"
+
+// ToHiragana converts romaji to hiragana the way the study inputs do, plus what a finished
+// word needs and live typing doesn't: n before a consonant or at the end is ん (hon, kanji, onna),
+// n' separates ん from a vowel (kin'en), m before b/p is ん (shimbun), tch is っち (matcha)
+// and - is the prolonged sound mark ー
+// Characters that aren't romaji are kept as they are
+func ToHiragana(romaji string) string {
+	s := []rune(macrons.Replace(strings.ToLower(romaji)))
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		next := rune(0)
+		if i+1 < len(s) {
+			next = s[i+1]
+		}
+
+		switch {
+		// Small tsu for double consonants, but not "nn" which becomes ん
+		case c == next && c != 'n' && strings.ContainsRune(consonants, c):
+			out.WriteString("っ")
+			i++
+			continue
+		case c == 't' && next == 'c':
+			out.WriteString("っ")
+			i++
+			continue
+		case c == 'n' && next == 'n' && i+2 < len(s) && strings.ContainsRune("aiueoy", s[i+2]):
+			// onna is おんな: the first n is ん and the second starts な
+			out.WriteString("ん")
+			i++
+			continue
+		case c == 'n' && next == '\'':
+			out.WriteString("ん")
+			i += 2
+			continue
+		case c == 'n' && next != 'n' && next != 'y' && !strings.ContainsRune("aiueo", next):
+			out.WriteString("ん")
+			i++
+			continue
+		case c == 'm' && (next == 'b' || next == 'p'):
+			out.WriteString("ん")
+			i++
+			continue
+		case c == '-':
+			out.WriteString("ー")
+			i++
+			continue
+		}
+
+		// Longest match first: 4 characters (shya), then 3, 2 and 1
+		matched := false
+		for n := 4; n >= 1 && !matched; n-- {
+			if i+n > len(s) {
+				continue
+			}
+			if kana, ok := romajiToHiragana[string(s[i:i+n])]; ok {
+				out.WriteString(kana)
+				i += n
+				matched = true
+			}
+		}
+
+		// If no match, keep original character
+		if !matched {
+			out.WriteRune(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// ToKatakana converts hiragana to katakana, leaving everything else as it is
+func ToKatakana(hiragana string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' || r == 'ゝ' || r == 'ゞ' {
+			return r + 'ァ' - 'ぁ'
+		}
+		return r
+	}, hiragana)
+}
+
+// FromRomaji converts a romaji word to hiragana and katakana
+// ok is false when the text isn't romaji: it has no letters, or letters are left over after
+// conversion, like the "t" of "eat"
+func FromRomaji(text string) (hiragana string, katakana string, ok bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", "", false
+	}
+	hiragana = ToHiragana(strings.ReplaceAll(text, " ", ""))
+	if strings.Trim(hiragana, "ー") == "" {
+		return "", "", false
+	}
+	for _, r := range hiragana {
+		if r < 'ぁ' || r > 'ゖ' && r != 'ー' {
+			return "", "", false
+		}
+	}
+	return hiragana, ToKatakana(hiragana), true
+}
+
+// hepburn picks one spelling for kana the syllable table spells more than one way
+var hepburn = map[string]string{
+	"し": "shi", "ち": "chi", "つ": "tsu", "ふ": "fu", "じ": "ji", "ん": "n",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo",
+}
+
+// hiraganaToRomaji is the syllable table the other way round, with Hepburn spellings
+var hiraganaToRomaji = func() map[string]string {
+	m := make(map[string]string, len(romajiToHiragana))
+	for romaji, kana := range romajiToHiragana {
+		m[kana] = romaji
+	}
+	for kana, romaji := range hepburn {
+		m[kana] = romaji
+	}
+	return m
+}()
+
+// ToRomaji converts kana to Hepburn romaji, the way ToHiragana reads it back:
+// っ doubles the next consonant (matte, matcha), ん is n' before a vowel or y (kin'en)
+// and ー is -
+// Characters that aren't kana are kept as they are
+func ToRomaji(text string) string {
+	s := []rune(katakanaToHiragana(text))
+	var out strings.Builder
+	doubled := false
+	for i := 0; i < len(s); {
+		if s[i] == 'っ' {
+			doubled = true
+			i++
+			continue
+		}
+
+		// Longest match first: a kana with its small ゃ, ゅ or ょ, then one kana
+		romaji := ""
+		for n := 2; n >= 1 && romaji == ""; n-- {
+			if i+n <= len(s) {
+				romaji = hiraganaToRomaji[string(s[i:i+n])]
+				if romaji != "" {
+					i += n
+				}
+			}
+		}
+		if romaji == "" {
+			if s[i] == 'ー' {
+				romaji = "-"
+			} else {
+				romaji = string(s[i])
+			}
+			i++
+		}
+
+		switch {
+		case doubled && strings.HasPrefix(romaji, "ch"):
+			out.WriteString("t")
+		case doubled && strings.ContainsRune(consonants, rune(romaji[0])):
+			out.WriteByte(romaji[0])
+		case doubled:
+			out.WriteString("っ")
+		}
+		doubled = false
+
+		out.WriteString(romaji)
+		if romaji == "n" && i < len(s) && strings.ContainsRune("あいうえおやゆよ", s[i]) {
+			out.WriteString("'")
+		}
+	}
+	if doubled {
+		out.WriteString("っ")
+	}
+	return out.String()
+}
+
+// katakanaToHiragana converts katakana to hiragana, leaving everything else as it is
+func katakanaToHiragana(katakana string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' || r == 'ヽ' || r == 'ヾ' {
+			return r - ('ァ' - 'ぁ')
+		}
+		return r
+	}, katakana)
+}
+
+// IsKana reports whether s is all hiragana or katakana (and ー), with at least one kana
+func IsKana(s string) bool {
+	for _, r := range s {
+		if !(r >= 'ぁ' && r <= 'ゖ' || r >= 'ァ' && r <= 'ヶ' || r == 'ー') {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,63 @@
+package kana
+
+import "testing"
+
+func TestToHiragana(t *testing.T) {
+	tests := []struct {
+		romaji, want string
+	}{
+		{"hon", "ほん"},
+		{"kanji", "かんじ"},
+		{"onna", "おんな"},
+		// An apostrophe keeps ん apart from the vowel after it
+		{"kin'en", "きんえん"},
+		{"kinen", "きねん"},
+		// Hepburn writes ん as m before b, m and p
+		{"shimbun", "しんぶん"},
+		{"shinbun", "しんぶん"},
+		{"matcha", "まっちゃ"},
+		// Romaji spells the particle は as it sounds
+		{"konnichiwa", "こんにちわ"},
+		{"konnichiha", "こんにちは"},
+		// A doubled n at the end is one ん
+		{"honn", "ほん"},
+		{"nn", "ん"},
+	}
+
+	for _, tt := range tests {
+		if got := ToHiragana(tt.romaji); got != tt.want {
+			t.Errorf("ToHiragana(%q) = %s, want %s", tt.romaji, got, tt.want)
+		}
+	}
+}
+
+func TestToRomaji(t *testing.T) {
+	tests := []struct {
+		kana, want string
+	}{
+		{"ほん", "hon"},
+		{"かんじ", "kanji"},
+		{"おんな", "onna"},
+		{"きんえん", "kin'en"},
+		{"キンエン", "kin'en"},
+		{"しんぶん", "shinbun"},
+		{"まっちゃ", "matcha"},
+		{"こんにちは", "konnichiha"},
+	}
+
+	for _, tt := range tests {
+		if got := ToRomaji(tt.kana); got != tt.want {
+			t.Errorf("ToRomaji(%s) = %q, want %q", tt.kana, got, tt.want)
+		}
+	}
+}
+
+// Romaji from ToRomaji reads back as the same hiragana
+func TestRomajiRoundTrip(t *testing.T) {
+	for _, hiragana := range []string{"ほん", "かんじ", "おんな", "きんえん", "きねん", "しんぶん", "まっちゃ", "こんにちは", "きって", "とうきょう"} {
+		romaji := ToRomaji(hiragana)
+		if got := ToHiragana(romaji); got != hiragana {
+			t.Errorf("ToHiragana(ToRomaji(%s)) = ToHiragana(%q) = %s", hiragana, romaji, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package pitch splits kana readings into morae and renders Tokyo-dialect pitch accent
+// patterns in downstep notation
+package pitch
+
+import (
+	"html"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// Downstep is the marker placed after the accented mora (e.g. はꜜし)
+const Downstep = "ꜜ"
+
+// Mora is one mora of a reading with its pitch
+type Mora struct {
+	Text string
+	High bool
+	Drop bool // Pitch falls after this mora
+}
+
+// Pattern is a reading with one accent position
+// Accent is the number of the mora after which the pitch drops: 0 is heiban (no drop)
+type Pattern struct {
+	Reading string
+	Accent  int
+	Morae   []Mora
+}
+
+// smallKana combine with the preceding kana into a single mora
+const smallKana = "ゃゅょぁぃぅぇぉゎャュョァィゥェォヮ"
+
+// Morae splits a kana reading into morae, e.g. "きょう" into "きょ", "う"
+// っ and ー count as morae of their own
+func Morae(reading string) []string {
+	var morae []string
+	for _, r := range reading {
+		if strings.ContainsRune(smallKana, r) && len(morae) > 0 {
+			morae[len(morae)-1] += string(r)
+			continue
+		}
+		morae = append(morae, string(r))
+	}
+	return morae
+}
+
+// New builds the pattern for a reading with the given accent position
+func New(reading string, accent int) Pattern {
+	p := Pattern{Reading: reading, Accent: accent}
+	for i, text := range Morae(reading) {
+		n := i + 1
+		var high bool
+		switch {
+		case accent == 1:
+			high = n == 1
+		case n == 1:
+			high = false
+		case accent == 0:
+			high = true
+		default:
+			high = n <= accent
+		}
+		p.Morae = append(p.Morae, Mora{Text: text, High: high, Drop: n == accent})
+	}
+	return p
+}
+
+// Patterns returns every possible pattern for a reading (heiban, then each downstep position)
+// Used for the pitch accent quiz choices
+func Patterns(reading string) []Pattern {
+	count := len(Morae(reading))
+	patterns := make([]Pattern, 0, count+1)
+	for accent := 0; accent <= count; accent++ {
+		patterns = append(patterns, New(reading, accent))
+	}
+	return patterns
+}
+
+// Name returns the pattern's traditional name
+func (p Pattern) Name() string {
+	switch {
+	case p.Accent == 0:
+		return "Heiban"
+	case p.Accent == 1:
+		return "Atamadaka"
+	case p.Accent >= len(p.Morae):
+		return "Odaka"
+	default:
+		return "Nakadaka"
+	}
+}
+
+// Notation returns the reading in downstep notation, e.g. "はꜜし" or "はしꜜ"
+// Heiban readings have no marker
+func (p Pattern) Notation() string {
+	var b strings.Builder
+	for _, m := range p.Morae {
+		b.WriteString(m.Text)
+		if m.Drop {
+			b.WriteString(Downstep)
+		}
+	}
+	return b.String()
+}
+
+// HTML renders the reading with a line over the high morae and a drop after the accented one
+func (p Pattern) HTML() template.HTML {
+	var b strings.Builder
+	b.WriteString(`<span class="pitch-accent" title="` + p.Name() + ` [` + strconv.Itoa(p.Accent) + `]" style="display: inline-block; white-space: nowrap;">`)
+	for _, m := range p.Morae {
+		style := "padding-top: 1px; border-top: 2px solid transparent;"
+		if m.High {
+			style = "padding-top: 1px; border-top: 2px solid #e17055;"
+		}
+		if m.Drop {
+			style += " border-right: 2px solid #e17055;"
+		}
+		b.WriteString(`<span style="` + style + `">` + html.EscapeString(m.Text) + `</span>`)
+	}
+	b.WriteString(`</span>`)
+	return template.HTML(b.String())
+}
+
+// ParseAccents parses an accent field like "0", "1,3" or "(名)0,(副)1" into accent positions
+func ParseAccents(field string) []int {
+	var accents []int
+	for _, part := range strings.Split(field, ",") {
+		// Drop part-of-speech prefixes like "(名)"
+		if i := strings.LastIndex(part, ")"); i >= 0 {
+			part = part[i+1:]
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 {
+			continue
+		}
+		seen := false
+		for _, a := range accents {
+			if a == n {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			accents = append(accents, n)
+		}
+	}
+	return accents
+}
@@ -25,6 +25,11 @@ type Router struct {
 	kanjiConfusionHandler *api.KanjiConfusionHandler
 	kanaHandler           *api.KanaHandler
 	learnHandler          *api.LearnHandler
+	kanjiHandler          *api.KanjiHandler
+	correctionHandler     *api.CorrectionHandler
+	customWordHandler     *api.CustomWordHandler
+	dictionaryHandler     *api.DictionaryHandler
+	savedSearchHandler    *api.SavedSearchHandler
 }
 
 func New(db *database.Database) *Router {
@@ -42,6 +47,11 @@ func New(db *database.Database) *Router {
 		kanjiConfusionHandler: api.NewKanjiConfusionHandler(db, authService),
 		kanaHandler:           api.NewKanaHandler(db, authService),
 		learnHandler:          api.NewLearnHandler(db, authService),
+		kanjiHandler:          api.NewKanjiHandler(db, authService),
+		correctionHandler:     api.NewCorrectionHandler(db, authService),
+		customWordHandler:     api.NewCustomWordHandler(db, authService),
+		dictionaryHandler:     api.NewDictionaryHandler(db, authService),
+		savedSearchHandler:    api.NewSavedSearchHandler(db, authService),
 	}
 }
 
@@ -113,12 +123,34 @@ func (r *Router) SetupRoutes() {
 	r.Mux.HandleFunc("/about", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleAbout)))
 	r.Mux.HandleFunc("/learn", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleLearn)))
 	r.Mux.HandleFunc("/kanji", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleKanjiLookup)))
+	r.Mux.HandleFunc("/kanji/coverage", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleKanjiCoverage)))
 	r.Mux.HandleFunc("/search", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleSearch)))
+	r.Mux.HandleFunc("/study/saved/{id}", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudySavedSearch)))
 
 	// Study routes
 	r.Mux.HandleFunc("/answer/pronunciation", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleAnswerPronunciation)))
 	r.Mux.HandleFunc("/answer/meaning", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleAnswerMeaning)))
 	r.Mux.HandleFunc("/study/answer", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyAnswer)))
+	r.Mux.HandleFunc("/study/pitch", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyPitch)))
+	r.Mux.HandleFunc("/answer/pitch", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleAnswerPitch)))
+	r.Mux.HandleFunc("/api/pitch/initialize", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleInitializePitch)))
+	r.Mux.HandleFunc("/study/cloze", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyCloze)))
+	r.Mux.HandleFunc("/answer/cloze", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleAnswerCloze)))
+	r.Mux.HandleFunc("/api/cloze/initialize", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleInitializeCloze)))
+
+	// Dictionary corrections
+	r.Mux.HandleFunc("/corrections/new", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleSuggestCorrection)))
+	r.Mux.HandleFunc("/api/corrections", r.logger.Middleware(r.auth.Middleware(r.correctionHandler.HandleProposeCorrection)))
+	r.Mux.HandleFunc("/admin/corrections", r.logger.Middleware(r.auth.Middleware(r.auth.AdminMiddleware(r.pageHandler.HandleAdminCorrections))))
+	r.Mux.HandleFunc("/api/admin/corrections/apply", r.logger.Middleware(r.auth.Middleware(r.auth.AdminMiddleware(r.correctionHandler.HandleApplyCorrection))))
+	r.Mux.HandleFunc("/api/admin/corrections/reject", r.logger.Middleware(r.auth.Middleware(r.auth.AdminMiddleware(r.correctionHandler.HandleRejectCorrection))))
+
+	// Custom words
+	r.Mux.HandleFunc("/words/custom", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleCustomWords)))
+	r.Mux.HandleFunc("/api/custom-words", r.logger.Middleware(r.auth.Middleware(r.customWordHandler.HandleSaveCustomWord)))
+	r.Mux.HandleFunc("/api/custom-words/delete", r.logger.Middleware(r.auth.Middleware(r.customWordHandler.HandleDeleteCustomWord)))
+	r.Mux.HandleFunc("/api/custom-words/promote", r.logger.Middleware(r.auth.Middleware(r.customWordHandler.HandlePromoteCustomWord)))
+
 	r.Mux.HandleFunc("/study/rate", r.logger.Middleware(r.auth.Middleware(r.studyHandler.HandleSubmitRating)))
 
 	// Kana study routes (beginners deck)
@@ -129,6 +161,16 @@ func (r *Router) SetupRoutes() {
 	r.Mux.HandleFunc("/study/kana/rate", r.logger.Middleware(r.auth.Middleware(r.kanaHandler.HandleSubmitKanaRating)))
 	r.Mux.HandleFunc("/api/kana/initialize", r.logger.Middleware(r.auth.Middleware(r.kanaHandler.HandleInitializeKana)))
 
+	// Kanji study routes (kanji-first progression)
+	r.Mux.HandleFunc("/study/kanji", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyKanji)))
+	r.Mux.HandleFunc("/answer/kanji", r.logger.Middleware(r.auth.Middleware(r.kanjiHandler.HandleAnswerKanji)))
+	r.Mux.HandleFunc("/study/kanji/answer", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyKanjiAnswer)))
+	r.Mux.HandleFunc("/study/kanji/rate", r.logger.Middleware(r.auth.Middleware(r.kanjiHandler.HandleSubmitKanjiRating)))
+	r.Mux.HandleFunc("/study/kanji/write", r.logger.Middleware(r.auth.Middleware(r.pageHandler.HandleStudyKanjiWriting)))
+	r.Mux.HandleFunc("/api/kanji/add", r.logger.Middleware(r.auth.Middleware(r.kanjiHandler.HandleAddKanji)))
+	r.Mux.HandleFunc("/api/kanji/strokes", r.logger.Middleware(r.auth.Middleware(r.kanjiHandler.HandleGetKanjiStrokes)))
+	r.Mux.HandleFunc("/api/kanji/handwriting", r.logger.Middleware(r.auth.Middleware(r.kanjiHandler.HandleCheckHandwriting)))
+
 	// Settings routes
 	r.Mux.HandleFunc("/api/settings", r.logger.Middleware(r.auth.Middleware(r.settingsHandler.HandleUpdateSettings)))
 
@@ -139,12 +181,22 @@ func (r *Router) SetupRoutes() {
 	r.Mux.HandleFunc("/api/learn/toggle-suspended", r.logger.Middleware(r.auth.Middleware(r.learnHandler.HandleToggleSuspended)))
 	r.Mux.HandleFunc("/api/learn/suspend-all", r.logger.Middleware(r.auth.Middleware(r.learnHandler.HandleSuspendAllOnPage)))
 
+	// Saved search routes
+	r.Mux.HandleFunc("/api/saved-searches", r.logger.Middleware(r.auth.Middleware(r.savedSearchHandler.HandleSaveSearch)))
+	r.Mux.HandleFunc("/api/saved-searches/delete", r.logger.Middleware(r.auth.Middleware(r.savedSearchHandler.HandleDeleteSavedSearch)))
+	r.Mux.HandleFunc("/api/saved-searches/add-to-deck", r.logger.Middleware(r.auth.Middleware(r.savedSearchHandler.HandleAddSavedSearchToDeck)))
+
 	// Kanji confusion routes
 	r.Mux.HandleFunc("/api/similar-kanji", r.logger.Middleware(r.auth.Middleware(r.kanjiConfusionHandler.HandleGetSimilarKanji)))
 	r.Mux.HandleFunc("/api/link-kanji", r.logger.Middleware(r.auth.Middleware(r.kanjiConfusionHandler.HandleLinkKanji)))
 
+	// Dictionary JSON API (answers 401 rather than redirecting to /login)
+	r.Mux.HandleFunc("/api/v1/search", r.logger.Middleware(r.auth.APIMiddleware(r.dictionaryHandler.HandleSearch)))
+	r.Mux.HandleFunc("/api/v1/words/{id}", r.logger.Middleware(r.auth.APIMiddleware(r.dictionaryHandler.HandleWord)))
+
 	// Verb conjugation routes (public - no auth required)
 	r.Mux.HandleFunc("/api/verb/conjugate", r.logger.Middleware(r.verbHandler.HandleConjugate))
+	r.Mux.HandleFunc("/api/verb/grid", r.logger.Middleware(r.verbHandler.HandleConjugateGrid))
 
 	// Static files - no logging for performance (optional)
 	r.Mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
@@ -0,0 +1,253 @@
+// Package searchquery parses what users type in the search box: words with ? and * wildcards,
+// "quoted phrases", and filters like reading:*かい, pos:adverb, level:3 and kanji:水.
+package searchquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Node is one part of a query: the text to search for, or a filter
+type Node interface {
+	node()
+}
+
+// Text is the free text of a query: a word, a reading, romaji or English, possibly with wildcards
+type Text struct {
+	Pattern Pattern
+}
+
+// Reading keeps words with a reading matching the pattern (reading:*かい)
+type Reading struct {
+	Pattern Pattern
+}
+
+// POS keeps words with a part of speech: a group such as "adverb", or a JMdict code such as "v5k" (pos:adverb)
+type POS struct {
+	Name string
+}
+
+// Level keeps words at a JLPT level (level:3 or level:n3)
+type Level struct {
+	Level int
+}
+
+// Kanji keeps words written with every one of these kanji (kanji:水)
+type Kanji struct {
+	Kanji string
+}
+
+func (Text) node()    {}
+func (Reading) node() {}
+func (POS) node()     {}
+func (Level) node()   {}
+func (Kanji) node()   {}
+
+// Query is a parsed search: at most one Text, then the filters in the order they were typed
+type Query struct {
+	Nodes []Node
+}
+
+// Text returns the query's free text, or "" if it only has filters
+func (q Query) Text() Pattern {
+	for _, n := range q.Nodes {
+		if t, ok := n.(Text); ok {
+			return t.Pattern
+		}
+	}
+	return ""
+}
+
+// Error is a query that doesn't parse, e.g. level:7
+type Error struct {
+	Term    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Term, e.Message)
+}
+
+// fullWidth maps what Japanese keyboards type to the ASCII syntax
+var fullWidth = strings.NewReplacer("？", "?", "＊", "*", "：", ":", "＂", `"`, "　", " ")
+
+// Parse parses a search box query
+// Words without a filter prefix are the free text, kept together: "to eat level:5" searches for
+// "to eat" at N5. Filter values can't contain spaces.
+// A quoted phrase is always text, so "pos:adverb" searches for those words rather than filtering.
+func Parse(input string) (Query, error) {
+	var q Query
+	var text []string
+	terms, err := split(fullWidth.Replace(input))
+	if err != nil {
+		return Query{}, err
+	}
+	for _, t := range terms {
+		term := t.text
+		name, value, found := strings.Cut(term, ":")
+		if t.quoted || !found || !isFilterName(name) {
+			text = append(text, term) // e.g. 10:30
+			continue
+		}
+		if value == "" {
+			return Query{}, &Error{Term: term, Message: "filter has no value"}
+		}
+
+		switch strings.ToLower(name) {
+		case "reading":
+			q.Nodes = append(q.Nodes, Reading{Pattern: Pattern(value)})
+		case "pos":
+			if !isPOSName(value) {
+				return Query{}, &Error{Term: term, Message: "unknown part of speech"}
+			}
+			q.Nodes = append(q.Nodes, POS{Name: strings.ToLower(value)})
+		case "level":
+			level, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "n"))
+			if err != nil || level < 1 || level > 5 {
+				return Query{}, &Error{Term: term, Message: "level must be 1 to 5"}
+			}
+			q.Nodes = append(q.Nodes, Level{Level: level})
+		case "kanji":
+			for _, r := range value {
+				if !unicode.Is(unicode.Han, r) {
+					return Query{}, &Error{Term: term, Message: fmt.Sprintf("%c isn't a kanji", r)}
+				}
+			}
+			q.Nodes = append(q.Nodes, Kanji{Kanji: value})
+		default:
+			return Query{}, &Error{Term: term, Message: "unknown filter, use reading:, pos:, level: or kanji:"}
+		}
+	}
+
+	if len(text) > 0 {
+		q.Nodes = append([]Node{Text{Pattern: Pattern(strings.Join(text, " "))}}, q.Nodes...)
+	}
+	return q, nil
+}
+
+// term is one space-separated word of a query, or a quoted phrase without its quotes
+type term struct {
+	text   string
+	quoted bool
+}
+
+// split splits a query into terms at spaces, keeping quoted phrases together
+// A quote only opens a phrase at the start of a term, so 5" is text.
+func split(input string) ([]term, error) {
+	var terms []term
+	s := input
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return terms, nil
+		}
+		if rest, ok := strings.CutPrefix(s, `"`); ok {
+			phrase, after, found := strings.Cut(rest, `"`)
+			if !found {
+				return nil, &Error{Term: s, Message: "quote isn't closed"}
+			}
+			phrase = strings.Join(strings.Fields(phrase), " ")
+			if phrase == "" {
+				return nil, &Error{Term: s[:len(s)-len(after)], Message: "quotes are empty"}
+			}
+			terms = append(terms, term{text: phrase, quoted: true})
+			s = after
+			continue
+		}
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		}
+		terms = append(terms, term{text: s[:end]})
+		s = s[end:]
+	}
+}
+
+// isFilterName reports whether s could be a filter name, so that a typo like lvl:3 is an
+// error rather than text
+func isFilterName(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isPOSName reports whether s looks like a part-of-speech group or JMdict code
+func isPOSName(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// Pattern is text where ? matches any one character and * any run of characters
+type Pattern string
+
+// HasWildcards reports whether the pattern has ? or *
+func (p Pattern) HasWildcards() bool {
+	return strings.ContainsAny(string(p), "?*")
+}
+
+// likeEscaper escapes LIKE's own wildcards and escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Like returns the pattern as a SQL LIKE pattern, with the default \ escape
+func (p Pattern) Like() string {
+	var b strings.Builder
+	for _, part := range p.split() {
+		switch part {
+		case "?":
+			b.WriteString("_")
+		case "*":
+			b.WriteString("%")
+		default:
+			b.WriteString(likeEscaper.Replace(part))
+		}
+	}
+	return b.String()
+}
+
+// MapLiterals converts the text between wildcards, e.g. romaji to hiragana
+// ok is false if convert fails on any part
+func (p Pattern) MapLiterals(convert func(string) (string, bool)) (Pattern, bool) {
+	var b strings.Builder
+	for _, part := range p.split() {
+		if part == "?" || part == "*" {
+			b.WriteString(part)
+			continue
+		}
+		converted, ok := convert(part)
+		if !ok {
+			return "", false
+		}
+		b.WriteString(converted)
+	}
+	return Pattern(b.String()), true
+}
+
+// split splits the pattern into wildcards and the literal text between them
+func (p Pattern) split() []string {
+	var parts []string
+	s := string(p)
+	for s != "" {
+		i := strings.IndexAny(s, "?*")
+		switch {
+		case i < 0:
+			parts = append(parts, s)
+			s = ""
+		case i > 0:
+			parts = append(parts, s[:i])
+			s = s[i:]
+		default:
+			parts = append(parts, s[:1])
+			s = s[1:]
+		}
+	}
+	return parts
+}
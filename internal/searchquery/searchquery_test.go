@@ -0,0 +1,129 @@
+package searchquery
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []Node
+	}{
+		{"食べる", []Node{Text{Pattern: "食べる"}}},
+		{"食べ*", []Node{Text{Pattern: "食べ*"}}},
+		{"to eat level:5", []Node{Text{Pattern: "to eat"}, Level{Level: 5}}},
+		{"reading:*かい pos:adverb", []Node{Reading{Pattern: "*かい"}, POS{Name: "adverb"}}},
+		{"level:N3 kanji:水火 た?", []Node{Text{Pattern: "た?"}, Level{Level: 3}, Kanji{Kanji: "水火"}}},
+		{"POS:V5K", []Node{POS{Name: "v5k"}}},
+		{"10:30", []Node{Text{Pattern: "10:30"}}},
+		// Full-width syntax from a Japanese keyboard
+		{"食べ＊　ｐos：adverb", []Node{Text{Pattern: "食べ* ｐos:adverb"}}},
+		{"食べ？　level：4", []Node{Text{Pattern: "食べ?"}, Level{Level: 4}}},
+		// Quoted phrases are text, even when they look like a filter
+		{`"re:zero" level:2`, []Node{Text{Pattern: "re:zero"}, Level{Level: 2}}},
+		{`"to   eat"  fast`, []Node{Text{Pattern: "to eat fast"}}},
+		{`"*かい"`, []Node{Text{Pattern: "*かい"}}},
+		{`5" screen`, []Node{Text{Pattern: `5" screen`}}},
+		{`＂pos:adverb＂`, []Node{Text{Pattern: "pos:adverb"}}},
+		// Empty queries have no nodes
+		{"", nil},
+		{"   　 ", nil},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(q.Nodes, tt.want) {
+			t.Errorf("Parse(%q) = %#v, want %#v", tt.input, q.Nodes, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input, term string
+	}{
+		{"level:7", "level:7"},
+		{"level:three", "level:three"},
+		{"lvl:3", "lvl:3"},
+		{"reading:", "reading:"},
+		{"kanji:水a", "kanji:水a"},
+		{"pos:ad;verb", "pos:ad;verb"},
+		{`"to eat`, `"to eat`},
+		{`level:3 "to eat`, `"to eat`},
+		{`"" 食べる`, `""`},
+		{`"  "`, `"  "`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *Error
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) error = %v, want an *Error", tt.input, err)
+			continue
+		}
+		if syntaxErr.Term != tt.term {
+			t.Errorf("Parse(%q) error term = %q, want %q", tt.input, syntaxErr.Term, tt.term)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	q, err := Parse("level:3 食べ*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Text(); got != "食べ*" {
+		t.Errorf("Text() = %q, want 食べ*", got)
+	}
+	if q, _ := Parse("level:3"); q.Text() != "" {
+		t.Errorf("Text() of a filter-only query = %q, want empty", q.Text())
+	}
+}
+
+func TestPatternLike(t *testing.T) {
+	tests := []struct {
+		pattern      Pattern
+		like         string
+		hasWildcards bool
+	}{
+		{"食べ*", "食べ%", true},
+		{"*かい", "%かい", true},
+		{"た?る", "た_る", true},
+		{"?*", "_%", true},
+		{"食べる", "食べる", false},
+		// LIKE's own wildcards and escape are literal
+		{"100%", `100\%`, false},
+		{"a_b*", `a\_b%`, true},
+		{`c:\*`, `c:\\%`, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pattern.Like(); got != tt.like {
+			t.Errorf("Pattern(%q).Like() = %q, want %q", tt.pattern, got, tt.like)
+		}
+		if got := tt.pattern.HasWildcards(); got != tt.hasWildcards {
+			t.Errorf("Pattern(%q).HasWildcards() = %t, want %t", tt.pattern, got, tt.hasWildcards)
+		}
+	}
+}
+
+func TestMapLiterals(t *testing.T) {
+	double := func(s string) (string, bool) {
+		if s == "x" {
+			return "", false
+		}
+		return s + s, true
+	}
+	if got, ok := Pattern("ab*c?").MapLiterals(double); !ok || got != "abab*cc?" {
+		t.Errorf("MapLiterals = %q, %t, want abab*cc?, true", got, ok)
+	}
+	if _, ok := Pattern("a*x").MapLiterals(double); ok {
+		t.Error("MapLiterals succeeded with a literal that doesn't convert")
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"gaijin/internal/database"
+	"gaijin/internal/doctor"
 	"gaijin/internal/server"
 	"log"
+	"os"
 
 	_ "github.com/lib/pq"
 )
@@ -21,6 +24,12 @@ func main() {
 		log.Printf("Warning: Failed to initialize tables: %v", err)
 	}
 
+	// `gaijin doctor [--fix]` checks the data instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		runDoctor(db, os.Args[2:])
+		return
+	}
+
 	srv := server.New(db)
 
 	log.Println("Starting server...")
@@ -28,3 +37,16 @@ func main() {
 		log.Fatal("Server failed to start:", err)
 	}
 }
+
+// runDoctor runs the data integrity checks and prints the report
+func runDoctor(db *database.Database, args []string) {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := flags.Bool("fix", false, "apply the safe fixes (orphan SR rows, kana flags, definitions from senses)")
+	flags.Parse(args)
+
+	results, err := doctor.Run(db, *fix)
+	if err != nil {
+		log.Fatal("Doctor failed:", err)
+	}
+	doctor.PrintReport(os.Stdout, results, *fix)
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"gaijin/internal/database"
+)
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log.Println("📦 Adding auto_link_confusions column to user_settings table...")
+	_, err = db.DB.Exec(`ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS auto_link_confusions BOOLEAN DEFAULT FALSE`)
+	if err != nil {
+		log.Fatalf("Failed to add auto_link_confusions column: %v", err)
+	}
+
+	log.Println("✅ auto_link_confusions column added to user_settings table")
+}
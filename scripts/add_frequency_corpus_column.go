@@ -0,0 +1,25 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"gaijin/internal/database"
+)
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log.Println("📦 Adding frequency_corpus column to user_settings table...")
+	_, err = db.DB.Exec(`ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS frequency_corpus VARCHAR(50) DEFAULT ''`)
+	if err != nil {
+		log.Fatalf("Failed to add frequency_corpus column: %v", err)
+	}
+
+	log.Println("✅ frequency_corpus column added to user_settings table")
+}
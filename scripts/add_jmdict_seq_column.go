@@ -0,0 +1,31 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"gaijin/internal/database"
+)
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log.Println("📦 Adding jmdict_seq column to words table...")
+	_, err = db.DB.Exec(`ALTER TABLE words ADD COLUMN IF NOT EXISTS jmdict_seq INTEGER`)
+	if err != nil {
+		log.Fatalf("Failed to add jmdict_seq column: %v", err)
+	}
+	log.Println("✅ jmdict_seq column added to words table")
+
+	// Create the jmdict_entries table
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to create jmdict_entries table: %v", err)
+	}
+
+	log.Println("\n✅ Migration complete! Run scripts/import_jmdict.go next.")
+}
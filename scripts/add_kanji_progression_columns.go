@@ -0,0 +1,36 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"gaijin/internal/database"
+)
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Add kanji progression settings to user_settings
+	log.Println("📦 Adding kanji progression columns to user_settings table...")
+	_, err = db.DB.Exec(`ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS kanji_progression BOOLEAN DEFAULT FALSE`)
+	if err != nil {
+		log.Fatalf("Failed to add kanji_progression column: %v", err)
+	}
+	_, err = db.DB.Exec(`ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS unlock_stage INTEGER DEFAULT 2`)
+	if err != nil {
+		log.Fatalf("Failed to add unlock_stage column: %v", err)
+	}
+	log.Println("✅ kanji_progression and unlock_stage columns added to user_settings table")
+
+	// Create the kanji, kanji_components, word_kanji and sr_kanji tables
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to create kanji tables: %v", err)
+	}
+
+	log.Println("\n✅ Migration complete! Run scripts/import_kanji.go next to populate the kanji tables.")
+}
@@ -0,0 +1,39 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"gaijin/internal/database"
+)
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log.Println("📦 Adding owner_id column to words table...")
+	_, err = db.DB.Exec(`ALTER TABLE words ADD COLUMN IF NOT EXISTS owner_id INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		log.Fatalf("Failed to add owner_id column: %v", err)
+	}
+	log.Println("✅ owner_id column added to words table")
+
+	// Custom words may share a word and level with a dictionary word or another user's word,
+	// so uniqueness now includes the owner
+	log.Println("📦 Replacing the words (word, level) unique constraint...")
+	_, err = db.DB.Exec(`
+		ALTER TABLE words DROP CONSTRAINT IF EXISTS words_word_level_key;
+		ALTER TABLE words DROP CONSTRAINT IF EXISTS words_word_level_owner_id_key;
+		ALTER TABLE words ADD CONSTRAINT words_word_level_owner_id_key UNIQUE (word, level, owner_id);
+	`)
+	if err != nil {
+		log.Fatalf("Failed to replace unique constraint: %v", err)
+	}
+	log.Println("✅ words are now unique by (word, level, owner_id)")
+
+	log.Println("\n✅ Migration complete!")
+}
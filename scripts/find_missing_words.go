@@ -35,7 +35,7 @@ func main() {
 	query := `
 		SELECT jv.id, jv.word, jv.meaning, jv.furigana, jv.romaji, jv.level
 		FROM jlpt_vocabulary jv
-		LEFT JOIN words w ON jv.word = w.word AND jv.level = w.level
+		LEFT JOIN words w ON jv.word = w.word AND jv.level = w.level AND w.owner_id = 0
 		WHERE w.id IS NULL
 		ORDER BY jv.level, jv.id
 	`
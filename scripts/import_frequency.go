@@ -3,174 +3,350 @@
 package main
 
 import (
+	"bufio"
 	"encoding/csv"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
-	"database/sql"
-
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"gaijin/internal/database"
 )
 
+// Imports a word frequency list for one corpus (news, novels, anime, web, ...).
+// Supported formats:
+//
+//	analysis  "Japanese Frequency Analysis.csv": header row, rank in column 1, word in column 3
+//	list      one word per line, most frequent first (extra tab or comma columns are ignored)
+//	counts    word and occurrence count per line, tab or comma separated, in any order
+//	yomitan   a Yomitan/Yomichan frequency dictionary: a term_meta_bank_*.json file, or the
+//	          unzipped dictionary directory
+//
+// Without -corpus the list becomes the default ranking in words.frequency, which the Learn
+// page, kanji coverage and confusion lists use. With -corpus it replaces that corpus's ranks
+// in word_frequencies, which users can pick on their profile to order the Learn page.
+// Words match on their headword and their written forms.
+//
+// Usage:
+//   go run scripts/import_frequency.go
+//   go run scripts/import_frequency.go -corpus news -format list -file news_words.txt
+//   go run scripts/import_frequency.go -corpus anime -format yomitan -file JPDB_v2/
+
 func main() {
-	// Load environment variables
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	path := flag.String("file", "Japanese Frequency Analysis.csv", "path to the frequency list")
+	format := flag.String("format", "analysis", "list format: analysis, list, counts or yomitan")
+	corpus := flag.String("corpus", "", "corpus name, e.g. news, novels, anime, web (empty for the default words.frequency)")
+	flag.Parse()
+
+	if *corpus != "" && !database.ValidFrequencyCorpusName(*corpus) {
+		log.Fatalf("Invalid corpus name %q: use lowercase letters, digits, - and _", *corpus)
 	}
 
-	// Connect to database
-	user := os.Getenv("PG_USER")
-	password := os.Getenv("PG_PASSWORD")
-	host := os.Getenv("PG_HOST")
-	database := os.Getenv("PG_DATABASE")
-	port := os.Getenv("PG_PORT")
+	// Step 1: Read the list
+	log.Printf("📖 Reading %s (%s format)...", *path, *format)
+	var ranks map[string]int
+	var err error
+	switch *format {
+	case "analysis":
+		ranks, err = readAnalysisCSV(*path)
+	case "list":
+		ranks, err = readWordList(*path)
+	case "counts":
+		ranks, err = readCounts(*path)
+	case "yomitan":
+		ranks, err = readYomitan(*path)
+	default:
+		log.Fatalf("Unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read frequency list: %v", err)
+	}
+	log.Printf("✅ Loaded %d unique words from the list", len(ranks))
 
-	connectionString := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable&client_encoding=UTF8", user, password, host, port, database)
-	db, err := sql.Open("postgres", connectionString)
+	db, err := database.ConnectDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	// Create the word_frequencies table
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
 	}
 
-	log.Println("✅ Connected to database")
-
-	// Step 1: Add frequency column if it doesn't exist
-	log.Println("📦 Adding frequency column to words table...")
-	_, err = db.Exec(`ALTER TABLE words ADD COLUMN IF NOT EXISTS frequency INTEGER`)
+	// Step 2: Match dictionary words on their headword and written forms
+	log.Println("📊 Matching dictionary words...")
+	rows, err := db.DB.Query(`
+		SELECT w.id, w.word FROM words w WHERE w.owner_id = 0
+		UNION
+		SELECT f.word_id, f.form FROM word_forms f JOIN words w ON w.id = f.word_id WHERE w.owner_id = 0
+	`)
 	if err != nil {
-		log.Fatalf("Failed to add frequency column: %v", err)
+		log.Fatalf("Failed to query words: %v", err)
+	}
+	wordRanks := make(map[int]int)
+	wordIDs := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var form string
+		if err := rows.Scan(&id, &form); err != nil {
+			log.Fatalf("Failed to scan word: %v", err)
+		}
+		if _, ok := wordIDs[id]; !ok {
+			wordIDs[id] = form
+		}
+		// A word ranks as its most frequent form
+		if rank, ok := ranks[form]; ok {
+			if best, seen := wordRanks[id]; !seen || rank < best {
+				wordRanks[id] = rank
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Fatalf("Failed to read words: %v", err)
+	}
+
+	// Step 3: Store the ranks
+	if *corpus == "" {
+		log.Println("🔄 Updating words.frequency...")
+		if _, err := db.DB.Exec(`ALTER TABLE words ADD COLUMN IF NOT EXISTS frequency INTEGER`); err != nil {
+			log.Fatalf("Failed to add frequency column: %v", err)
+		}
+		updateStmt, err := db.DB.Prepare(`UPDATE words SET frequency = $1 WHERE id = $2`)
+		if err != nil {
+			log.Fatalf("Failed to prepare update statement: %v", err)
+		}
+		defer updateStmt.Close()
+		for id, rank := range wordRanks {
+			if _, err := updateStmt.Exec(rank, id); err != nil {
+				log.Printf("Warning: failed to update word %d: %v", id, err)
+			}
+		}
+	} else {
+		log.Printf("🔄 Replacing %s frequency ranks...", *corpus)
+		if err := db.ReplaceWordFrequencies(*corpus, wordRanks); err != nil {
+			log.Fatalf("Failed to store frequencies: %v", err)
+		}
 	}
-	log.Println("✅ Frequency column ready")
 
-	// Step 2: Read the CSV file
-	log.Println("📖 Reading frequency CSV file...")
-	file, err := os.Open("Japanese Frequency Analysis.csv")
+	// Step 4: Output results
+	var unmatched []string
+	for id, word := range wordIDs {
+		if _, ok := wordRanks[id]; !ok && len(unmatched) < 50 {
+			unmatched = append(unmatched, word)
+		}
+	}
+	sort.Strings(unmatched)
+
+	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Println("📈 FREQUENCY IMPORT RESULTS")
+	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("📚 Total words in database: %d", len(wordIDs))
+	log.Printf("✅ Words matched with frequency: %d", len(wordRanks))
+	log.Printf("❌ Words WITHOUT frequency match: %d", len(wordIDs)-len(wordRanks))
+	if len(wordIDs) > 0 {
+		log.Printf("📊 Match rate: %.1f%%", float64(len(wordRanks))/float64(len(wordIDs))*100)
+	}
+	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(unmatched) > 0 {
+		log.Println("\n📝 Sample unmatched words:")
+		for i, word := range unmatched {
+			log.Printf("   %d. %s", i+1, word)
+		}
+	}
+
+	log.Println("\n✅ Frequency import complete!")
+}
+
+// addRank records a word's rank, keeping the first (lowest) one seen
+func addRank(ranks map[string]int, word string, rank int) {
+	word = strings.TrimSpace(word)
+	if word == "" || rank <= 0 {
+		return
+	}
+	if best, exists := ranks[word]; !exists || rank < best {
+		ranks[word] = rank
+	}
+}
+
+// readAnalysisCSV reads "Japanese Frequency Analysis.csv": rank, ..., word
+func readAnalysisCSV(path string) (map[string]int, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatalf("Failed to open CSV file: %v", err)
+		return nil, err
 	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
 
 	// Skip header row
-	_, err = reader.Read()
-	if err != nil {
-		log.Fatalf("Failed to read CSV header: %v", err)
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
 	}
 
-	// Build a map of word -> rank from CSV
-	frequencyMap := make(map[string]int)
-	csvWordCount := 0
-
+	ranks := make(map[string]int)
 	for {
 		record, err := reader.Read()
 		if err != nil {
 			break // End of file
 		}
-
 		if len(record) < 3 {
 			continue
 		}
-
 		rank, err := strconv.Atoi(strings.TrimSpace(record[0]))
 		if err != nil {
 			continue
 		}
+		addRank(ranks, record[2], rank)
+	}
+	return ranks, nil
+}
 
-		word := strings.TrimSpace(record[2])
-		if word == "" {
-			continue
-		}
+// splitLine returns the tab- or comma-separated fields of a list line
+func splitLine(line string) []string {
+	if strings.Contains(line, "\t") {
+		return strings.Split(line, "\t")
+	}
+	return strings.Split(line, ",")
+}
 
-		// Only store the first occurrence (lowest rank = most frequent)
-		if _, exists := frequencyMap[word]; !exists {
-			frequencyMap[word] = rank
-			csvWordCount++
-		}
+// readWordList reads one word per line, most frequent first
+func readWordList(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
 	}
+	defer file.Close()
 
-	log.Printf("✅ Loaded %d unique words from CSV", csvWordCount)
+	ranks := make(map[string]int)
+	rank := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rank++
+		addRank(ranks, splitLine(line)[0], rank)
+	}
+	return ranks, scanner.Err()
+}
 
-	// Step 3: Get all words from database
-	log.Println("📊 Fetching words from database...")
-	rows, err := db.Query(`SELECT id, word FROM words`)
+// readCounts reads "word<TAB>count" lines and ranks words by count, most frequent first
+func readCounts(path string) (map[string]int, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatalf("Failed to query words: %v", err)
+		return nil, err
 	}
-	defer rows.Close()
+	defer file.Close()
 
-	type dbWord struct {
-		ID   int
-		Word string
+	type wordCount struct {
+		word  string
+		count float64
 	}
-
-	var dbWords []dbWord
-	for rows.Next() {
-		var w dbWord
-		if err := rows.Scan(&w.ID, &w.Word); err != nil {
-			log.Printf("Warning: failed to scan word: %v", err)
+	var counts []wordCount
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := splitLine(strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff")))
+		if len(fields) < 2 {
 			continue
 		}
-		dbWords = append(dbWords, w)
+		word := strings.TrimSpace(fields[0])
+		count, err := strconv.ParseFloat(strings.TrimSpace(fields[len(fields)-1]), 64)
+		if err != nil || word == "" || seen[word] {
+			continue // header rows and repeats
+		}
+		seen[word] = true
+		counts = append(counts, wordCount{word, count})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
-	log.Printf("✅ Found %d words in database", len(dbWords))
-
-	// Step 4: Update frequency for each word
-	log.Println("🔄 Updating frequency values...")
-
-	updateStmt, err := db.Prepare(`UPDATE words SET frequency = $1 WHERE id = $2`)
-	if err != nil {
-		log.Fatalf("Failed to prepare update statement: %v", err)
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].count > counts[j].count })
+	ranks := make(map[string]int, len(counts))
+	for i, c := range counts {
+		addRank(ranks, c.word, i+1)
 	}
-	defer updateStmt.Close()
+	return ranks, nil
+}
 
-	matchedCount := 0
-	unmatchedCount := 0
-	unmatchedWords := []string{}
+// readYomitan reads the term_meta_bank files of a Yomitan frequency dictionary
+// Entries look like ["食べる", "freq", 523] or ["食べる", "freq", {"reading": "たべる", "frequency": {"value": 523}}]
+func readYomitan(path string) (map[string]int, error) {
+	files := []string{path}
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "term_meta_bank_*.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no term_meta_bank_*.json files in %s", path)
+		}
+	}
 
-	for _, w := range dbWords {
-		if rank, exists := frequencyMap[w.Word]; exists {
-			_, err := updateStmt.Exec(rank, w.ID)
-			if err != nil {
-				log.Printf("Warning: failed to update word %s: %v", w.Word, err)
+	ranks := make(map[string]int)
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var entries [][]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f, err)
+		}
+		for _, entry := range entries {
+			if len(entry) < 3 {
 				continue
 			}
-			matchedCount++
-		} else {
-			unmatchedCount++
-			// Store first 50 unmatched words for reference
-			if len(unmatchedWords) < 50 {
-				unmatchedWords = append(unmatchedWords, w.Word)
+			var term, mode string
+			if json.Unmarshal(entry[0], &term) != nil || json.Unmarshal(entry[1], &mode) != nil || mode != "freq" {
+				continue
 			}
+			addRank(ranks, term, yomitanValue(entry[2]))
 		}
 	}
+	return ranks, nil
+}
 
-	// Step 5: Output results
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Println("📈 FREQUENCY IMPORT RESULTS")
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Printf("📚 Total words in database: %d", len(dbWords))
-	log.Printf("✅ Words matched with frequency: %d", matchedCount)
-	log.Printf("❌ Words WITHOUT frequency match: %d", unmatchedCount)
-	log.Printf("📊 Match rate: %.1f%%", float64(matchedCount)/float64(len(dbWords))*100)
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-
-	if len(unmatchedWords) > 0 {
-		log.Println("\n📝 Sample unmatched words (first 50):")
-		for i, word := range unmatchedWords {
-			log.Printf("   %d. %s", i+1, word)
+// yomitanValue extracts the rank from the number, string or object forms of a frequency
+func yomitanValue(raw json.RawMessage) int {
+	var n float64
+	if json.Unmarshal(raw, &n) == nil {
+		return int(n)
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		// e.g. "523" or "523㋕"
+		if fields := strings.Fields(s); len(fields) > 0 {
+			n, _ := strconv.Atoi(fields[0])
+			return n
 		}
+		return 0
 	}
-
-	log.Println("\n✅ Frequency import complete!")
+	var obj struct {
+		Value     *float64        `json:"value"`
+		Frequency json.RawMessage `json:"frequency"`
+	}
+	if json.Unmarshal(raw, &obj) != nil {
+		return 0
+	}
+	if obj.Value != nil {
+		return int(*obj.Value)
+	}
+	if obj.Frequency != nil {
+		return yomitanValue(obj.Frequency)
+	}
+	return 0
 }
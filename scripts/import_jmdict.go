@@ -0,0 +1,358 @@
+//go:build ignore
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"gaijin/internal/database"
+	"gaijin/internal/jmdict"
+)
+
+// Imports a local JMdict file into jmdict_entries and fills the words table for every
+// jlpt_vocabulary row, replacing the old Jisho API scraping scripts.
+//
+// Usage:
+//   go run scripts/import_jmdict.go -file JMdict_e.xml
+//   go run scripts/import_jmdict.go -file jmdict-eng-3.6.1.json
+//
+// Safe to re-run: entries are upserted by sequence number and words by (word, level).
+
+// JLPTVocab represents a row from the jlpt_vocabulary table
+type JLPTVocab struct {
+	ID       int
+	Word     string
+	Meaning  string
+	Furigana string
+	Romaji   string
+	Level    int
+}
+
+// match is the best JMdict entry found so far for a JLPT vocabulary row
+type match struct {
+	Entry *jmdict.Entry
+	Score int
+}
+
+// batchSize is how many entries are written per transaction
+const batchSize = 1000
+
+func main() {
+	path := flag.String("file", "", "path to JMdict XML (JMdict_e / JMdict_e.xml) or JMdict-simplified JSON")
+	format := flag.String("format", "auto", "file format: xml, json or auto (by extension)")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("Usage: go run scripts/import_jmdict.go -file <JMdict file>")
+	}
+	if *format == "auto" {
+		*format = "xml"
+		if strings.HasSuffix(strings.ToLower(*path), ".json") {
+			*format = "json"
+		}
+	}
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
+	}
+
+	// Step 1: Index the JLPT vocabulary by word so each entry can be checked as it streams past
+	vocabList, err := loadJLPTVocab(db)
+	if err != nil {
+		log.Fatalf("Failed to load jlpt_vocabulary: %v", err)
+	}
+	vocabByWord := make(map[string][]int)
+	for i, v := range vocabList {
+		vocabByWord[v.Word] = append(vocabByWord[v.Word], i)
+	}
+	log.Printf("📊 Loaded %d JLPT vocabulary rows", len(vocabList))
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	// Step 2: Stream every entry into jmdict_entries, remembering the best match per JLPT row
+	log.Printf("📖 Streaming %s (%s)...", *path, *format)
+	best := make([]match, len(vocabList))
+	writer := newEntryWriter(db)
+	start := time.Now()
+	count := 0
+
+	handle := func(entry *jmdict.Entry) error {
+		if err := writer.Write(entry); err != nil {
+			return err
+		}
+
+		keys := make(map[string]bool)
+		for _, k := range entry.Kanji {
+			keys[k.Text] = true
+		}
+		for _, r := range entry.Readings {
+			keys[r.Text] = true
+		}
+		for key := range keys {
+			for _, i := range vocabByWord[key] {
+				if score := scoreMatch(entry, vocabList[i]); score > best[i].Score {
+					best[i] = match{Entry: entry, Score: score}
+				}
+			}
+		}
+
+		count++
+		if count%10000 == 0 {
+			log.Printf("  📦 %d entries processed (%.0f/s)", count, float64(count)/time.Since(start).Seconds())
+		}
+		return nil
+	}
+
+	var tags map[string]string
+	if *format == "json" {
+		tags, err = jmdict.ParseJSON(file, handle)
+	} else {
+		tags, err = jmdict.ParseXML(file, handle)
+	}
+	if err != nil {
+		log.Fatalf("Failed to parse JMdict: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatalf("Failed to write entries: %v", err)
+	}
+	log.Printf("✅ Stored %d JMdict entries in %s", count, time.Since(start).Round(time.Second))
+
+	// Step 3: Upsert a words row for every JLPT vocabulary row
+	log.Println("🔄 Writing words table...")
+	matched, fallback, errorCount := 0, 0, 0
+	for i, vocab := range vocabList {
+		var err error
+		if best[i].Entry == nil {
+			err = upsertFallbackWord(db, vocab)
+			fallback++
+		} else {
+			err = upsertWord(db, vocab, best[i].Entry, tags)
+			matched++
+		}
+		if err != nil {
+			log.Printf("  ❌ Error writing %s (N%d): %v", vocab.Word, vocab.Level, err)
+			errorCount++
+		}
+		if (i+1)%1000 == 0 {
+			log.Printf("  📦 %d/%d words written", i+1, len(vocabList))
+		}
+	}
+
+	log.Printf("\n=== JMdict Import Complete ===")
+	log.Printf("✅ Matched: %d words", matched)
+	log.Printf("⚠️  No JMdict entry (used JLPT data): %d words", fallback)
+	log.Printf("❌ Errors: %d words", errorCount)
+}
+
+func loadJLPTVocab(db *database.Database) ([]JLPTVocab, error) {
+	rows, err := db.Query(`
+		SELECT id, word, meaning, COALESCE(furigana, ''), COALESCE(romaji, ''), level
+		FROM jlpt_vocabulary
+		ORDER BY level DESC, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vocabList []JLPTVocab
+	for rows.Next() {
+		var v JLPTVocab
+		if err := rows.Scan(&v.ID, &v.Word, &v.Meaning, &v.Furigana, &v.Romaji, &v.Level); err != nil {
+			return nil, err
+		}
+		vocabList = append(vocabList, v)
+	}
+	return vocabList, rows.Err()
+}
+
+// scoreMatch rates how well an entry fits a JLPT vocabulary row (0 = not a match)
+// Homographs are common, so the reading and common-word markers break ties
+func scoreMatch(entry *jmdict.Entry, vocab JLPTVocab) int {
+	score := 0
+	switch {
+	case entry.HasWriting(vocab.Word):
+		score += 4
+	case entry.HasReading(vocab.Word):
+		score += 2
+	default:
+		return 0
+	}
+	for _, reading := range strings.Split(vocab.Furigana, "/") {
+		if reading = strings.TrimSpace(reading); reading != "" && entry.HasReading(reading) {
+			score += 3
+			break
+		}
+	}
+	if entry.IsCommon() {
+		score++
+	}
+	return score
+}
+
+// upsertWord writes a words row from a matched JMdict entry
+func upsertWord(db *database.Database, vocab JLPTVocab, entry *jmdict.Entry, tags map[string]string) error {
+	word := vocab.Word
+	var readings []string
+	isHiraganaOnly := entry.UsuallyKana()
+
+	if entry.HasWriting(vocab.Word) {
+		readings = entry.ReadingsFor(vocab.Word)
+	} else if writings := entry.WritingsFor(vocab.Word); len(writings) > 0 {
+		// JLPT lists the kana, JMdict has a kanji form: study the kanji with the kana as furigana
+		word = writings[0]
+		readings = []string{vocab.Word}
+		isHiraganaOnly = true
+	} else {
+		readings = []string{vocab.Word}
+	}
+	if len(readings) == 0 {
+		readings = entry.ReadingsFor("")
+	}
+
+	var partsOfSpeech []string
+	for _, code := range entry.POS() {
+		if description, ok := tags[code]; ok {
+			partsOfSpeech = append(partsOfSpeech, description)
+		} else {
+			partsOfSpeech = append(partsOfSpeech, code)
+		}
+	}
+
+	var wordID int
+	err := db.DB.QueryRow(`
+		INSERT INTO words (word, furigana, romaji, level, definitions, parts_of_speech, katakana_only, hiragana_only, jmdict_seq, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (word, level, owner_id) DO UPDATE
+		SET furigana = EXCLUDED.furigana,
+		    romaji = EXCLUDED.romaji,
+		    definitions = EXCLUDED.definitions,
+		    parts_of_speech = EXCLUDED.parts_of_speech,
+		    katakana_only = EXCLUDED.katakana_only,
+		    hiragana_only = EXCLUDED.hiragana_only,
+		    jmdict_seq = EXCLUDED.jmdict_seq
+		RETURNING id
+	`, word, strings.Join(readings, " / "), vocab.Romaji, vocab.Level,
+		strings.Join(entry.Glosses(), "; "), strings.Join(partsOfSpeech, "; "),
+		isKatakanaOnly(word), isHiraganaOnly, entry.Seq).Scan(&wordID)
+	if err != nil {
+		return err
+	}
+	if err := db.ReplaceWordSenses(wordID, database.SensesFromJMdict(entry, tags)); err != nil {
+		return err
+	}
+	primaryReading := ""
+	if len(readings) > 0 {
+		primaryReading = readings[0]
+	}
+	forms, wordReadings := database.VariantsFromJMdict(entry, word, primaryReading)
+	return db.ReplaceWordVariants(wordID, forms, wordReadings)
+}
+
+// upsertFallbackWord writes a words row from the JLPT list data alone
+func upsertFallbackWord(db *database.Database, vocab JLPTVocab) error {
+	var wordID int
+	err := db.DB.QueryRow(`
+		INSERT INTO words (word, furigana, romaji, level, definitions, parts_of_speech, katakana_only, hiragana_only, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (word, level, owner_id) DO NOTHING
+		RETURNING id
+	`, vocab.Word, vocab.Furigana, vocab.Romaji, vocab.Level, vocab.Meaning, "Unknown", isKatakanaOnly(vocab.Word), false).Scan(&wordID)
+	if err == sql.ErrNoRows {
+		return nil // Already exists
+	}
+	if err != nil {
+		return err
+	}
+	forms, readings := database.LegacyVariants(vocab.Word, vocab.Furigana)
+	return db.ReplaceWordVariants(wordID, forms, readings)
+}
+
+// entryWriter upserts JMdict entries in batched transactions
+type entryWriter struct {
+	db      *database.Database
+	pending []*jmdict.Entry
+}
+
+func newEntryWriter(db *database.Database) *entryWriter {
+	return &entryWriter{db: db}
+}
+
+func (w *entryWriter) Write(entry *jmdict.Entry) error {
+	w.pending = append(w.pending, entry)
+	if len(w.pending) >= batchSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+func (w *entryWriter) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	tx, err := w.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO jmdict_entries (seq, data, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (seq) DO UPDATE SET data = EXCLUDED.data, updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, entry := range w.pending {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(entry.Seq, data); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	w.pending = w.pending[:0]
+	return nil
+}
+
+// isKatakanaOnly checks if a string contains only katakana characters and common punctuation
+func isKatakanaOnly(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	hasKatakana := false
+	for _, r := range s {
+		if (r >= '\u30A0' && r <= '\u30FF') || // Katakana block
+			(r >= '\u31F0' && r <= '\u31FF') || // Katakana Phonetic Extensions
+			(r >= '\uFF65' && r <= '\uFF9F') { // Halfwidth Katakana
+			hasKatakana = true
+		} else if !(r == ' ' || r == '・' || r == 'ー' || r == '〜' || r == '～') {
+			return false
+		}
+	}
+	return hasKatakana
+}
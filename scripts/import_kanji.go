@@ -0,0 +1,263 @@
+//go:build ignore
+
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"gaijin/internal/database"
+)
+
+// kanjidicEntry holds the parts of a KANJIDIC2 <character> element we care about
+type kanjidicEntry struct {
+	Literal string `xml:"literal"`
+	Misc    struct {
+		StrokeCount []int `xml:"stroke_count"`
+		Freq        int   `xml:"freq"`
+	} `xml:"misc"`
+	Readings []struct {
+		Type  string `xml:"r_type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"reading_meaning>rmgroup>reading"`
+	Meanings []struct {
+		Lang  string `xml:"m_lang,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"reading_meaning>rmgroup>meaning"`
+}
+
+// kanjiInfo is the data written to the kanji table for a single character
+type kanjiInfo struct {
+	Meanings    []string
+	Onyomi      []string
+	Kunyomi     []string
+	StrokeCount int
+	Frequency   int
+}
+
+func main() {
+	kanjidicPath := flag.String("kanjidic", "", "path to a KANJIDIC2 XML file (meanings, readings, stroke counts)")
+	kradPath := flag.String("kradfile", "", "path to a UTF-8 KRADFILE (kanji component decomposition)")
+	flag.Parse()
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
+	}
+
+	// Step 1: Load KANJIDIC2 data if provided
+	info := make(map[string]kanjiInfo)
+	if *kanjidicPath != "" {
+		log.Printf("📖 Reading KANJIDIC2 from %s...", *kanjidicPath)
+		info, err = readKanjidic(*kanjidicPath)
+		if err != nil {
+			log.Fatalf("Failed to read KANJIDIC2: %v", err)
+		}
+		log.Printf("✅ Loaded %d kanji from KANJIDIC2", len(info))
+	}
+
+	// Step 2: Collect every kanji used by the words table, with the easiest JLPT level it appears at
+	log.Println("📊 Collecting kanji from words table...")
+	rows, err := db.DB.Query(`SELECT id, word, level FROM words`)
+	if err != nil {
+		log.Fatalf("Failed to query words: %v", err)
+	}
+	levels := make(map[string]int)
+	wordKanji := make(map[int][]string)
+	for rows.Next() {
+		var id, level int
+		var word string
+		if err := rows.Scan(&id, &word, &level); err != nil {
+			log.Printf("Warning: failed to scan word: %v", err)
+			continue
+		}
+		for _, k := range database.ExtractKanji(word) {
+			char := string(k)
+			// Higher level number = easier (N5 = 5)
+			if level > levels[char] {
+				levels[char] = level
+			}
+			wordKanji[id] = append(wordKanji[id], char)
+		}
+	}
+	rows.Close()
+	log.Printf("✅ Found %d distinct kanji in %d words", len(levels), len(wordKanji))
+
+	// Step 3: Upsert kanji rows
+	log.Println("🔄 Writing kanji table...")
+	upsert := `
+		INSERT INTO kanji (character, meanings, onyomi, kunyomi, stroke_count, level, frequency)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, 0), $6, NULLIF($7, 0))
+		ON CONFLICT (character) DO UPDATE SET
+			meanings = COALESCE(EXCLUDED.meanings, kanji.meanings),
+			onyomi = COALESCE(EXCLUDED.onyomi, kanji.onyomi),
+			kunyomi = COALESCE(EXCLUDED.kunyomi, kanji.kunyomi),
+			stroke_count = COALESCE(EXCLUDED.stroke_count, kanji.stroke_count),
+			level = EXCLUDED.level,
+			frequency = COALESCE(EXCLUDED.frequency, kanji.frequency)
+	`
+	kanjiIDs := make(map[string]int)
+	for char, level := range levels {
+		ki := info[char]
+		_, err := db.DB.Exec(upsert, char,
+			strings.Join(ki.Meanings, "; "), strings.Join(ki.Onyomi, "、"), strings.Join(ki.Kunyomi, "、"),
+			ki.StrokeCount, level, ki.Frequency)
+		if err != nil {
+			log.Printf("Warning: failed to upsert kanji %s: %v", char, err)
+			continue
+		}
+	}
+	rows, err = db.DB.Query(`SELECT id, character FROM kanji`)
+	if err != nil {
+		log.Fatalf("Failed to query kanji: %v", err)
+	}
+	for rows.Next() {
+		var id int
+		var char string
+		if err := rows.Scan(&id, &char); err == nil {
+			kanjiIDs[char] = id
+		}
+	}
+	rows.Close()
+	log.Printf("✅ Kanji table has %d rows", len(kanjiIDs))
+
+	// Step 4: Link words to their kanji
+	log.Println("🔗 Linking words to kanji...")
+	linked := 0
+	for wordID, chars := range wordKanji {
+		for _, char := range chars {
+			kanjiID, ok := kanjiIDs[char]
+			if !ok {
+				continue
+			}
+			_, err := db.DB.Exec(`INSERT INTO word_kanji (word_id, kanji_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, wordID, kanjiID)
+			if err != nil {
+				log.Printf("Warning: failed to link word %d to %s: %v", wordID, char, err)
+				continue
+			}
+			linked++
+		}
+	}
+	log.Printf("✅ Linked %d word/kanji pairs", linked)
+
+	// Step 5: Load component decomposition if provided
+	if *kradPath != "" {
+		log.Printf("📖 Reading KRADFILE from %s...", *kradPath)
+		components, err := readKradfile(*kradPath)
+		if err != nil {
+			log.Fatalf("Failed to read KRADFILE: %v", err)
+		}
+		inserted := 0
+		for char, parts := range components {
+			kanjiID, ok := kanjiIDs[char]
+			if !ok {
+				continue
+			}
+			for _, part := range parts {
+				if part == char {
+					continue
+				}
+				_, err := db.DB.Exec(`INSERT INTO kanji_components (kanji_id, component) VALUES ($1, $2) ON CONFLICT DO NOTHING`, kanjiID, part)
+				if err != nil {
+					log.Printf("Warning: failed to insert component %s for %s: %v", part, char, err)
+					continue
+				}
+				inserted++
+			}
+		}
+		log.Printf("✅ Inserted %d kanji components", inserted)
+	}
+
+	log.Println("\n✅ Kanji import complete!")
+}
+
+// readKanjidic streams a KANJIDIC2 XML file and returns English meanings, readings and stroke counts per kanji
+func readKanjidic(path string) (map[string]kanjiInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	// KANJIDIC2 declares entities in its DTD that encoding/xml doesn't read
+	decoder.Strict = false
+
+	result := make(map[string]kanjiInfo)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "character" {
+			continue
+		}
+
+		var entry kanjidicEntry
+		if err := decoder.DecodeElement(&entry, &start); err != nil {
+			return nil, err
+		}
+
+		var ki kanjiInfo
+		for _, m := range entry.Meanings {
+			// Meanings without m_lang are English
+			if m.Lang == "" {
+				ki.Meanings = append(ki.Meanings, m.Value)
+			}
+		}
+		for _, r := range entry.Readings {
+			switch r.Type {
+			case "ja_on":
+				ki.Onyomi = append(ki.Onyomi, r.Value)
+			case "ja_kun":
+				ki.Kunyomi = append(ki.Kunyomi, r.Value)
+			}
+		}
+		if len(entry.Misc.StrokeCount) > 0 {
+			// The first stroke_count is the accepted one, the rest are common miscounts
+			ki.StrokeCount = entry.Misc.StrokeCount[0]
+		}
+		ki.Frequency = entry.Misc.Freq
+		result[entry.Literal] = ki
+	}
+	return result, nil
+}
+
+// readKradfile parses KRADFILE lines of the form "漢 : 氵 艹 口 夫"
+func readKradfile(path string) (map[string][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string][]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		kanji := strings.TrimSpace(parts[0])
+		result[kanji] = strings.Fields(parts[1])
+	}
+	return result, scanner.Err()
+}
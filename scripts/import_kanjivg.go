@@ -0,0 +1,199 @@
+//go:build ignore
+
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gaijin/internal/database"
+)
+
+// strokeIDPattern matches KanjiVG stroke path IDs like "kvg:06f22-s3"
+var strokeIDPattern = regexp.MustCompile(`-s(\d+)$`)
+
+type stroke struct {
+	Number int
+	Path   string
+}
+
+func main() {
+	dir := flag.String("dir", "kanjivg/kanji", "path to the KanjiVG kanji directory (one <codepoint>.svg per kanji)")
+	fixCounts := flag.Bool("fix-counts", false, "overwrite kanji.stroke_count when it disagrees with the SVG")
+	flag.Parse()
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
+	}
+
+	// Load the kanji we track, with their recorded stroke counts
+	rows, err := db.DB.Query(`SELECT id, character, COALESCE(stroke_count, 0) FROM kanji`)
+	if err != nil {
+		log.Fatalf("Failed to query kanji: %v", err)
+	}
+	type kanjiRow struct {
+		ID          int
+		StrokeCount int
+	}
+	kanji := make(map[string]kanjiRow)
+	for rows.Next() {
+		var char string
+		var row kanjiRow
+		if err := rows.Scan(&row.ID, &char, &row.StrokeCount); err != nil {
+			log.Fatalf("Failed to scan kanji: %v", err)
+		}
+		kanji[char] = row
+	}
+	rows.Close()
+	log.Printf("📊 %d kanji in database", len(kanji))
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.svg"))
+	if err != nil {
+		log.Fatalf("Failed to list SVG files: %v", err)
+	}
+	log.Printf("📖 Found %d SVG files in %s", len(files), *dir)
+
+	imported, skipped, filled, mismatches := 0, 0, 0, 0
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".svg")
+		// Variant files (e.g. 06f22-Kaisho.svg) use alternative stroke orders
+		if strings.Contains(name, "-") {
+			continue
+		}
+		codepoint, err := strconv.ParseInt(name, 16, 32)
+		if err != nil {
+			continue
+		}
+		char := string(rune(codepoint))
+
+		row, ok := kanji[char]
+		if !ok {
+			skipped++
+			continue
+		}
+
+		strokes, err := readStrokes(file)
+		if err != nil {
+			log.Printf("Warning: failed to parse %s: %v", file, err)
+			continue
+		}
+		if len(strokes) == 0 {
+			log.Printf("Warning: no strokes found in %s", file)
+			continue
+		}
+
+		paths := make([]string, len(strokes))
+		for i, s := range strokes {
+			paths[i] = s.Path
+		}
+		if err := db.SaveKanjiStrokes(row.ID, paths); err != nil {
+			log.Printf("Warning: failed to save strokes for %s: %v", char, err)
+			continue
+		}
+		imported++
+
+		// Cross-check the derived stroke count against the kanji table
+		switch {
+		case row.StrokeCount == 0:
+			_, err = db.DB.Exec(`UPDATE kanji SET stroke_count = $1 WHERE id = $2`, len(paths), row.ID)
+			if err != nil {
+				log.Printf("Warning: failed to set stroke count for %s: %v", char, err)
+				continue
+			}
+			filled++
+		case row.StrokeCount != len(paths):
+			mismatches++
+			log.Printf("⚠️  Stroke count mismatch for %s: kanji table has %d, KanjiVG has %d", char, row.StrokeCount, len(paths))
+			if *fixCounts {
+				_, err = db.DB.Exec(`UPDATE kanji SET stroke_count = $1 WHERE id = $2`, len(paths), row.ID)
+				if err != nil {
+					log.Printf("Warning: failed to fix stroke count for %s: %v", char, err)
+				}
+			}
+		}
+	}
+
+	log.Printf("✅ Imported stroke data for %d kanji", imported)
+	log.Printf("📊 Skipped %d SVGs for kanji not in the kanji table", skipped)
+	log.Printf("📊 Filled in %d missing stroke counts", filled)
+	if mismatches > 0 {
+		if *fixCounts {
+			log.Printf("🔧 Fixed %d stroke count mismatches", mismatches)
+		} else {
+			log.Printf("⚠️  %d stroke count mismatches (re-run with -fix-counts to overwrite)", mismatches)
+		}
+	}
+
+	// Report kanji that still have no stroke data
+	var missing int
+	err = db.DB.QueryRow(`
+		SELECT COUNT(*) FROM kanji k
+		WHERE NOT EXISTS (SELECT 1 FROM kanji_strokes ks WHERE ks.kanji_id = k.id)
+	`).Scan(&missing)
+	if err == nil && missing > 0 {
+		log.Printf("⚠️  %d kanji have no stroke data", missing)
+	}
+
+	log.Println("\n✅ KanjiVG import complete!")
+}
+
+// readStrokes returns the stroke paths in a KanjiVG SVG file, ordered by stroke number
+func readStrokes(path string) ([]stroke, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	// KanjiVG files declare kvg: attributes in an internal DTD that encoding/xml doesn't read
+	decoder.Strict = false
+
+	var strokes []stroke
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "path" {
+			continue
+		}
+
+		var id, d string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "id":
+				id = attr.Value
+			case "d":
+				d = attr.Value
+			}
+		}
+		match := strokeIDPattern.FindStringSubmatch(id)
+		if match == nil || d == "" {
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		strokes = append(strokes, stroke{Number: number, Path: d})
+	}
+
+	sort.Slice(strokes, func(i, j int) bool { return strokes[i].Number < strokes[j].Number })
+	return strokes, nil
+}
@@ -0,0 +1,117 @@
+//go:build ignore
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"gaijin/internal/database"
+	"gaijin/internal/pitch"
+)
+
+// Imports Tokyo-dialect pitch accents from a Kanjium-style accents file into pitch_accents.
+// Each line is tab-separated: written form, reading, accent positions, e.g.
+//
+//	橋	はし	2
+//	箸	はし	1
+//	今日	きょう	1
+//	あそこ		0
+//
+// Several positions are comma-separated and may carry part-of-speech prefixes like "(名)0".
+// An empty reading means the form is its own reading. Words pick up their accents through
+// word_forms and word_readings, so run scripts/migrate_word_readings.go first.
+//
+// Usage:
+//   go run scripts/import_pitch_accent.go -file accents.txt
+
+// batchSize is how many entries are written per transaction
+const batchSize = 1000
+
+func main() {
+	path := flag.String("file", "", "path to a Kanjium-style accents.txt")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("Usage: go run scripts/import_pitch_accent.go -file <accents file>")
+	}
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Create the pitch_accents table
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	log.Printf("📖 Reading %s...", *path)
+	var batch []database.PitchAccentEntry
+	count, skipped := 0, 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			skipped++
+			continue
+		}
+
+		form, reading := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+		if reading == "" {
+			reading = form
+		}
+		accents := pitch.ParseAccents(fields[2])
+		if form == "" || len(accents) == 0 {
+			skipped++
+			continue
+		}
+
+		batch = append(batch, database.PitchAccentEntry{Form: form, Reading: reading, Accents: accents})
+		if len(batch) >= batchSize {
+			if err := db.SavePitchAccents(batch); err != nil {
+				log.Fatalf("Failed to save pitch accents: %v", err)
+			}
+			count += len(batch)
+			batch = batch[:0]
+			log.Printf("  📦 %d entries imported", count)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("Failed to read %s: %v", *path, err)
+	}
+	if err := db.SavePitchAccents(batch); err != nil {
+		log.Fatalf("Failed to save pitch accents: %v", err)
+	}
+	count += len(batch)
+
+	var matched int
+	err = db.DB.QueryRow(`
+		SELECT COUNT(DISTINCT f.word_id)
+		FROM word_forms f
+		JOIN word_readings r ON r.word_id = f.word_id
+		JOIN pitch_accents p ON p.form = f.form AND p.reading = r.reading
+	`).Scan(&matched)
+	if err != nil {
+		log.Fatalf("Failed to count matched words: %v", err)
+	}
+
+	log.Printf("\n=== Pitch Accent Import Complete ===")
+	log.Printf("✅ Imported: %d entries", count)
+	log.Printf("⚠️  Skipped: %d lines", skipped)
+	log.Printf("📊 Words with pitch accent data: %d", matched)
+}
@@ -0,0 +1,199 @@
+//go:build ignore
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"gaijin/internal/database"
+)
+
+// Imports example sentences from a Tatoeba/Tanaka corpus export (examples.utf format) into
+// sentences and links them to words through the indexed headwords. Each pair of lines is
+//
+//	A: 彼は忙しい生活の中で家族と会うことがない。	He doesn't see his family in his busy life.#ID=303645_494293
+//	B: 彼(かれ)[01] は 忙しい 生活 の 中 で 家族 と 会う[01]{会う} 事{こと} が 無い{ない}
+//
+// where each B token is headword(reading)[sense]{surface form}, with a trailing ~ on
+// checked, representative examples. Headwords are matched against word_forms, and the
+// reading (if given) against word_readings, so run scripts/migrate_word_readings.go first.
+//
+// Usage:
+//   go run scripts/import_sentences.go -file examples.utf
+//
+// Safe to re-run: sentences are upserted by their #ID and their links replaced.
+
+// token is one indexed headword from a B line
+type token struct {
+	Headword string
+	Reading  string
+	Surface  string
+	Good     bool
+}
+
+var tokenPattern = regexp.MustCompile(`^([^(\[{~]+)(?:\(([^)]*)\))?(?:\[[0-9]+\])?(?:\{([^}]*)\})?(~)?$`)
+
+func main() {
+	path := flag.String("file", "", "path to a Tanaka-format examples file (examples.utf)")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("Usage: go run scripts/import_sentences.go -file <examples file>")
+	}
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Create the sentences and sentence_words tables
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to initialize tables: %v", err)
+	}
+
+	// Step 1: Index word forms and readings
+	wordsByForm, readingsByWord, err := loadWordIndex(db)
+	if err != nil {
+		log.Fatalf("Failed to load word forms: %v", err)
+	}
+	log.Printf("📊 Indexed %d written forms", len(wordsByForm))
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	// Step 2: Read A/B line pairs and link each sentence's headwords
+	log.Printf("📖 Reading %s...", *path)
+	var japanese, english, sourceID string
+	count, linked, errorCount := 0, 0, 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "A: "):
+			japanese, english, sourceID = parseALine(line[3:])
+		case strings.HasPrefix(line, "B: ") && japanese != "":
+			tokens := line[3:]
+			var links []database.SentenceWord
+			seen := make(map[int]bool)
+			for _, field := range strings.Fields(tokens) {
+				t, ok := parseToken(field)
+				if !ok {
+					continue
+				}
+				for _, wordID := range matchWords(t, wordsByForm, readingsByWord) {
+					if seen[wordID] {
+						continue
+					}
+					seen[wordID] = true
+					links = append(links, database.SentenceWord{WordID: wordID, Surface: t.Surface, GoodExample: t.Good})
+				}
+			}
+
+			if err := db.SaveSentence(sourceID, japanese, english, tokens, links); err != nil {
+				log.Printf("  ❌ Error saving sentence %s: %v", sourceID, err)
+				errorCount++
+			} else {
+				count++
+				linked += len(links)
+			}
+			japanese = ""
+			if count%10000 == 0 && count > 0 {
+				log.Printf("  📦 %d sentences imported", count)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("Failed to read %s: %v", *path, err)
+	}
+
+	log.Printf("\n=== Sentence Import Complete ===")
+	log.Printf("✅ Imported: %d sentences", count)
+	log.Printf("🔗 Word links: %d", linked)
+	log.Printf("❌ Errors: %d sentences", errorCount)
+}
+
+// parseALine splits "日本語\tEnglish#ID=1_2" into its parts
+func parseALine(s string) (string, string, string) {
+	japanese, rest, _ := strings.Cut(s, "\t")
+	english, sourceID, _ := strings.Cut(rest, "#ID=")
+	if sourceID == "" {
+		sourceID = japanese
+	}
+	return strings.TrimSpace(japanese), strings.TrimSpace(english), strings.TrimSpace(sourceID)
+}
+
+// parseToken parses a B line token like 会う(あう)[01]{会った}~
+func parseToken(field string) (token, bool) {
+	m := tokenPattern.FindStringSubmatch(field)
+	if m == nil {
+		return token{}, false
+	}
+	t := token{Headword: m[1], Reading: m[2], Surface: m[3], Good: m[4] == "~"}
+	if t.Surface == "" {
+		t.Surface = t.Headword
+	}
+	return t, true
+}
+
+// matchWords returns the words a token's headword refers to
+// If the token gives a reading, words with the same form but another reading are skipped
+func matchWords(t token, wordsByForm map[string][]int, readingsByWord map[int]map[string]bool) []int {
+	candidates := wordsByForm[t.Headword]
+	if t.Reading == "" {
+		return candidates
+	}
+	var matched []int
+	for _, wordID := range candidates {
+		if readingsByWord[wordID][t.Reading] {
+			matched = append(matched, wordID)
+		}
+	}
+	return matched
+}
+
+func loadWordIndex(db *database.Database) (map[string][]int, map[int]map[string]bool, error) {
+	wordsByForm := make(map[string][]int)
+	rows, err := db.Query(`SELECT word_id, form FROM word_forms`)
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		var wordID int
+		var form string
+		if err := rows.Scan(&wordID, &form); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		wordsByForm[form] = append(wordsByForm[form], wordID)
+	}
+	rows.Close()
+
+	readingsByWord := make(map[int]map[string]bool)
+	rows, err = db.Query(`SELECT word_id, reading FROM word_readings`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var wordID int
+		var reading string
+		if err := rows.Scan(&wordID, &reading); err != nil {
+			return nil, nil, err
+		}
+		if readingsByWord[wordID] == nil {
+			readingsByWord[wordID] = make(map[string]bool)
+		}
+		readingsByWord[wordID][reading] = true
+	}
+	return wordsByForm, readingsByWord, rows.Err()
+}
@@ -0,0 +1,107 @@
+//go:build ignore
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"gaijin/internal/database"
+	"gaijin/internal/jmdict"
+)
+
+// Fills word_forms and word_readings from the existing words table. Words imported from
+// JMdict get every written form and reading from their stored entry, with rare and outdated
+// ones flagged; everything else is split from the "a / b" values in words.word and
+// words.furigana (see "incorrect DB.txt" for examples like いい / よい).
+//
+// Usage:
+//   go run scripts/migrate_word_readings.go
+//
+// Safe to re-run: each word's forms and readings are replaced.
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Create the word_forms and word_readings tables
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to create word form tables: %v", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT w.id, w.word, COALESCE(w.furigana, ''), j.data
+		FROM words w
+		LEFT JOIN jmdict_entries j ON j.seq = w.jmdict_seq
+		WHERE w.owner_id = 0
+		ORDER BY w.id
+	`)
+	if err != nil {
+		log.Fatalf("Failed to load words: %v", err)
+	}
+
+	type wordRow struct {
+		ID       int
+		Word     string
+		Furigana string
+		Entry    []byte
+	}
+	var words []wordRow
+	for rows.Next() {
+		var w wordRow
+		var entry sql.NullString
+		if err := rows.Scan(&w.ID, &w.Word, &w.Furigana, &entry); err != nil {
+			log.Fatalf("Failed to scan word: %v", err)
+		}
+		if entry.Valid {
+			w.Entry = []byte(entry.String)
+		}
+		words = append(words, w)
+	}
+	rows.Close()
+	log.Printf("📊 Migrating forms and readings for %d words", len(words))
+
+	fromJMdict, fromLegacy, errorCount := 0, 0, 0
+	for i, w := range words {
+		var forms []database.WordForm
+		var readings []database.WordReading
+
+		// Hand-merged rows like "ラジカセ / ラジオカセット" don't map onto a single entry
+		if w.Entry != nil && !strings.Contains(w.Word, "/") {
+			var entry jmdict.Entry
+			if err := json.Unmarshal(w.Entry, &entry); err != nil {
+				log.Printf("  ⚠️  Bad JMdict entry for %s, using legacy columns: %v", w.Word, err)
+			} else {
+				primary := ""
+				if alternates := database.SplitAlternates(w.Furigana); len(alternates) > 0 {
+					primary = alternates[0]
+				}
+				forms, readings = database.VariantsFromJMdict(&entry, w.Word, primary)
+			}
+		}
+		if len(forms) > 0 {
+			fromJMdict++
+		} else {
+			forms, readings = database.LegacyVariants(w.Word, w.Furigana)
+			fromLegacy++
+		}
+
+		if err := db.ReplaceWordVariants(w.ID, forms, readings); err != nil {
+			log.Printf("  ❌ Error migrating %s: %v", w.Word, err)
+			errorCount++
+		}
+		if (i+1)%1000 == 0 {
+			log.Printf("  📦 %d/%d words migrated", i+1, len(words))
+		}
+	}
+
+	log.Printf("\n=== Word Form Migration Complete ===")
+	log.Printf("✅ From JMdict entries: %d words", fromJMdict)
+	log.Printf("✅ From legacy columns: %d words", fromLegacy)
+	log.Printf("❌ Errors: %d words", errorCount)
+}
@@ -0,0 +1,101 @@
+//go:build ignore
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"gaijin/internal/database"
+	"gaijin/internal/jmdict"
+)
+
+// Converts the semicolon-joined words.definitions / words.parts_of_speech strings into
+// word_senses rows. Words imported from JMdict are rebuilt from their stored entry so each
+// sense keeps its own parts of speech and usage tags; everything else is split from the
+// legacy strings.
+//
+// Usage:
+//   go run scripts/migrate_word_senses.go
+//
+// Safe to re-run: each word's senses are replaced.
+
+func main() {
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Create the part_of_speech, word_senses and word_sense_pos tables
+	if err := db.InitializeTables(); err != nil {
+		log.Fatalf("Failed to create word sense tables: %v", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT w.id, w.word, COALESCE(w.definitions, ''), COALESCE(w.parts_of_speech, ''), j.data
+		FROM words w
+		LEFT JOIN jmdict_entries j ON j.seq = w.jmdict_seq
+		WHERE w.owner_id = 0
+		ORDER BY w.id
+	`)
+	if err != nil {
+		log.Fatalf("Failed to load words: %v", err)
+	}
+
+	type wordRow struct {
+		ID            int
+		Word          string
+		Definitions   string
+		PartsOfSpeech string
+		Entry         []byte
+	}
+	var words []wordRow
+	for rows.Next() {
+		var w wordRow
+		var entry sql.NullString
+		if err := rows.Scan(&w.ID, &w.Word, &w.Definitions, &w.PartsOfSpeech, &entry); err != nil {
+			log.Fatalf("Failed to scan word: %v", err)
+		}
+		if entry.Valid {
+			w.Entry = []byte(entry.String)
+		}
+		words = append(words, w)
+	}
+	rows.Close()
+	log.Printf("📊 Migrating senses for %d words", len(words))
+
+	fromJMdict, fromLegacy, errorCount := 0, 0, 0
+	for i, w := range words {
+		var senses []database.Sense
+		if w.Entry != nil {
+			var entry jmdict.Entry
+			if err := json.Unmarshal(w.Entry, &entry); err != nil {
+				log.Printf("  ⚠️  Bad JMdict entry for %s, using legacy strings: %v", w.Word, err)
+			} else {
+				// Descriptions come from the built-in table since the DTD isn't stored
+				senses = database.SensesFromJMdict(&entry, nil)
+			}
+		}
+		if len(senses) > 0 {
+			fromJMdict++
+		} else {
+			senses = database.LegacySenses(w.Definitions, w.PartsOfSpeech)
+			fromLegacy++
+		}
+
+		if err := db.ReplaceWordSenses(w.ID, senses); err != nil {
+			log.Printf("  ❌ Error migrating %s: %v", w.Word, err)
+			errorCount++
+		}
+		if (i+1)%1000 == 0 {
+			log.Printf("  📦 %d/%d words migrated", i+1, len(words))
+		}
+	}
+
+	log.Printf("\n=== Word Sense Migration Complete ===")
+	log.Printf("✅ From JMdict entries: %d words", fromJMdict)
+	log.Printf("✅ From legacy strings: %d words", fromLegacy)
+	log.Printf("❌ Errors: %d words", errorCount)
+}
@@ -1,113 +0,0 @@
-// Conjugation endings and irregular verb patterns
-// Returns endings and irregularEndings objects for getConjugationEndings function
-
-function getConjugationEndingsData() {
-    const endings = {
-        plain: {
-            casual: { 'い・え　る': '', 'る': '', 'う': '', 'く': '', 'す': '', 'つ': '', 'ぬ': '', 'ぶ': '', 'む': '', 'ぐ': '' },
-            standard: { 'い・え　る': '', 'る': '', 'う': '', 'く': '', 'す': '', 'つ': '', 'ぬ': '', 'ぶ': '', 'む': '', 'ぐ': '' },
-            polite: { 'い・え　る': 'ます', 'る': 'ます', 'う': 'います', 'く': 'きます', 'す': 'します', 'つ': 'ちます', 'ぬ': 'にます', 'ぶ': 'びます', 'む': 'みます', 'ぐ': 'ぎます' },
-            formal: { 'い・え　る': 'ます', 'る': 'ます', 'う': 'います', 'く': 'きます', 'す': 'します', 'つ': 'ちます', 'ぬ': 'にます', 'ぶ': 'びます', 'む': 'みます', 'ぐ': 'ぎます' }
-        },
-        te: {
-            casual: { 'い・え　る': 'て', 'る': 'って', 'う': 'って', 'く': 'いて', 'す': 'して', 'つ': 'って', 'ぬ': 'んで', 'ぶ': 'んで', 'む': 'んで', 'ぐ': 'いで' },
-            standard: { 'い・え　る': 'て', 'る': 'って', 'う': 'って', 'く': 'いて', 'す': 'して', 'つ': 'って', 'ぬ': 'んで', 'ぶ': 'んで', 'む': 'んで', 'ぐ': 'いで' },
-            polite: { 'い・え　る': 'て', 'る': 'って', 'う': 'って', 'く': 'いて', 'す': 'して', 'つ': 'って', 'ぬ': 'んで', 'ぶ': 'んで', 'む': 'んで', 'ぐ': 'いで' },
-            formal: { 'い・え　る': 'て', 'る': 'って', 'う': 'って', 'く': 'いて', 'す': 'して', 'つ': 'って', 'ぬ': 'んで', 'ぶ': 'んで', 'む': 'んで', 'ぐ': 'いで' }
-        },
-        volitional: {
-            casual: { 'い・え　る': 'よう', 'る': 'ろう', 'う': 'おう', 'く': 'こう', 'す': 'そう', 'つ': 'とう', 'ぬ': 'のう', 'ぶ': 'ぼう', 'む': 'もう', 'ぐ': 'ごう' },
-            standard: { 'い・え　る': 'よう', 'る': 'ろう', 'う': 'おう', 'く': 'こう', 'す': 'そう', 'つ': 'とう', 'ぬ': 'のう', 'ぶ': 'ぼう', 'む': 'もう', 'ぐ': 'ごう' },
-            polite: { 'い・え　る': 'ましょう', 'る': 'りましょう', 'う': 'いましょう', 'く': 'きましょう', 'す': 'しましょう', 'つ': 'ちましょう', 'ぬ': 'にましょう', 'ぶ': 'びましょう', 'む': 'みましょう', 'ぐ': 'ぎましょう' },
-            formal: { 'い・え　る': 'ましょう', 'る': 'りましょう', 'う': 'いましょう', 'く': 'きましょう', 'す': 'しましょう', 'つ': 'ちましょう', 'ぬ': 'にましょう', 'ぶ': 'びましょう', 'む': 'みましょう', 'ぐ': 'ぎましょう' }
-        },
-        conditional: {
-            casual: { 'い・え　る': 'たら', 'る': 'ったら', 'う': 'ったら', 'く': 'いたら', 'す': 'したら', 'つ': 'ったら', 'ぬ': 'んだら', 'ぶ': 'んだら', 'む': 'んだら', 'ぐ': 'いだら' },
-            standard: { 'い・え　る': 'れば', 'る': 'れば', 'う': 'えば', 'く': 'けば', 'す': 'せば', 'つ': 'てば', 'ぬ': 'ねば', 'ぶ': 'べば', 'む': 'めば', 'ぐ': 'げば' },
-            polite: { 'い・え　る': 'たら', 'る': 'ったら', 'う': 'ったら', 'く': 'いたら', 'す': 'したら', 'つ': 'ったら', 'ぬ': 'んだら', 'ぶ': 'んだら', 'む': 'んだら', 'ぐ': 'いだら' },
-            formal: { 'い・え　る': 'れば', 'る': 'れば', 'う': 'えば', 'く': 'けば', 'す': 'せば', 'つ': 'てば', 'ぬ': 'ねば', 'ぶ': 'べば', 'む': 'めば', 'ぐ': 'げば' }
-        },
-        causative: {
-            casual: { 'い・え　る': 'させる', 'る': 'らせる', 'う': 'わせる', 'く': 'かせる', 'す': 'させる', 'つ': 'たせる', 'ぬ': 'なせる', 'ぶ': 'ばせる', 'む': 'ませる', 'ぐ': 'がせる' },
-            standard: { 'い・え　る': 'させる', 'る': 'らせる', 'う': 'わせる', 'く': 'かせる', 'す': 'させる', 'つ': 'たせる', 'ぬ': 'なせる', 'ぶ': 'ばせる', 'む': 'ませる', 'ぐ': 'がせる' },
-            polite: { 'い・え　る': 'させます', 'る': 'らせます', 'う': 'わせます', 'く': 'かせます', 'す': 'させます', 'つ': 'たせます', 'ぬ': 'なせます', 'ぶ': 'ばせます', 'む': 'ませます', 'ぐ': 'がせます' },
-            formal: { 'い・え　る': 'させます', 'る': 'らせます', 'う': 'わせます', 'く': 'かせます', 'す': 'させます', 'つ': 'たせます', 'ぬ': 'なせます', 'ぶ': 'ばせます', 'む': 'ませます', 'ぐ': 'がせます' }
-        },
-        potential: {
-            casual: { 'い・え　る': 'られる', 'る': 'れる', 'う': 'える', 'く': 'ける', 'す': 'せる', 'つ': 'てる', 'ぬ': 'ねる', 'ぶ': 'べる', 'む': 'める', 'ぐ': 'げる' },
-            standard: { 'い・え　る': 'られる', 'る': 'れる', 'う': 'える', 'く': 'ける', 'す': 'せる', 'つ': 'てる', 'ぬ': 'ねる', 'ぶ': 'べる', 'む': 'める', 'ぐ': 'げる' },
-            polite: { 'い・え　る': 'られます', 'る': 'れます', 'う': 'えます', 'く': 'けます', 'す': 'せます', 'つ': 'てます', 'ぬ': 'ねます', 'ぶ': 'べます', 'む': 'めます', 'ぐ': 'げます' },
-            formal: { 'い・え　る': 'られます', 'る': 'れます', 'う': 'えます', 'く': 'けます', 'す': 'せます', 'つ': 'てます', 'ぬ': 'ねます', 'ぶ': 'べます', 'む': 'めます', 'ぐ': 'げます' }
-        },
-        desiderative: {
-            casual: { 'い・え　る': 'たい', 'る': 'りたい', 'う': 'いたい', 'く': 'きたい', 'す': 'したい', 'つ': 'ちたい', 'ぬ': 'にたい', 'ぶ': 'びたい', 'む': 'みたい', 'ぐ': 'ぎたい' },
-            standard: { 'い・え　る': 'たい', 'る': 'りたい', 'う': 'いたい', 'く': 'きたい', 'す': 'したい', 'つ': 'ちたい', 'ぬ': 'にたい', 'ぶ': 'びたい', 'む': 'みたい', 'ぐ': 'ぎたい' },
-            polite: { 'い・え　る': 'たいです', 'る': 'りたいです', 'う': 'いたいです', 'く': 'きたいです', 'す': 'したいです', 'つ': 'ちたいです', 'ぬ': 'にたいです', 'ぶ': 'びたいです', 'む': 'みたいです', 'ぐ': 'ぎたいです' },
-            formal: { 'い・え　る': 'たいです', 'る': 'りたいです', 'う': 'いたいです', 'く': 'きたいです', 'す': 'したいです', 'つ': 'ちたいです', 'ぬ': 'にたいです', 'ぶ': 'びたいです', 'む': 'みたいです', 'ぐ': 'ぎたいです' }
-        },
-        deontic: {
-            casual: { 'い・え　る': 'なければならない', 'る': 'らなければならない', 'う': 'わなければならない', 'く': 'かなければならない', 'す': 'さなければならない', 'つ': 'たなければならない', 'ぬ': 'ななければならない', 'ぶ': 'ばなければならない', 'む': 'まなければならない', 'ぐ': 'がなければならない' },
-            standard: { 'い・え　る': 'なければならない', 'る': 'らなければならない', 'う': 'わなければならない', 'く': 'かなければならない', 'す': 'さなければならない', 'つ': 'たなければならない', 'ぬ': 'ななければならない', 'ぶ': 'ばなければならない', 'む': 'まなければならない', 'ぐ': 'がなければならない' },
-            polite: { 'い・え　る': 'なければなりません', 'る': 'らなければなりません', 'う': 'わなければなりません', 'く': 'かければなりません', 'す': 'さなければなりません', 'つ': 'たなければなりません', 'ぬ': 'ななければなりません', 'ぶ': 'ばなければなりません', 'む': 'まなければなりません', 'ぐ': 'がなければなりません' },
-            formal: { 'い・え　る': 'なければなりません', 'る': 'らなければなりません', 'う': 'わなければなりません', 'く': 'かければなりません', 'す': 'さなければなりません', 'つ': 'たなければなりません', 'ぬ': 'ななければなりません', 'ぶ': 'ばなければなりません', 'む': 'まなければなりません', 'ぐ': 'がなければなりません' }
-        },
-        imperative: {
-            casual: { 'い・え　る': 'ろ', 'る': 'れ', 'う': 'え', 'く': 'け', 'す': 'せ', 'つ': 'て', 'ぬ': 'ね', 'ぶ': 'べ', 'む': 'め', 'ぐ': 'げ' },
-            standard: { 'い・え　る': 'ろ', 'る': 'れ', 'う': 'え', 'く': 'け', 'す': 'せ', 'つ': 'て', 'ぬ': 'ね', 'ぶ': 'べ', 'む': 'め', 'ぐ': 'げ' },
-            polite: { 'い・え　る': 'なさい', 'る': 'りなさい', 'う': 'いなさい', 'く': 'きなさい', 'す': 'しなさい', 'つ': 'ちなさい', 'ぬ': 'になさい', 'ぶ': 'びなさい', 'む': 'みなさい', 'ぐ': 'ぎなさい' },
-            formal: { 'い・え　る': 'なさい', 'る': 'りなさい', 'う': 'いなさい', 'く': 'きなさい', 'す': 'しなさい', 'つ': 'ちなさい', 'ぬ': 'になさい', 'ぶ': 'びなさい', 'む': 'みなさい', 'ぐ': 'ぎなさい' }
-        }
-    };
-
-    // Handle irregular verbs
-    const irregularEndings = {
-        'ある': { plain: { casual: 'ある', standard: 'ある', polite: 'あります', formal: 'あります' },
-                  te: { casual: 'あって', standard: 'あって', polite: 'あって', formal: 'あって' },
-                  volitional: { casual: 'あろう', standard: 'あろう', polite: 'ありましょう', formal: 'ありましょう' },
-                  conditional: { casual: 'あったら', standard: 'あれば', polite: 'あったら', formal: 'あれば' },
-                  causative: { casual: 'あらせる', standard: 'あらせる', polite: 'あらせます', formal: 'あらせます' },
-                  potential: { casual: 'あれる', standard: 'あれる', polite: 'あれます', formal: 'あれます' },
-                  desiderative: { casual: 'ありたい', standard: 'ありたい', polite: 'ありたいです', formal: 'ありたいです' },
-                  deontic: { casual: 'あらなければならない', standard: 'あらなければならない', polite: 'あらなければなりません', formal: 'あらなければなりません' },
-                  imperative: { casual: 'あれ', standard: 'あれ', polite: 'ありなさい', formal: 'ありなさい' } },
-        'いる': { plain: { casual: 'いる', standard: 'いる', polite: 'います', formal: 'います' },
-                  te: { casual: 'いて', standard: 'いて', polite: 'いて', formal: 'いて' },
-                  volitional: { casual: 'いよう', standard: 'いよう', polite: 'いましょう', formal: 'いましょう' },
-                  conditional: { casual: 'いたら', standard: 'いれば', polite: 'いたら', formal: 'いれば' },
-                  causative: { casual: 'いさせる', standard: 'いさせる', polite: 'いさせます', formal: 'いさせます' },
-                  potential: { casual: 'いられる', standard: 'いられる', polite: 'いられます', formal: 'いられます' },
-                  desiderative: { casual: 'いたい', standard: 'いたい', polite: 'いたいです', formal: 'いたいです' },
-                  deontic: { casual: 'いなければならない', standard: 'いなければならない', polite: 'いなければなりません', formal: 'いなければなりません' },
-                  imperative: { casual: 'いろ', standard: 'いろ', polite: 'いなさい', formal: 'いなさい' } },
-        '行く': { plain: { casual: '行く', standard: '行く', polite: '行きます', formal: '行きます' },
-                  te: { casual: '行って', standard: '行って', polite: '行って', formal: '行って' },
-                  volitional: { casual: '行こう', standard: '行こう', polite: '行きましょう', formal: '行きましょう' },
-                  conditional: { casual: '行ったら', standard: '行けば', polite: '行ったら', formal: '行けば' },
-                  causative: { casual: '行かせる', standard: '行かせる', polite: '行かせます', formal: '行かせます' },
-                  potential: { casual: '行ける', standard: '行ける', polite: '行けます', formal: '行けます' },
-                  desiderative: { casual: '行きたい', standard: '行きたい', polite: '行きたいです', formal: '行きたいです' },
-                  deontic: { casual: '行かなければならない', standard: '行かなければならない', polite: '行かなければなりません', formal: '行かなければなりません' },
-                  imperative: { casual: '行け', standard: '行け', polite: '行きなさい', formal: '行きなさい' } },
-        'くる': { plain: { casual: 'くる', standard: 'くる', polite: 'きます', formal: 'きます' },
-                  te: { casual: 'きて', standard: 'きて', polite: 'きて', formal: 'きて' },
-                  volitional: { casual: 'こよう', standard: 'こよう', polite: 'きましょう', formal: 'きましょう' },
-                  conditional: { casual: 'きたら', standard: 'くれば', polite: 'きたら', formal: 'くれば' },
-                  causative: { casual: 'こさせる', standard: 'こさせる', polite: 'こさせます', formal: 'こさせます' },
-                  potential: { casual: 'こられる', standard: 'こられる', polite: 'こられます', formal: 'こられます' },
-                  desiderative: { casual: 'きたい', standard: 'きたい', polite: 'きたいです', formal: 'きたいです' },
-                  deontic: { casual: 'こなければならない', standard: 'こなければならない', polite: 'こなければなりません', formal: 'こなければなりません' },
-                  imperative: { casual: 'こい', standard: 'こい', polite: 'きなさい', formal: 'きなさい' } },
-        'する': { plain: { casual: 'する', standard: 'する', polite: 'します', formal: 'します' },
-                  te: { casual: 'して', standard: 'して', polite: 'して', formal: 'して' },
-                  volitional: { casual: 'しよう', standard: 'しよう', polite: 'しましょう', formal: 'しましょう' },
-                  conditional: { casual: 'したら', standard: 'すれば', polite: 'したら', formal: 'すれば' },
-                  causative: { casual: 'させる', standard: 'させる', polite: 'させます', formal: 'させます' },
-                  potential: { casual: 'できる', standard: 'できる', polite: 'できます', formal: 'できます' },
-                  desiderative: { casual: 'したい', standard: 'したい', polite: 'したいです', formal: 'したいです' },
-                  deontic: { casual: 'しなければならない', standard: 'しなければならない', polite: 'しなければなりません', formal: 'しなければなりません' },
-                  imperative: { casual: 'しろ', standard: 'しろ', polite: 'しなさい', formal: 'しなさい' } }
-    };
-
-    return { endings, irregularEndings };
-}
-
@@ -1,135 +0,0 @@
-// CSV-based conjugation patterns (from Japanese_FULL_map_reorganized.csv for casual)
-// Structure: { construction: { base: string, root: string[], conjugation: string, alts: string[] } }
-const conjugationPatterns = {
-    // Tier 1 - Basic forms
-    'simple present': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: '', alts: [] },
-    'past': { base: 'Modified T', root: ['った', 'った', 'った', 'いた', 'いだ', 'んだ', 'んだ', 'んだ', 'した', 'た'], conjugation: '', alts: [] },
-    'negative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', ''], conjugation: 'ない', alts: [] },
-    'continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いる', alts: [] },
-    'completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまう', alts: [] },
-    'resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いる', alts: ['ある'] },
-    'potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'る', alts: [] },
-    'passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れる', alts: [] },
-    'causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せる', alts: [] },
-    'conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ば', alts: ['たら', 'なら', 'と'] },
-    'desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たい', alts: [] },
-    'deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べき', alts: [] },
-    'volitional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', ''], conjugation: 'よう', alts: [] },
-    'imperative': { base: 'Imperative', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'ろ'], conjugation: '', alts: [] },
-    
-    // Tier 2 - Combined forms
-    'past negative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', ''], conjugation: 'なかった', alts: [] },
-    'past continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いた', alts: [] },
-    'past completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまった', alts: [] },
-    'past resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いた', alts: ['てあった'] },
-    'negative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いない', alts: [] },
-    'negative resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'ない', alts: ['あない'] },
-    'negative potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ない', alts: [] },
-    'negative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れない', alts: [] },
-    'negative causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せない', alts: [] },
-    'negative conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なければ', alts: [] },
-    'negative desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくない', alts: [] },
-    'negative deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでない', alts: [] },
-    'continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられる', alts: [] },
-    'continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられる', alts: [] },
-    'continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いれば', alts: [] },
-    'continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたい', alts: [] },
-    'continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべき', alts: [] },
-    'completion continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっている', alts: [] },
-    // Note: completion + resultant must use いる (not ある) because てある cannot follow てしまう
-    'completion resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっている', alts: [] },
-    'completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえる', alts: [] },
-    'completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえば', alts: [] },
-    'completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたい', alts: [] },
-    'completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべき', alts: [] },
-    'negative completion continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていない', alts: [] },
-    'causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せている', alts: [] },
-    'causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せている', alts: ['せてある'] },
-    'causative potential': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられる', alts: [] },
-    'causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられる', alts: [] },
-    'causative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられている', alts: [] },
-    'causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せれば', alts: ['せたら', 'せれるなら', 'せれると'] },
-    'causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたい', alts: [] },
-    'causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべき', alts: [] },
-    'potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れば', alts: [] },
-    'potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たい', alts: [] },
-    'potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべき', alts: [] },
-    'passive conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れれば', alts: ['れたら', 'れるなら', 'れると'] },
-    'passive desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたい', alts: [] },
-    'passive deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れるべき', alts: [] },
-    'conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たければ', alts: [] },
-    'conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきであれば', alts: ['べきなら'] },
-    
-    // Tier 3 - Triple combined forms
-    'past negative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなかった', alts: [] },
-    'past negative completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわなかった', alts: [] },
-    'past negative completion continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていなかった', alts: [] },
-    'past negative completion resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていなかった', alts: [] },
-    'past negative resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'なかった', alts: ['あらなかった'] },
-    'past negative potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なかった', alts: ['えなかった'] },
-    'past negative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れなかった', alts: [] },
-    'past negative causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せなかった', alts: [] },
-    'past negative causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられなかった', alts: [] },
-    'past negative causative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていなかった', alts: [] },
-    'past negative conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なかったら', alts: ['なければ'] },
-    'past negative desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなかった', alts: [] },
-    'past negative deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきなかった', alts: [] },
-    'past continuous completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていた', alts: [] },
-    'past continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられた', alts: [] },
-    'past continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられた', alts: [] },
-    'past continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたら', alts: ['いなければ'] },
-    'past continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたかった', alts: [] },
-    'past continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきだった', alts: [] },
-    'past completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえた', alts: [] },
-    'past completion passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれた', alts: [] },
-    'past completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまったら', alts: ['しまわなければ'] },
-    'past completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたかった', alts: [] },
-    'past completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきだった', alts: [] },
-    'past completion continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていた', alts: [] },
-    'past completion resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていた', alts: [] },
-    'past resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いったら', alts: ['あったら'] },
-    'past potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たら', alts: ['ば', 'れるなら', 'れると'] },
-    'past potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たかった', alts: [] },
-    'past potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきだった', alts: [] },
-    'past passive conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたら', alts: ['れば', 'れるなら', 'れると'] },
-    'past passive desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたかった', alts: [] },
-    'past passive deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れるべきだった', alts: [] },
-    'past continuous causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていた', alts: [] },
-    'past resultant causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていった', alts: ['せてあった'] },
-    'past potential causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられた', alts: [] },
-    'past causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられた', alts: [] },
-    'past causative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていた', alts: [] },
-    'past causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたら', alts: ['せれば', 'せるなら', 'せると'] },
-    'past causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたかった', alts: [] },
-    'past causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきだった', alts: [] },
-    'past conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たかったら', alts: [] },
-    'past conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきだったら', alts: [] },
-    'negative continuous completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていない', alts: [] },
-    'negative continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられない', alts: [] },
-    'negative continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられない', alts: [] },
-    'negative continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなけらば', alts: [] },
-    'negative continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたくない', alts: [] },
-    'negative continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでない', alts: [] },
-    'negative completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえない', alts: [] },
-    'negative completion passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれれない', alts: [] },
-    'negative completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわなければ', alts: ['しまわなったら'] },
-    'negative completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくない', alts: [] },
-    'negative completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきでない', alts: [] },
-    'negative completion resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていない', alts: [] },
-    'negative resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'なければ', alts: ['あれあなければ'] },
-    'negative potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なければ', alts: ['なったら', 'ないなら', 'ないと'] },
-    'negative potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たくない', alts: [] },
-    'negative potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきでない', alts: [] },
-    'negative continuous passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れていない', alts: [] },
-    'negative continuous causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていない', alts: [] },
-    'negative resultant causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていない', alts: ['せてない'] },
-    'negative potential causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられない', alts: [] },
-    'negative causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられない', alts: [] },
-    'negative causative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていない', alts: [] },
-    'negative causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せなければ', alts: ['せたら', 'せるなら', 'せると'] },
-    'negative causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたくない', alts: [] },
-    'negative causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきでない', alts: [] },
-    'negative conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなければ', alts: ['たくなったら', 'たくないなら', 'たくないと'] },
-    'negative conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでなければ', alts: ['べきでなったら', 'べきでないなら', 'べきでないと'] }
-};
-
@@ -1,313 +0,0 @@
-// CSV-based conjugation patterns (from Japanese FULL map Polite.csv for polite/formal)
-// Structure: { construction: { base: string, root: string[], conjugation: string, alts: string[] } }
-const conjugationPatternsPolite = {
-    'causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せます', alts: [] },
-    'causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せれば', alts: ['せたら', 'せれるなら', 'せれると'] },
-    'causative conditional continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていれば', alts: [] },
-    'causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきなら', alts: [] },
-    'causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたければ', alts: [] },
-    'causative conditional resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていれば', alts: ['させてあれば'] },
-    'causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せています', alts: [] },
-    'causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきです', alts: [] },
-    'causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたいです', alts: [] },
-    'causative desiderative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたいです', alts: [] },
-    'causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられます', alts: [] },
-    'causative passive conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきなら', alts: [] },
-    'causative passive conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたければ', alts: [] },
-    'causative passive continuous conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていれば', alts: [] },
-    'causative passive continuous deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられているべきです', alts: [] },
-    'causative passive continuous desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていたいです', alts: [] },
-    'causative potential': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられます', alts: [] },
-    'causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せています', alts: ['せてあります'] },
-    'completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいます', alts: [] },
-    'completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえば', alts: [] },
-    'completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきなら', alts: [] },
-    'completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたければ', alts: [] },
-    'completion continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっています', alts: [] },
-    'completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきです', alts: [] },
-    'completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたいです', alts: [] },
-    'completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえます', alts: [] },
-    'completion potential conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえるべきなら', alts: [] },
-    'completion potential conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたければ', alts: [] },
-    'completion resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっています', alts: [] },
-    'completion resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってあれば', alts: ['しまってあったら', 'しまってあるなら', 'しまってあると'] },
-    'completion resultant negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってないです', alts: ['しまっていません'] },
-    'completion resultant past': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってあったです', alts: [] },
-    'conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'れ'], conjugation: 'ば', alts: ['たら', 'なら', 'と'] },
-    'conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきであれば', alts: ['べきなら'] },
-    'conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たければ', alts: [] },
-    'conditional desiderative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたければ', alts: [] },
-    'continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'います', alts: [] },
-    'continuous completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていれば', alts: ['しまっていたら', 'しまっているなら', 'しまっていると'] },
-    'continuous completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきなら', alts: [] },
-    'continuous completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたければ', alts: [] },
-    'continuous completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきです', alts: [] },
-    'continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いれば', alts: [] },
-    'continuous conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきなら', alts: [] },
-    'continuous conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたければ', alts: [] },
-    'continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきです', alts: [] },
-    'continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたいです', alts: [] },
-    'continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられます', alts: [] },
-    'continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられます', alts: [] },
-    'continuous potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられれば', alts: ['いれたら', 'いれるなら', 'いれると'] },
-    'continuous potential conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきなら', alts: [] },
-    'continuous potential conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたければ', alts: [] },
-    'continuous potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきです', alts: [] },
-    'continuous potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたいです', alts: [] },
-    'continuous resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いてあれば', alts: ['いてあったら', 'いてあるなら', 'いてあると'] },
-    'continuous resultant negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いてないです', alts: ['いていません'] },
-    'continuous resultant past': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いていたです', alts: ['いてあったです'] },
-    'deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べき', alts: [] },
-    'desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たいです', alts: [] },
-    'imperative': { base: 'Imperative', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'ろ'], conjugation: '', alts: [] },
-    'negative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'ません', alts: [] },
-    'negative causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せません', alts: [] },
-    'negative causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せなければ', alts: ['せたら', 'せるなら', 'せると'] },
-    'negative causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきでなければ', alts: [] },
-    'negative causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたくなければ', alts: [] },
-    'negative causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていないです', alts: ['せていません'] },
-    'negative causative continuous conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていなければ', alts: [] },
-    'negative causative continuous conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたくなければ', alts: [] },
-    'negative causative continuous deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せているべきでないです', alts: ['せているべきではありません'] },
-    'negative causative continuous desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたくないです', alts: ['せていたくありません'] },
-    'negative causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきでないです', alts: [] },
-    'negative causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたくないです', alts: [] },
-    'negative causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられないです', alts: ['せられません'] },
-    'negative causative potential': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられないです', alts: ['せられません'] },
-    'negative causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていないです', alts: ['せていません'] },
-    'negative causative resultant conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せてなければ', alts: [] },
-    'negative completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわなければ', alts: ['しまわなったら'] },
-    'negative completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきでなければ', alts: [] },
-    'negative completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくなければ', alts: [] },
-    'negative completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきでないです', alts: [] },
-    'negative completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくないです', alts: [] },
-    'negative completion passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれれないです', alts: ['しまわれれません'] },
-    'negative completion passive conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれなければ', alts: [] },
-    'negative completion passive deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれるべきでないです', alts: ['しまわれるべきではありません'] },
-    'negative completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえないです', alts: ['しまえません'] },
-    'negative completion potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえなければ', alts: [] },
-    'negative completion potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえるべきでないです', alts: ['しまえるべきではありません'] },
-    'negative completion potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたくないです', alts: ['しまえたくありません'] },
-    'negative completion resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってなければ', alts: [] },
-    'negative conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なければ', alts: [] },
-    'negative conditional continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなければ', alts: ['いったら', 'いるなら', 'いると'] },
-    'negative conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでなければ', alts: ['べきでなったら', 'べきでないなら', 'べきでないと'] },
-    'negative conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなければ', alts: ['たくなったら', 'たくないなら', 'たくないと'] },
-    'negative conditional passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れなければ', alts: ['なったら', 'ないなら', 'ないと'] },
-    'negative conditional potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れなければ', alts: ['たら', 'るなら', 'ると'] },
-    'negative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いません', alts: [] },
-    'negative continuous completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていないです', alts: ['しまっていません'] },
-    'negative continuous completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていなければ', alts: [] },
-    'negative continuous completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきでないです', alts: ['しまっているべきではありません'] },
-    'negative continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなけらば', alts: [] },
-    'negative continuous conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでなければ', alts: [] },
-    'negative continuous conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたくなければ', alts: [] },
-    'negative continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでないです', alts: [] },
-    'negative continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたくないです', alts: [] },
-    'negative continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられないです', alts: ['いられません'] },
-    'negative continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられないです', alts: ['いられません'] },
-    'negative continuous potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられなければ', alts: [] },
-    'negative continuous potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきでないです', alts: ['いられるべきではありません'] },
-    'negative continuous potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたくないです', alts: ['いられたくありません'] },
-    'negative continuous resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いてなければ', alts: [] },
-    'negative deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでない', alts: [] },
-    'negative deontic past': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでなかったです', alts: [] },
-    'negative desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくありません', alts: ['たくないです'] },
-    'negative desiderative completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくないです', alts: [] },
-    'negative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れません', alts: [] },
-    'negative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れていないです', alts: ['れていません'] },
-    'negative potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ません', alts: [] },
-    'negative potential causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられなければ', alts: [] },
-    'negative potential causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきでなければ', alts: [] },
-    'negative potential causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたくなければ', alts: [] },
-    'negative potential causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていないです', alts: ['せられていません'] },
-    'negative potential causative continuous conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていなければ', alts: [] },
-    'negative potential causative continuous deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられているべきでないです', alts: ['せられているべきではありません'] },
-    'negative potential causative continuous desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていたくないです', alts: ['せられていたくありません'] },
-    'negative potential causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきでないです', alts: ['せられるべきではありません'] },
-    'negative potential causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたくないです', alts: ['せられたくありません'] },
-    'negative potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なければ', alts: ['なったら', 'ないなら', 'ないと'] },
-    'negative potential conditional deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきでなければ', alts: [] },
-    'negative potential conditional desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たくなければ', alts: [] },
-    'negative potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきでないです', alts: [] },
-    'negative potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たくないです', alts: [] },
-    'negative potential resultant conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'てなければ', alts: [] },
-    'negative potential resultant past': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'てなかったです', alts: ['ていませんでした'] },
-    'negative resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いません', alts: ['ありません'] },
-    'negative resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'なければ', alts: ['あれあなければ'] },
-    'passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れます', alts: [] },
-    'passive conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れれば', alts: ['れたら', 'れるなら', 'れると'] },
-    'passive deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れるべきです', alts: [] },
-    'passive desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたいです', alts: [] },
-    'past': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'ました', alts: [] },
-    'past causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せました', alts: [] },
-    'past causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたら', alts: ['せれば', 'せるなら', 'せると'] },
-    'past causative conditional continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたら', alts: [] },
-    'past causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきだったら', alts: [] },
-    'past causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたかったら', alts: [] },
-    'past causative conditional resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたら', alts: ['させてあったら'] },
-    'past causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたです', alts: ['せていました'] },
-    'past causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきだったです', alts: [] },
-    'past causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたかったです', alts: [] },
-    'past causative desiderative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたかった', alts: [] },
-    'past causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられた', alts: ['せられました'] },
-    'past causative potential': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたです', alts: ['せられました'] },
-    'past causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていったです', alts: ['せてあったです'] },
-    'past completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいました', alts: [] },
-    'past completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまったら', alts: ['しまわなければ'] },
-    'past completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきだったら', alts: [] },
-    'past completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたかったら', alts: [] },
-    'past completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきだったです', alts: [] },
-    'past completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたかったです', alts: [] },
-    'past completion desiderative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたかったです', alts: [] },
-    'past completion passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれたです', alts: ['しまわれました'] },
-    'past completion passive conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれたら', alts: [] },
-    'past completion passive deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれるべきだったです', alts: [] },
-    'past completion potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたです', alts: ['しまえました'] },
-    'past completion potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたら', alts: [] },
-    'past completion potential conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたかったら', alts: [] },
-    'past completion potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえるべきだったです', alts: [] },
-    'past completion potential deontic conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえるべきだったら', alts: [] },
-    'past completion potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたかったです', alts: [] },
-    'past completion resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってあったら', alts: [] },
-    'past completion resultant negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまってなかったです', alts: ['しまっていませんでした'] },
-    'past conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たら', alts: [] },
-    'past conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきだったら', alts: [] },
-    'past conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たかったら', alts: [] },
-    'past conditional desiderative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたかったら', alts: [] },
-    'past continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いません', alts: [] },
-    'past continuous completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたです', alts: ['しまっていました'] },
-    'past continuous completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたら', alts: [] },
-    'past continuous completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきだったら', alts: [] },
-    'past continuous completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたかったら', alts: [] },
-    'past continuous completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきだったです', alts: [] },
-    'past continuous completion desiderative negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていたくなかったです', alts: ['しまっていたくありませんでした'] },
-    'past continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたら', alts: ['いなければ'] },
-    'past continuous conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきだったら', alts: [] },
-    'past continuous conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたかったら', alts: [] },
-    'past continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきだったです', alts: [] },
-    'past continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたかったです', alts: [] },
-    'past continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたです', alts: ['いられました'] },
-    'past continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたです', alts: ['いられました'] },
-    'past continuous potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたら', alts: [] },
-    'past continuous potential conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきだったら', alts: [] },
-    'past continuous potential conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたかったら', alts: [] },
-    'past continuous potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきだったです', alts: [] },
-    'past continuous potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたかったです', alts: [] },
-    'past continuous resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いていったら', alts: ['ていてあったら'] },
-    'past continuous resultant negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いてなかったです', alts: ['いていませんでした'] },
-    'past deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきだったです', alts: [] },
-    'past desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たかったです', alts: [] },
-    'past desiderative negative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなかったです', alts: [] },
-    'past negative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'ませんでした', alts: [] },
-    'past negative causative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せなかったです', alts: ['せませんでした'] },
-    'past negative causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せなかったら', alts: [] },
-    'past negative causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきでなかったら', alts: [] },
-    'past negative causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたくなかったら', alts: [] },
-    'past negative causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていなかったです', alts: ['せていませんでした'] },
-    'past negative causative continuous conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていなかったら', alts: [] },
-    'past negative causative continuous deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せているべきでなかったです', alts: ['せているべきではありませんでした'] },
-    'past negative causative continuous desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せていたくなかったです', alts: ['せていたくありませんでした'] },
-    'past negative causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せるべきでなかったです', alts: ['せるべきではありませんでした'] },
-    'past negative causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せたくなかったです', alts: ['せたくありませんでした'] },
-    'past negative causative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられなかったです', alts: ['せられませんでした'] },
-    'past negative causative potential': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられなかったです', alts: ['せられませんでした'] },
-    'past negative causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せてなかったです', alts: ['せてませんでした'] },
-    'past negative completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわなかったです', alts: ['しまわませんでした'] },
-    'past negative completion conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわなかったら', alts: [] },
-    'past negative completion conditional continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていなかったら', alts: [] },
-    'past negative completion conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきでなかったら', alts: [] },
-    'past negative completion conditional desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくなかったら', alts: [] },
-    'past negative completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまうべきでなかったです', alts: ['しまうべきではありませんでした'] },
-    'past negative completion desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまいたくなかったです', alts: ['しまいたくありませんでした'] },
-    'past negative completion passive conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれなかったら', alts: [] },
-    'past negative completion passive deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまわれるべきでなかったです', alts: ['しまわれれべきではありませんでした'] },
-    'past negative completion potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえなかったら', alts: [] },
-    'past negative completion potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえるべきでなかったです', alts: ['しまえるべきではありませんでした'] },
-    'past negative completion potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまえたくなかったです', alts: ['しまえたくありませんでした'] },
-    'past negative conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なかったら', alts: ['なければ'] },
-    'past negative conditional deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきでなかったら', alts: [] },
-    'past negative conditional desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなかったら', alts: [] },
-    'past negative continuous': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなかったです', alts: ['いませんでした'] },
-    'past negative continuous completion': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっていなかったです', alts: ['しまっていませんでした'] },
-    'past negative continuous completion deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'しまっているべきでなかったです', alts: ['しまっているべきではありませんでした'] },
-    'past negative continuous conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いなかったら', alts: [] },
-    'past negative continuous conditional deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでなかったら', alts: [] },
-    'past negative continuous deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでなかったです', alts: [] },
-    'past negative continuous deontic conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いるべきでなかったら', alts: [] },
-    'past negative continuous desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたくなかったです', alts: [] },
-    'past negative continuous desiderative conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いたくなかったら', alts: [] },
-    'past negative continuous passive': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられなかったです', alts: ['いられませんでした'] },
-    'past negative continuous potential': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられなかったです', alts: ['いられませんでした'] },
-    'past negative continuous potential conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられなかったら', alts: [] },
-    'past negative continuous potential deontic': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられるべきでなかったです', alts: ['いられるべきではありませんでした'] },
-    'past negative continuous potential desiderative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いられたくなかったです', alts: ['いられたくありませんでした'] },
-    'past negative deontic': { base: 'Dictionary', root: ['う', 'つ', 'る', 'く', 'ぐ', 'む', 'ぬ', 'ぶ', 'す', 'る'], conjugation: 'べきなかったです', alts: [] },
-    'past negative desiderative': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'たくなかったです', alts: [] },
-    'past negative passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れなかったです', alts: ['れませんでした'] },
-    'past negative passive causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられなかったら', alts: [] },
-    'past negative passive causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていなかったです', alts: ['せられていませんでした'] },
-    'past negative passive causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきでなかったです', alts: ['せられるべきではありませんでした'] },
-    'past negative passive causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたくなかったです', alts: ['せられたくありませんでした'] },
-    'past negative passive conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れなかったら', alts: [] },
-    'past negative passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れていなかったです', alts: ['れていませんでした'] },
-    'past negative passive deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れるべきでなかったです', alts: ['れるべきではありませんでした'] },
-    'past negative passive desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたくなかったです', alts: ['れたくありませんでした'] },
-    'past negative potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なかったです', alts: ['ませんでした'] },
-    'past negative potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'なかったら', alts: [] },
-    'past negative potential conditional deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきでなかったら', alts: [] },
-    'past negative potential conditional desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たくなかったら', alts: [] },
-    'past negative potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきでなかったです', alts: ['るべきではありませんでした'] },
-    'past negative potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たくなかったです', alts: ['たくありませんでした'] },
-    'past negative resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'なかったです', alts: ['あらなかった'] },
-    'past negative resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'なかったら', alts: [] },
-    'past passive': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', ''], conjugation: 'れました', alts: [] },
-    'past passive conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたら', alts: ['れば', 'れるなら', 'れると'] },
-    'past passive continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れていたです', alts: ['らていました'] },
-    'past passive deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れるべきだったです', alts: [] },
-    'past passive desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'ら'], conjugation: 'れたかったです', alts: [] },
-    'past potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ました', alts: [] },
-    'past potential causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたら', alts: [] },
-    'past potential causative conditional deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきだったら', alts: [] },
-    'past potential causative conditional desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたかったら', alts: [] },
-    'past potential causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていたです', alts: ['せられていました'] },
-    'past potential causative continuous deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられているべきだったです', alts: [] },
-    'past potential causative continuous desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていたかったです', alts: [] },
-    'past potential causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきだったです', alts: [] },
-    'past potential causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたかったです', alts: [] },
-    'past potential causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられていたです', alts: ['せられてあったです', 'せられていました', 'せられてありました'] },
-    'past potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たら', alts: ['ば', 'れるなら', 'れると'] },
-    'past potential conditional deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきだったら', alts: [] },
-    'past potential conditional desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たかったら', alts: [] },
-    'past potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきだったです', alts: [] },
-    'past potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たかったです', alts: [] },
-    'past potential desiderative continuous': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ていたかったです', alts: [] },
-    'past resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いました', alts: ['ありました'] },
-    'past resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いったら', alts: ['あったら'] },
-    'potential': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'ます', alts: [] },
-    'potential causative conditional': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられれば', alts: [] },
-    'potential causative continuous': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられています', alts: [] },
-    'potential causative deontic': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられるべきです', alts: [] },
-    'potential causative desiderative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられたいです', alts: [] },
-    'potential causative resultant': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', 'さ'], conjugation: 'せられています', alts: ['させられてあります'] },
-    'potential conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れば', alts: [] },
-    'potential conditional deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れるべきなら', alts: [] },
-    'potential conditional desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れたければ', alts: [] },
-    'potential deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'るべきです', alts: [] },
-    'potential deontic negative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れるべきでないです', alts: ['れるべきではありません'] },
-    'potential desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'たいです', alts: [] },
-    'potential desiderative continuous': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れていたいです', alts: [] },
-    'potential passive conditional': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられれば', alts: [] },
-    'potential passive continuous': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられている', alts: ['れられています'] },
-    'potential passive deontic': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられるべきです', alts: [] },
-    'potential passive desiderative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられたいです', alts: [] },
-    'potential passive negative': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられないです', alts: ['れられません'] },
-    'potential passive past': { base: 'Hypothetical', root: ['え', 'て', 'れ', 'け', 'げ', 'め', 'ね', 'べ', 'せ', 'られ'], conjugation: 'れられたです', alts: ['れられました'] },
-    'resultant': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'います', alts: ['あります'] },
-    'resultant conditional': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いれば', alts: ['あれば'] },
-    'resultant conditional negative': { base: 'Irrealis / Imperfective', root: ['わ', 'た', 'ら', 'か', 'が', 'ま', 'な', 'ば', 'さ', ''], conjugation: 'なければ', alts: [] },
-    'resultant negative': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'ないです', alts: ['あない'] },
-    'resultant past': { base: 'T Form', root: ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'], conjugation: 'いました', alts: ['ありました'] },
-    'simple present': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'ます', alts: [] },
-    'volitional': { base: 'Continuative / Stem', root: ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''], conjugation: 'ましょう', alts: [] }
-};
@@ -0,0 +1,17 @@
+// Furigana helper shared by the conjugation chart and the verb grid
+
+// Adds the text with its reading as furigana above it, unless the text is already kana
+function appendWithFurigana(parent, text, reading) {
+    if (!reading || reading === text) {
+        parent.appendChild(document.createTextNode(text));
+        return;
+    }
+    const ruby = document.createElement('ruby');
+    ruby.appendChild(document.createTextNode(text));
+    const rt = document.createElement('rt');
+    rt.style.fontSize = '11px';
+    rt.style.fontWeight = 'normal';
+    rt.textContent = reading;
+    ruby.appendChild(rt);
+    parent.appendChild(ruby);
+}
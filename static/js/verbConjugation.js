@@ -1,4 +1,5 @@
 // Verb Conjugation Chart JavaScript
+// Needs furigana.js (appendWithFurigana)
 
 // Global state for toggles
 let isNegative = false;
@@ -87,7 +88,11 @@ document.addEventListener('DOMContentLoaded', function() {
             }
 
             // Show verb type with modifiers
-            showVerbType(data.verb, data.verbType, isNegative, isPolite);
+            if (data.wordType === 'adjective') {
+                showVerbType(data.verb, data.adjectiveType, isNegative, isPolite);
+            } else {
+                showVerbType(data.verb, `${data.verbType} verb`, isNegative, isPolite);
+            }
 
             // Populate conjugation chart
             populateChart(data.conjugations);
@@ -115,7 +120,7 @@ document.addEventListener('DOMContentLoaded', function() {
         if (negative) modifiers.push('negative');
         if (polite) modifiers.push('polite');
         
-        let text = `${verb} is a ${type} verb`;
+        let text = `${verb} is a ${type}`;
         if (modifiers.length > 0) {
             text += ` (${modifiers.join(' + ')})`;
         }
@@ -196,11 +201,25 @@ document.addEventListener('DOMContentLoaded', function() {
             japaneseCell.style.fontSize = '20px';
             japaneseCell.style.fontWeight = 'bold';
             
-            let japaneseText = data.japanese;
+            appendWithFurigana(japaneseCell, data.japanese, data.reading);
             if (data.alts && data.alts.length > 0) {
-                japaneseText += ` (${data.alts.join(', ')})`;
+                japaneseCell.appendChild(document.createTextNode(' ('));
+                data.alts.forEach((alt, i) => {
+                    if (i > 0) {
+                        japaneseCell.appendChild(document.createTextNode(', '));
+                    }
+                    appendWithFurigana(japaneseCell, alt, data.altReadings && data.altReadings[i]);
+                });
+                japaneseCell.appendChild(document.createTextNode(')'));
+            }
+            if (data.romaji) {
+                const romaji = document.createElement('div');
+                romaji.style.fontSize = '14px';
+                romaji.style.fontWeight = 'normal';
+                romaji.style.color = '#666';
+                romaji.textContent = data.romaji;
+                japaneseCell.appendChild(romaji);
             }
-            japaneseCell.textContent = japaneseText;
             
             row.appendChild(formCell);
             row.appendChild(englishCell);
@@ -2,7 +2,8 @@
 
 // Current verb being conjugated
 let currentVerb = '食べる';
-let currentVerbData = null;
+// The current construction at each formality, from /api/verb/grid
+let currentCells = {};
 
 // Current selected mood
 let currentMood = 'plain';
@@ -42,139 +43,9 @@ const controls = {
 // Mode state: 'linguistic' or 'common'
 let currentMode = 'Linguistic';
 
-// Constants are now loaded from separate files:
-// - verbConstants.js (verbExamples, fullVerbExamples, endingToIndex, ichidanRoots)
-// - conjugationPatterns.js (conjugationPatterns)
+// Loaded from separate files:
 // - conjugationTranslations.js (conjugationTranslations)
-// - conjugationEndings.js (getConjugationEndingsData function)
-
-// CSV-based conjugation patterns (from Japanese_FULL_map_reorganized.csv for casual)
-// Structure: { construction: { base: string, root: string[], conjugation: string, alts: string[] } }
-// Note: conjugationPatterns is now defined in conjugationPatterns.js
-// conjugationPatterns is loaded from conjugationPatterns.js
-    // Tier 1 - Basic forms
-
-// Get verb ending index (handles ichidan vs godan ru)
-function getVerbEndingIndex(verb) {
-    const lastChar = verb[verb.length - 1];
-    if (lastChar === 'る' && verb.length > 1) {
-        const secondLast = verb[verb.length - 2];
-        // Ichidan verbs end in いる or える sounds
-        if (ichidanRoots[secondLast]) {
-            return 9; // ichidan
-        }
-        return 2; // godan ru
-    }
-    return endingToIndex[lastChar] !== undefined ? endingToIndex[lastChar] : 2;
-}
-
-// Build construction name from active controls
-// CSV column 2 order: tense → negation → voice → aspect → mood
-function getConstructionName() {
-    const parts = [];
-    
-    // Build construction name in the order it appears in CSV column 2:
-    // tense → negation → voice → aspect → mood
-    
-    // 1. TENSE (past or simple present - simple present is default/implied)
-    const isPast = controls.past;
-    
-    // 2. NEGATION
-    const isNegative = controls.negative;
-    
-    // 3. VOICE (potential, passive, causative)
-    const hasPotential = controls.potential;
-    const hasPassive = controls.passive;
-    const hasCausative = controls.causative;
-    
-    // 4. ASPECT (continuous, completion, resultant)
-    const hasContinuous = controls.continuous;
-    const hasCompletion = controls.completion;
-    const hasResultant = controls.resultant;
-    
-    // 5. MOOD (conditional, desiderative, deontic)
-    const hasConditional = controls.conditional;
-    const hasDesiderative = controls.desiderative;
-    const hasDeontic = controls.deontic;
-    
-    // Build name in CSV order: tense → negation → voice → aspect → mood
-    
-    // 1. Start with tense
-    if (isPast) parts.push('past');
-    
-    // 2. Add negation
-    if (isNegative) parts.push('negative');
-    
-    // 3. Add voice (mutually exclusive in practice, but handle combinations from CSV)
-    // Standardize to "causative passive" order when both are present
-    if (hasPotential && hasPassive) {
-        parts.push('potential');
-        parts.push('passive');
-    } else if (hasPotential && hasCausative) {
-        parts.push('potential');
-        parts.push('causative');
-    } else if (hasCausative && hasPassive) {
-        // Always use "causative passive" order for consistency
-        parts.push('causative');
-        parts.push('passive');
-    } else if (hasPotential) {
-        parts.push('potential');
-    } else if (hasPassive) {
-        parts.push('passive');
-    } else if (hasCausative) {
-        parts.push('causative');
-    }
-    
-    // 4. Add aspect (can have multiple, but CSV shows specific combinations)
-    // Note: continuous, completion, resultant use T Form base
-    if (hasCompletion && hasContinuous) {
-        parts.push('completion');
-        parts.push('continuous');
-    } else if (hasCompletion && hasResultant) {
-        parts.push('completion');
-        parts.push('resultant');
-    } else if (hasContinuous && hasResultant) {
-        parts.push('continuous');
-        parts.push('resultant');
-    } else if (hasContinuous) {
-        parts.push('continuous');
-    } else if (hasCompletion) {
-        parts.push('completion');
-    } else if (hasResultant) {
-        parts.push('resultant');
-    }
-    
-    // 5. Add mood (conditional, desiderative, deontic are modifiers)
-    // Note: volitional and imperative are handled separately as base constructions
-    if (hasConditional && hasDesiderative) {
-        parts.push('conditional');
-        parts.push('desiderative');
-    } else if (hasConditional && hasDeontic) {
-        parts.push('conditional');
-        parts.push('deontic');
-    } else if (hasDesiderative) {
-        parts.push('desiderative');
-    } else if (hasDeontic) {
-        parts.push('deontic');
-    } else if (hasConditional) {
-        parts.push('conditional');
-    }
-    
-    // If no modifiers, return basic form
-    if (parts.length === 0) return 'simple present';
-    
-    // Join parts
-    const constructionName = parts.join(' ');
-    
-    // Check if this exact pattern exists, if not try simpler combinations
-    if (conjugationPatterns[constructionName]) {
-        return constructionName;
-    }
-    
-    // Try to find closest match or build from components
-    // For now, return what we have - we'll handle missing patterns in applyConjugationPattern
-    return constructionName;
-}
+// - furigana.js (appendWithFurigana)
 
 // Get active voice (potential or causative)
 function getActiveVoice() {
@@ -965,306 +836,62 @@ function toggleControl(controlName) {
     }
 }
 
-// Get conjugation endings for a specific form
-// Note: endings and irregularEndings are now loaded from conjugationEndings.js
-function getConjugationEndings(form, formality) {
-    // Get data from conjugationEndings.js
-    const endingsData = getConjugationEndingsData();
-    const endings = endingsData.endings;
-    const irregularEndings = endingsData.irregularEndings;
-    
-    return { endings: endings[form]?.[formality] || {}, irregularEndings };
-}
-
-// Get full verb conjugations
-function getFullVerbConjugations(form, formality) {
-    const stems = {
-        'い・え　る': '食べ',  // ichidan verb
-        'る': '作',           // godan ru
-        'う': '買',           // godan u
-        'く': '歩',           // godan ku
-        'す': '話',           // godan su
-        'つ': '持',           // godan tsu
-        'ぬ': '死',           // godan nu
-        'ぶ': '飛',           // godan bu
-        'む': '飲',           // godan mu
-        'ぐ': '急'            // godan gu
-    };
-
-    const conjugations = getConjugationEndings(form, formality);
-    const fullVerbs = {};
-
-    for (const [ending, stem] of Object.entries(stems)) {
-        const endingText = conjugations.endings[ending] || '';
-        fullVerbs[ending] = stem + endingText;
-    }
-
-    return fullVerbs;
-}
-
+// Update all grid cells with the API's forms, with their readings as furigana
+function updateGridCells() {
+    const formalities = ['casual', 'standard', 'polite', 'formal'];
 
-// Apply conjugation pattern to a verb
-// Japanese construction order: stem → voice → aspect → tense → mood
-// The CSV patterns encode the full transformation, so we apply them directly
-function applyConjugationPattern(verb, pattern, verbEndingIndex) {
-    if (!pattern) {
-        // If pattern not found, try to build from components
-        console.warn('Pattern not found, using fallback');
-        return verb;
-    }
-    
-    let stem = '';
-    const lastChar = verb[verb.length - 1];
-    
-    // Get base form based on pattern.base
-    // This extracts the stem and applies the appropriate base transformation
-    if (pattern.base === 'Dictionary') {
-        // Use verb as-is (for deontic, simple present)
-        stem = verb;
-    } else if (pattern.base === 'Continuative / Stem') {
-        // Get stem form
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る
-            stem = verb.slice(0, -1);
-        } else {
-            // Godan: change last character to stem form
-            const stemEndings = ['い', 'ち', 'り', 'き', 'ぎ', 'み', 'に', 'び', 'し', ''];
-            stem = verb.slice(0, -1) + stemEndings[verbEndingIndex];
-        }
-    } else if (pattern.base === 'Irrealis / Imperfective') {
-        // Get negative stem (Irrealis form)
-        const rootEnding = pattern.root[verbEndingIndex];
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, then add root if not ''
-            stem = verb.slice(0, -1);
-            if (rootEnding !== '') {
-                stem = stem + rootEnding;
-            }
-        } else {
-            // Godan: use root from pattern
-            if (rootEnding === '') {
-                stem = verb.slice(0, -1); // ichidan
-            } else {
-                stem = verb.slice(0, -1) + rootEnding;
-            }
-        }
-    } else if (pattern.base === 'Hypothetical') {
-        // Get hypothetical form
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, add hypothetical
-            stem = verb.slice(0, -1) + 'れ';
-        } else {
-            // Godan: use root from pattern
-            const rootEnding = pattern.root[verbEndingIndex];
-            stem = verb.slice(0, -1) + rootEnding;
-        }
-    } else if (pattern.base === 'T Form') {
-        // Get te-form
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, add て
-            stem = verb.slice(0, -1) + 'て';
-        } else {
-            // Godan: use root from pattern
-            const rootEnding = pattern.root[verbEndingIndex];
-            stem = verb.slice(0, -1) + rootEnding;
-        }
-    } else if (pattern.base === 'Modified T') {
-        // Past form (ta-form)
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, add た
-            stem = verb.slice(0, -1) + 'た';
-        } else {
-            // Godan: use root from pattern
-            const rootEnding = pattern.root[verbEndingIndex];
-            stem = verb.slice(0, -1) + rootEnding;
-        }
-    } else if (pattern.base === 'Imperative') {
-        // Imperative form
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, add ろ
-            stem = verb.slice(0, -1) + 'ろ';
-        } else {
-            // Godan: use root from pattern
-            const rootEnding = pattern.root[verbEndingIndex];
-            stem = verb.slice(0, -1) + rootEnding;
+    formalities.forEach(formality => {
+        const cell = document.getElementById(formality + 'Cell');
+        if (cell) {
+            const entry = currentCells[formality] || {};
+            cell.textContent = '';
+            appendWithFurigana(cell, entry.japanese || '', entry.reading);
+            cell.dataset.answers = JSON.stringify(getAcceptedAnswers(entry));
         }
-    }
-    
-    // Add conjugation ending
-    return stem + pattern.conjugation;
+    });
+    clearPracticeResult();
 }
 
-// Build full construction name including base mood when appropriate
-function getFullConstructionName() {
-    const parts = [];
-    
-    // 1. TENSE (past or simple present - simple present is default/implied)
-    if (controls.past) parts.push('past');
-    
-    // 2. NEGATION
-    if (controls.negative) parts.push('negative');
-    
-    // 3. VOICE (potential, passive, causative)
-    if (controls.potential && controls.passive) {
-        parts.push('potential');
-        parts.push('passive');
-    } else if (controls.potential && controls.causative) {
-        parts.push('potential');
-        parts.push('causative');
-    } else if (controls.causative && controls.passive) {
-        parts.push('causative');
-        parts.push('passive');
-    } else if (controls.potential) {
-        parts.push('potential');
-    } else if (controls.passive) {
-        parts.push('passive');
-    } else if (controls.causative) {
-        parts.push('causative');
-    }
-    
-    // 4. ASPECT (continuous, completion, resultant)
-    if (controls.completion && controls.continuous) {
-        parts.push('completion');
-        parts.push('continuous');
-    } else if (controls.completion && controls.resultant) {
-        parts.push('completion');
-        parts.push('resultant');
-    } else if (controls.continuous && controls.resultant) {
-        parts.push('continuous');
-        parts.push('resultant');
-    } else if (controls.continuous) {
-        parts.push('continuous');
-    } else if (controls.completion) {
-        parts.push('completion');
-    } else if (controls.resultant) {
-        parts.push('resultant');
-    }
-    
-    // 5. MOOD modifiers (conditional, desiderative, deontic)
-    if (controls.conditional && controls.desiderative) {
-        parts.push('conditional');
-        parts.push('desiderative');
-    } else if (controls.conditional && controls.deontic) {
-        parts.push('conditional');
-        parts.push('deontic');
-    } else if (controls.desiderative) {
-        parts.push('desiderative');
-    } else if (controls.deontic) {
-        parts.push('deontic');
-    } else if (controls.conditional) {
-        parts.push('conditional');
-    }
-    
-    // Handle base moods (volitional, imperative) - these are standalone patterns
-    // Note: te-form is handled specially as it has no pattern when standalone
-    if (controls.volitional && parts.length === 0) {
-        return 'volitional';
-    }
-    if (controls.imperative && parts.length === 0) {
-        return 'imperative';
-    }
-    
-    // If no parts, return basic form
-    if (parts.length === 0) return 'simple present';
-    
-    // Join parts
-    return parts.join(' ');
+// Get every answer a grid cell accepts: the form, its reading, and its alternatives
+// (書けば also takes 書いたら and かいたら)
+function getAcceptedAnswers(entry) {
+    const answers = [entry.japanese, entry.reading, ...(entry.alts || []), ...(entry.altReadings || [])];
+    return [...new Set(answers.filter(Boolean))];
 }
 
-// Get conjugated form for a specific form and formality
-function getConjugatedForm(form, formality) {
-    if (!currentVerb) {
-        return '';
-    }
-
-    const verbEndingIndex = getVerbEndingIndex(currentVerb);
-    
-    // Handle standalone te-form (no modifiers) - build te-form directly
-    if (controls.te && !controls.continuous && !controls.completion && !controls.resultant && 
-        !controls.negative && !controls.past && !controls.potential && !controls.passive && 
-        !controls.causative && !controls.conditional && !controls.desiderative && !controls.deontic) {
-        // Build te-form directly using T Form roots
-        if (verbEndingIndex === 9) {
-            // Ichidan: remove る, add て
-            return currentVerb.slice(0, -1) + 'て';
-        } else {
-            // Godan: use T Form roots
-            const teRoots = ['って', 'って', 'って', 'いて', 'いで', 'んで', 'んで', 'んで', 'して', 'て'];
-            return currentVerb.slice(0, -1) + teRoots[verbEndingIndex];
+// Hide or show the grid's forms, to practice them from memory
+function togglePracticeMode(hidden) {
+    ['casual', 'standard', 'polite', 'formal'].forEach(formality => {
+        const cell = document.getElementById(formality + 'Cell');
+        if (cell) {
+            cell.style.visibility = hidden ? 'hidden' : 'visible';
         }
-    }
-    
-    // Build construction name from active buttons
-    const constructionName = getFullConstructionName();
-    
-    // Select pattern set based on formality
-    const patternSet = (formality === 'polite' || formality === 'formal') 
-        ? conjugationPatternsPolite 
-        : conjugationPatterns;
-    
-    // Look up pattern
-    const pattern = patternSet[constructionName];
-    
-    if (pattern) {
-        // Apply pattern: verb stem + root[verbEndingIndex] + conjugation
-        return applyConjugationPattern(currentVerb, pattern, verbEndingIndex);
-    }
-    
-    // Fallback: return empty string if pattern not found
-    console.warn(`Pattern not found for construction: ${constructionName}, formality: ${formality}`);
-    return '';
+    });
+    clearPracticeResult();
 }
 
-// Convert verb to masu form (simplified - uses API response when available)
-function convertToMasuForm(verb) {
-    // This is a simplified conversion - in practice, we'd use the API response
-    // For now, just return the verb as-is if it doesn't already end with ます
-    if (verb.endsWith('ます') || verb.endsWith('です')) {
-        return verb;
+// Check a practice answer against a formality's cell, in kanji or kana
+function checkPracticeAnswer(formality, answer) {
+    const cell = document.getElementById(formality + 'Cell');
+    const result = document.getElementById('gridPracticeResult');
+    if (!cell || !result || !answer) {
+        return;
     }
-    // Try to get from API response if available
-    if (currentVerbData && currentVerbData.conjugations && 
-        currentVerbData.conjugations.tenses && 
-        currentVerbData.conjugations.tenses.time && 
-        currentVerbData.conjugations.tenses.time.present) {
-        // Check if there's a polite version
-        const present = currentVerbData.conjugations.tenses.time.present;
-        if (present.japanese && present.japanese.endsWith('ます')) {
-            return present.japanese;
-        }
+    const answers = JSON.parse(cell.dataset.answers || '[]');
+    if (answers.includes(answer.trim())) {
+        result.textContent = '✓ Correct';
+        result.style.color = '#28a745';
+    } else {
+        result.textContent = '✗ The answer is ' + answers.join(' / ');
+        result.style.color = '#dc3545';
     }
-    return verb;
 }
 
-// Convert to mashou form (volitional polite)
-function convertToMashouForm(verb) {
-    if (verb.endsWith('ましょう')) {
-        return verb;
+function clearPracticeResult() {
+    const result = document.getElementById('gridPracticeResult');
+    if (result) {
+        result.textContent = '';
     }
-    // Try to get from API response
-    if (currentVerbData && currentVerbData.conjugations && 
-        currentVerbData.conjugations.tenses && 
-        currentVerbData.conjugations.tenses.mood && 
-        currentVerbData.conjugations.tenses.mood.volitional) {
-        const volitional = currentVerbData.conjugations.tenses.mood.volitional;
-        if (volitional.japanese && volitional.japanese.endsWith('ましょう')) {
-            return volitional.japanese;
-        }
-    }
-    return verb;
-}
-
-// Update all grid cells with conjugated forms
-function updateGridCells() {
-    const formalities = ['casual', 'standard', 'polite', 'formal'];
-
-    formalities.forEach(formality => {
-        const cell = document.getElementById(formality + 'Cell');
-        if (cell) {
-            const conjugated = getConjugatedForm(currentMood, formality);
-            cell.textContent = conjugated || '';
-        }
-    });
 }
 
 // Handle verb input form submission
@@ -1326,16 +953,22 @@ document.addEventListener('DOMContentLoaded', function() {
 
     async function conjugateVerb(verb) {
         try {
-            // Call API without polite flag first to get casual forms
-            const response = await fetch('/api/verb/conjugate', {
+            const response = await fetch('/api/verb/grid', {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',
                 },
-                body: JSON.stringify({ 
+                body: JSON.stringify({
                     verb: verb,
+                    potential: controls.potential,
+                    passive: controls.passive,
+                    causative: controls.causative,
+                    continuous: controls.continuous,
+                    completion: controls.completion,
+                    resultant: controls.resultant,
+                    past: controls.past,
                     negative: controls.negative,
-                    polite: false
+                    mood: currentMood || 'plain'
                 })
             });
 
@@ -1346,28 +979,9 @@ document.addEventListener('DOMContentLoaded', function() {
                 return;
             }
 
-            // Also get polite forms
-            const politeResponse = await fetch('/api/verb/conjugate', {
-                method: 'POST',
-                headers: {
-                    'Content-Type': 'application/json',
-                },
-                body: JSON.stringify({ 
-                    verb: verb,
-                    negative: controls.negative,
-                    polite: true
-                })
-            });
-
-            const politeData = await politeResponse.json();
-            
-            // Merge polite forms into the data
-            if (politeData.valid && politeData.conjugations) {
-                data.politeConjugations = politeData.conjugations;
-            }
-
+            hideVerbError();
             currentVerb = verb;
-            currentVerbData = data;
+            currentCells = data.cells || {};
             updateGridCells();
 
         } catch (error) {
@@ -1376,9 +990,21 @@ document.addEventListener('DOMContentLoaded', function() {
         }
     }
     
+    const practiceForm = document.getElementById('gridPracticeForm');
+    if (practiceForm) {
+        practiceForm.addEventListener('submit', function(e) {
+            e.preventDefault();
+            checkPracticeAnswer(
+                document.getElementById('gridPracticeFormality').value,
+                document.getElementById('gridPracticeInput').value
+            );
+        });
+    }
+
     // Make conjugateVerb available globally for toggleControl
     conjugateVerbGlobal = conjugateVerb;
     window.conjugateVerb = conjugateVerb;
     window.toggleMode = toggleMode;
+    window.togglePracticeMode = togglePracticeMode;
 });
 